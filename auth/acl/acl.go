@@ -0,0 +1,122 @@
+package acl
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-chassis/go-chassis/auth"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/security/common"
+)
+
+// constants for acl plugin
+const (
+	// Name is name of acl auth plugin
+	Name = "acl"
+	// Any matches any source service in rule key, or any operation in rule value
+	Any = "*"
+)
+
+// rules is allow and deny lists loaded from auth.yaml
+type rules struct {
+	requireIdentity bool
+	allow           map[string][]string
+	deny            map[string][]string
+}
+
+var (
+	current     atomic.Value
+	currentOnce sync.Once
+)
+
+// Refresh loads rules under cse.auth.acl again, it is called when rules change
+func Refresh() {
+	s := config.GetACLSettings()
+	Update(s)
+	lager.Logger.Infof("acl rules refreshed, %d allow and %d deny rules", len(s.Allow), len(s.Deny))
+}
+
+// Update replaces rules in use
+func Update(s config.ACLSettings) {
+	current.Store(&rules{requireIdentity: s.RequireIdentity, allow: s.Allow, deny: s.Deny})
+}
+
+func getRules() *rules {
+	currentOnce.Do(func() {
+		if current.Load() == nil {
+			Refresh()
+		}
+	})
+	return current.Load().(*rules)
+}
+
+// ACL authorizes caller by allow and deny rules of source service and operation,
+// deny rules are checked first, then caller must match one of allow rules if there is any
+type ACL struct{}
+
+// CheckAuthorization returns auth.ErrUnauthenticated if identity is required and caller is not authenticated,
+// returns auth.ErrForbidden if caller is denied.
+// source service of authenticated caller is taken from its identity, source service claimed in header is not trusted
+func (a *ACL) CheckAuthorization(check *auth.Check) *auth.CheckResult {
+	r := getRules()
+	source := check.SourceService
+	if check.SourceIdentity != "" {
+		source = common.IdentityServiceName(check.SourceIdentity)
+		if r.requireIdentity && check.SourceService != "" && check.SourceService != source {
+			return &auth.CheckResult{Message: "source service does not match caller certificate", Err: auth.ErrUnauthenticated}
+		}
+	} else if r.requireIdentity {
+		return &auth.CheckResult{Message: "caller certificate is required", Err: auth.ErrUnauthenticated}
+	}
+	operation := check.TargetSchema + "." + check.TargetMethod
+	if match(r.deny, source, operation) {
+		return &auth.CheckResult{Message: "denied by acl rule", Err: auth.ErrForbidden}
+	}
+	if len(r.allow) != 0 && !match(r.allow, source, operation) {
+		return &auth.CheckResult{Message: "not allowed by any acl rule", Err: auth.ErrForbidden}
+	}
+	return &auth.CheckResult{}
+}
+
+// GetAPICertification is not supported by acl
+func (a *ACL) GetAPICertification(ak, sk, project string) (*auth.Cert, error) {
+	return nil, errors.New("acl plugin does not support API certification")
+}
+
+// match returns true if rules of source, or rules of any source contain operation
+func match(rules map[string][]string, source, operation string) bool {
+	sources := []string{Any}
+	if source != "" {
+		sources = append(sources, source)
+	}
+	for _, s := range sources {
+		for _, pattern := range rules[s] {
+			if matchOperation(pattern, operation) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchOperation matches schema.operation, schema.* or *
+func matchOperation(pattern, operation string) bool {
+	if pattern == Any || pattern == operation {
+		return true
+	}
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(operation, strings.TrimSuffix(pattern, "*"))
+	}
+	return false
+}
+
+func newACL(role, service string, props map[string]string) auth.Auth {
+	return &ACL{}
+}
+
+func init() {
+	auth.InstallPlugin(Name, newACL)
+}
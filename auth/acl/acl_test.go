@@ -0,0 +1,68 @@
+package acl_test
+
+import (
+	"testing"
+
+	"github.com/go-chassis/go-chassis/auth"
+	"github.com/go-chassis/go-chassis/auth/acl"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func check(source, identity, schema, operation string) error {
+	a := auth.GetPlugin(acl.Name)("Provider", "Server", nil)
+	return a.CheckAuthorization(&auth.Check{
+		SourceService:  source,
+		SourceIdentity: identity,
+		TargetSchema:   schema,
+		TargetMethod:   operation,
+	}).Err
+}
+
+func TestACL_CheckAuthorization(t *testing.T) {
+	// no rules
+	acl.Update(config.ACLSettings{})
+	assert.NoError(t, check("Client", "", "HelloSchema", "SayHello"))
+	assert.NoError(t, check("", "", "HelloSchema", "SayHello"))
+
+	acl.Update(config.ACLSettings{
+		Allow: map[string][]string{
+			"Client": {"HelloSchema.*"},
+			acl.Any:  {"HealthSchema.Check"},
+		},
+		Deny: map[string][]string{
+			"Client": {"HelloSchema.Delete"},
+			"Bad":    {acl.Any},
+		},
+	})
+	assert.NoError(t, check("Client", "", "HelloSchema", "SayHello"))
+	assert.Equal(t, auth.ErrForbidden, check("Client", "", "HelloSchema", "Delete"))
+	assert.Equal(t, auth.ErrForbidden, check("Client", "", "OtherSchema", "SayHello"))
+	assert.NoError(t, check("Other", "", "HealthSchema", "Check"))
+	assert.NoError(t, check("", "", "HealthSchema", "Check"))
+	assert.Equal(t, auth.ErrForbidden, check("Other", "", "HelloSchema", "SayHello"))
+	assert.Equal(t, auth.ErrForbidden, check("Bad", "", "HealthSchema", "Check"))
+	// schema prefix does not match another schema
+	assert.Equal(t, auth.ErrForbidden, check("Client", "", "HelloSchemaV2", "SayHello"))
+
+	acl.Update(config.ACLSettings{RequireIdentity: true})
+	assert.Equal(t, auth.ErrUnauthenticated, check("Client", "", "HelloSchema", "SayHello"))
+	assert.NoError(t, check("Client", "spiffe://cluster.local/ns/default/sa/Client", "HelloSchema", "SayHello"))
+
+	// source service claimed in header is not trusted if caller is authenticated
+	acl.Update(config.ACLSettings{
+		Allow: map[string][]string{"Client": {"HelloSchema.*"}},
+	})
+	assert.NoError(t, check("", "spiffe://cluster.local/ns/default/sa/Client", "HelloSchema", "SayHello"))
+	assert.Equal(t, auth.ErrForbidden, check("Client", "spiffe://cluster.local/ns/default/sa/Other", "HelloSchema", "SayHello"))
+	acl.Update(config.ACLSettings{
+		RequireIdentity: true,
+		Allow:           map[string][]string{"Client": {"HelloSchema.*"}},
+	})
+	assert.Equal(t, auth.ErrUnauthenticated, check("Client", "spiffe://cluster.local/ns/default/sa/Other", "HelloSchema", "SayHello"))
+	assert.Equal(t, auth.ErrUnauthenticated, check("Client", "spiffe://cluster.local", "HelloSchema", "SayHello"))
+	assert.NoError(t, check("Client", "Client.default.svc", "HelloSchema", "SayHello"))
+
+	_, err := auth.GetPlugin(acl.Name)("Provider", "Server", nil).GetAPICertification("", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package aksk_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/auth/aksk"
+	_ "github.com/go-chassis/go-chassis/security/plugins/plain"
+	"github.com/stretchr/testify/assert"
+)
+
+type keys map[string]string
+
+func (k keys) SecretKey(accessKey string) (string, error) {
+	return k[accessKey], nil
+}
+
+func newRequest(body string) (*aksk.Request, http.Header) {
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	return &aksk.Request{
+		Method: http.MethodPost,
+		Path:   "/hello",
+		Query:  url.Values{"b": {"2"}, "a": {"1"}},
+		Header: h.Get,
+		Body:   []byte(body),
+	}, h
+}
+
+func sign(t *testing.T, r *aksk.Request, h http.Header, sk string, now time.Time) {
+	headers, err := aksk.Sign(r, "ak", sk, []string{"Content-Type", "content-type"}, now)
+	assert.NoError(t, err)
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	v := aksk.NewVerifier(keys{"ak": "sk"}, time.Minute)
+
+	r, h := newRequest(`{"name":"peter"}`)
+	sign(t, r, h, "sk", time.Now())
+	assert.Equal(t, "content-type", h.Get(aksk.HeaderSignedHeaders))
+	ak, err := v.Verify(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", ak)
+	// same nonce is rejected
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrReplayedRequest, err)
+
+	// tampered body, path, query and header
+	r, h = newRequest(`{"name":"peter"}`)
+	sign(t, r, h, "sk", time.Now())
+	r.Body = []byte(`{"name":"paul"}`)
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now())
+	r.Path = "/bye"
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now())
+	r.Query.Set("a", "3")
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now())
+	h.Set("Content-Type", "text/plain")
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now())
+	h.Set(aksk.HeaderSignedHeaders, "")
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+	h.Set(aksk.HeaderSignedHeaders, "Content-Type")
+	_, err = v.Verify(r)
+	assert.Error(t, err)
+
+	// wrong secret key
+	r, h = newRequest("")
+	sign(t, r, h, "other", time.Now())
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidSignature, err)
+
+	// unknown access key
+	v = aksk.NewVerifier(keys{}, time.Minute)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now())
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrUnknownAccessKey, err)
+
+	// timestamp out of range
+	v = aksk.NewVerifier(keys{"ak": "sk"}, time.Minute)
+	r, h = newRequest("")
+	sign(t, r, h, "sk", time.Now().Add(-2*time.Minute))
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidTimestamp, err)
+	h.Set(aksk.HeaderTimestamp, strconv.FormatInt(time.Now().Add(2*time.Minute).Unix(), 10))
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrInvalidTimestamp, err)
+
+	r, _ = newRequest("")
+	_, err = v.Verify(r)
+	assert.Equal(t, aksk.ErrNoSignature, err)
+
+	_, err = aksk.Sign(r, "", "sk", nil, time.Now())
+	assert.Equal(t, aksk.ErrEmptyCredentials, err)
+}
+
+func TestDecryptSecretKey(t *testing.T) {
+	sk, err := aksk.DecryptSecretKey("sk", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "sk", sk)
+}
@@ -0,0 +1,65 @@
+package aksk
+
+import (
+	"fmt"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/security"
+)
+
+// ConfigKeyStoreName is name of key store reading secret keys from config
+const ConfigKeyStoreName = "config"
+
+// KeyStore returns secret key of access key, it is used by provider to verify signature
+type KeyStore interface {
+	SecretKey(accessKey string) (string, error)
+}
+
+var keyStores = make(map[string]func() (KeyStore, error))
+
+//InstallPlugin install key store plugin
+func InstallPlugin(name string, f func() (KeyStore, error)) {
+	keyStores[name] = f
+}
+
+//GetPlugin return key store plugin
+func GetPlugin(name string) func() (KeyStore, error) {
+	return keyStores[name]
+}
+
+// DecryptSecretKey decrypts secret key with cipher plugin, empty cipher name means default plain cipher
+func DecryptSecretKey(secretKey, cipherName string) (string, error) {
+	if cipherName == "" {
+		cipherName = config.DefaultAKSKCipher
+	}
+	f, err := security.GetCipherNewFunc(cipherName)
+	if err != nil {
+		return "", err
+	}
+	c := f()
+	if c == nil {
+		return "", fmt.Errorf("invalid cipher plugin [%s]", cipherName)
+	}
+	return c.Decrypt(secretKey)
+}
+
+// ConfigKeyStore reads secret keys of cse.auth.aksk.keys.{accessKey},
+// they are decrypted by cipher of cse.credentials.akskCustomCipher, changes of config take effect at once
+type ConfigKeyStore struct{}
+
+// SecretKey returns decrypted secret key of access key
+func (s *ConfigKeyStore) SecretKey(accessKey string) (string, error) {
+	sk := config.GetAKSKSecretKey(accessKey)
+	if sk == "" {
+		return "", ErrUnknownAccessKey
+	}
+	return DecryptSecretKey(sk, config.GetAKSKConfig().Cipher)
+}
+
+func newConfigKeyStore() (KeyStore, error) {
+	return &ConfigKeyStore{}, nil
+}
+
+func init() {
+	InstallPlugin(ConfigKeyStoreName, newConfigKeyStore)
+}
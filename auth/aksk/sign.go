@@ -0,0 +1,111 @@
+// Package aksk signs requests between chassis services with HMAC-SHA256 of access key and secret key
+package aksk
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers of signed request
+const (
+	HeaderAccessKey     = "X-Cse-Access-Key"
+	HeaderTimestamp     = "X-Cse-Timestamp"
+	HeaderNonce         = "X-Cse-Nonce"
+	HeaderSignedHeaders = "X-Cse-Signed-Headers"
+	HeaderSignature     = "X-Cse-Signature"
+)
+
+// Algorithm is name and version of signing scheme, it is the first line of string to sign
+const Algorithm = "CHASSIS-HMAC-SHA256"
+
+// MetadataAccessKey is invocation metadata key of verified access key
+const MetadataAccessKey = "accessKey"
+
+// errors of signature verification
+var (
+	ErrNoSignature       = errors.New("request is not signed")
+	ErrUnknownAccessKey  = errors.New("unknown access key")
+	ErrInvalidSignature  = errors.New("invalid request signature")
+	ErrInvalidTimestamp  = errors.New("request timestamp is out of range")
+	ErrReplayedRequest   = errors.New("replayed request")
+	ErrEmptyCredentials  = errors.New("access key or secret key is empty")
+	ErrKeyStoreNotFound  = errors.New("key store is not installed")
+	ErrNotRestRequest    = errors.New("only rest request can be signed, highway args are not covered by signature")
+	errInvalidHeaderList = errors.New("invalid signed headers")
+)
+
+// Request is the part of a rest request covered by signature
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Header returns value of header, name is case insensitive
+	Header func(name string) string
+	Body   []byte
+}
+
+// StringToSign returns canonical string of r, signed headers are lower case and sorted
+func StringToSign(r *Request, signedHeaders []string, timestamp, nonce string) string {
+	path := r.Path
+	if path == "" {
+		path = "/"
+	}
+	lines := []string{Algorithm, strings.ToUpper(r.Method), path, r.Query.Encode()}
+	for _, h := range signedHeaders {
+		lines = append(lines, h+":"+strings.TrimSpace(r.Header(h)))
+	}
+	body := sha256.Sum256(r.Body)
+	lines = append(lines, strings.Join(signedHeaders, ";"), hex.EncodeToString(body[:]), timestamp, nonce)
+	return strings.Join(lines, "\n")
+}
+
+// Signature returns hex encoded HMAC-SHA256 of s
+func Signature(secretKey, s string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign returns headers which should be added to r
+func Sign(r *Request, accessKey, secretKey string, signedHeaders []string, now time.Time) (map[string]string, error) {
+	if accessKey == "" || secretKey == "" {
+		return nil, ErrEmptyCredentials
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	nonce := hex.EncodeToString(b)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	headers := canonicalHeaders(signedHeaders)
+	return map[string]string{
+		HeaderAccessKey:     accessKey,
+		HeaderTimestamp:     timestamp,
+		HeaderNonce:         nonce,
+		HeaderSignedHeaders: strings.Join(headers, ";"),
+		HeaderSignature:     Signature(secretKey, StringToSign(r, headers, timestamp, nonce)),
+	}, nil
+}
+
+// canonicalHeaders returns lower case, sorted and distinct header names
+func canonicalHeaders(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	headers := make([]string, 0, len(names))
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n != "" && !seen[n] {
+			seen[n] = true
+			headers = append(headers, n)
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
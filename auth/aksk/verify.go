@@ -0,0 +1,109 @@
+package aksk
+
+import (
+	"crypto/hmac"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxClockSkew is default max difference between request timestamp and local time
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// Verifier checks signature and timestamp of request, nonce of a request is remembered until its timestamp is out of range,
+// so that a captured request can not be replayed to the same instance
+type Verifier struct {
+	keys         KeyStore
+	maxClockSkew time.Duration
+	nonces       *nonceCache
+}
+
+// NewVerifier returns verifier reading secret keys from keys
+func NewVerifier(keys KeyStore, maxClockSkew time.Duration) *Verifier {
+	if maxClockSkew <= 0 {
+		maxClockSkew = DefaultMaxClockSkew
+	}
+	return &Verifier{
+		keys:         keys,
+		maxClockSkew: maxClockSkew,
+		nonces:       &nonceCache{nonces: make(map[string]time.Time)},
+	}
+}
+
+// Verify returns access key of r if signature is valid
+func (v *Verifier) Verify(r *Request) (string, error) {
+	accessKey := r.Header(HeaderAccessKey)
+	signature := r.Header(HeaderSignature)
+	timestamp := r.Header(HeaderTimestamp)
+	nonce := r.Header(HeaderNonce)
+	if accessKey == "" || signature == "" || timestamp == "" || nonce == "" {
+		return "", ErrNoSignature
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrInvalidTimestamp
+	}
+	now := time.Now()
+	t := time.Unix(sec, 0)
+	if t.Before(now.Add(-v.maxClockSkew)) || t.After(now.Add(v.maxClockSkew)) {
+		return "", ErrInvalidTimestamp
+	}
+	headers, err := parseSignedHeaders(r.Header(HeaderSignedHeaders))
+	if err != nil {
+		return "", err
+	}
+	secretKey, err := v.keys.SecretKey(accessKey)
+	if err != nil || secretKey == "" {
+		return "", ErrUnknownAccessKey
+	}
+	expected := Signature(secretKey, StringToSign(r, headers, timestamp, nonce))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return "", ErrInvalidSignature
+	}
+	// nonce is recorded after signature is verified, so that forged requests do not fill the cache
+	if !v.nonces.add(accessKey+":"+nonce, t.Add(v.maxClockSkew)) {
+		return "", ErrReplayedRequest
+	}
+	return accessKey, nil
+}
+
+// parseSignedHeaders requires names to be canonical, so that signature covers exactly the same headers
+func parseSignedHeaders(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	names := strings.Split(s, ";")
+	canonical := canonicalHeaders(names)
+	if strings.Join(canonical, ";") != s {
+		return nil, errInvalidHeaderList
+	}
+	return canonical, nil
+}
+
+// nonceCache remembers nonces until they expire
+type nonceCache struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	nextSweep time.Time
+}
+
+// add returns false if nonce exists
+func (c *nonceCache) add(nonce string, expireAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.After(c.nextSweep) {
+		for n, e := range c.nonces {
+			if now.After(e) {
+				delete(c.nonces, n)
+			}
+		}
+		c.nextSweep = now.Add(time.Minute)
+	}
+	if e, ok := c.nonces[nonce]; ok && !now.After(e) {
+		return false
+	}
+	c.nonces[nonce] = expireAt
+	return true
+}
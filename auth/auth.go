@@ -1,5 +1,13 @@
 package auth
 
+import "errors"
+
+// errors returned in CheckResult, ErrUnauthenticated is responded with 401, other errors with 403
+var (
+	ErrUnauthenticated = errors.New("caller is not authenticated")
+	ErrForbidden       = errors.New("caller has no permission")
+)
+
 var authPlugin = make(map[string]func(role, service string, props map[string]string) Auth)
 
 //InstallPlugin install auth plugin
@@ -15,8 +23,15 @@ func GetPlugin(name string) func(role, service string, props map[string]string)
 // Check includes information to be checked by auth service
 type Check struct {
 	TargetService           string
-	TargetMethod            string
+	TargetSchema            string
+	TargetMethod            string //operation id
 	TargetServiceProperties map[string]string
+	// SourceService is service name claimed by caller, or taken from SourceIdentity
+	SourceService string
+	// SourceIdentity is identity of verified mutual tls peer certificate, it is empty if caller is not authenticated
+	SourceIdentity string
+	Protocol       string
+	Headers        map[string]string
 }
 
 //CheckResult is returned by auth service
@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type tokenKey struct{}
+
+// WithToken returns a copy of ctx holding token of caller,
+// consumer forwards it to providers if token forwarding is enabled
+func WithToken(ctx context.Context, token string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext returns token stored by WithToken
+func TokenFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+// BearerToken returns token of authorization header value, it returns empty if scheme is not Bearer
+func BearerToken(authorization string) string {
+	const prefix = "bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
+
+var scopes = struct {
+	sync.RWMutex
+	m map[string][]string
+}{m: make(map[string][]string)}
+
+// RequireScopes declares scopes which token must have to call the operation
+func RequireScopes(schemaID, operationID string, required ...string) {
+	scopes.Lock()
+	scopes.m[schemaID+"."+operationID] = required
+	scopes.Unlock()
+}
+
+// RequiredScopes returns scopes declared by RequireScopes
+func RequiredScopes(schemaID, operationID string) []string {
+	scopes.RLock()
+	defer scopes.RUnlock()
+	return scopes.m[schemaID+"."+operationID]
+}
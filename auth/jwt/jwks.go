@@ -0,0 +1,279 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// DefaultJWKSRefreshInterval is default interval of reloading JWKS
+const DefaultJWKSRefreshInterval = 5 * time.Minute
+
+// minRefreshInterval limits reloading when tokens of unknown key id arrive
+const minRefreshInterval = 10 * time.Second
+
+// backoff bounds of retrying after loading fails
+const (
+	minRetryInterval = time.Second
+	maxRetryInterval = time.Minute
+)
+
+// JSONWebKey is a public key of JWKS
+type JSONWebKey struct {
+	KeyID     string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// KeySet returns key to verify signature, kid is key id in token header
+type KeySet interface {
+	Key(kid string) (*JSONWebKey, error)
+}
+
+type rawKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS parses RSA and EC signing keys of JWKS document, other keys are skipped
+func ParseJWKS(data []byte) ([]*JSONWebKey, error) {
+	var doc struct {
+		Keys []rawKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	keys := make([]*JSONWebKey, 0, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parse key [%s] failed, %s", k.Kid, err)
+		}
+		if pub == nil {
+			continue
+		}
+		keys = append(keys, &JSONWebKey{KeyID: k.Kid, Algorithm: k.Alg, Key: pub})
+	}
+	return keys, nil
+}
+
+func (k rawKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// JWKS loads keys from a file or an http url, keys are cached and reloaded after refreshInterval,
+// or when a token is signed by an unknown key, so that keys rotated by issuer are picked up.
+// concurrent callers share one loading, which is done without holding the lock,
+// and loading is retried with exponential backoff after it fails
+type JWKS struct {
+	source          string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	mu   sync.Mutex
+	keys []*JSONWebKey
+	// fetched is time of the last successful loading, attempted is time of the last loading
+	fetched   time.Time
+	attempted time.Time
+	// failures is count of successive failed loadings, loading is not tried before retryAt
+	failures int
+	retryAt  time.Time
+	// loading is closed when the loading in flight is done
+	loading chan struct{}
+}
+
+// NewJWKS returns JWKS of source, which is a file path or an http(s) url
+func NewJWKS(source string, refreshInterval time.Duration) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+	return &JWKS{
+		source:          source,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key returns key of kid, it returns the only key if kid is empty and there is one key
+func (s *JWKS) Key(kid string) (*JSONWebKey, error) {
+	s.mu.Lock()
+	stale := s.keys == nil || time.Since(s.fetched) > s.refreshInterval
+	s.mu.Unlock()
+	if stale {
+		s.refresh()
+	}
+
+	s.mu.Lock()
+	k := s.find(kid)
+	retry := k == nil && !stale && time.Since(s.attempted) > minRefreshInterval
+	s.mu.Unlock()
+	if k != nil {
+		return k, nil
+	}
+	if retry {
+		s.refresh()
+		s.mu.Lock()
+		k = s.find(kid)
+		s.mu.Unlock()
+		if k != nil {
+			return k, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// find must be called with mu locked
+func (s *JWKS) find(kid string) *JSONWebKey {
+	if kid == "" && len(s.keys) == 1 {
+		return s.keys[0]
+	}
+	for _, k := range s.keys {
+		if k.KeyID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// refresh loads keys, callers during loading wait for it instead of loading again,
+// keys loaded last time are kept if loading fails
+func (s *JWKS) refresh() {
+	s.mu.Lock()
+	if done := s.loading; done != nil {
+		s.mu.Unlock()
+		<-done
+		return
+	}
+	if time.Now().Before(s.retryAt) {
+		s.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	s.loading = done
+	s.mu.Unlock()
+
+	keys, err := s.fetch()
+
+	s.mu.Lock()
+	now := time.Now()
+	s.attempted = now
+	if err != nil {
+		s.failures++
+		s.retryAt = now.Add(retryInterval(s.failures))
+	} else {
+		s.keys = keys
+		s.fetched = now
+		s.failures = 0
+		s.retryAt = time.Time{}
+	}
+	s.loading = nil
+	s.mu.Unlock()
+	close(done)
+}
+
+// retryInterval doubles from minRetryInterval for each failure, up to maxRetryInterval
+func retryInterval(failures int) time.Duration {
+	d := minRetryInterval
+	for i := 1; i < failures && d < maxRetryInterval; i++ {
+		d *= 2
+	}
+	if d > maxRetryInterval {
+		d = maxRetryInterval
+	}
+	return d
+}
+
+func (s *JWKS) fetch() ([]*JSONWebKey, error) {
+	data, err := s.load()
+	if err != nil {
+		lager.Logger.Errorf(err, "load jwks from [%s] failed", s.source)
+		return nil, err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		lager.Logger.Errorf(err, "parse jwks from [%s] failed", s.source)
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *JWKS) load() ([]byte, error) {
+	if !strings.HasPrefix(s.source, "http://") && !strings.HasPrefix(s.source, "https://") {
+		return ioutil.ReadFile(s.source)
+	}
+	resp, err := s.client.Get(s.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get jwks failed, status %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,275 @@
+// Package jwt validates JSON web tokens signed with RSA or ECDSA keys of a JWKS
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	// hash functions of RS, PS and ES algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errors of token validation
+var (
+	ErrNoToken              = errors.New("no bearer token")
+	ErrMalformedToken       = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrKeyNotFound          = errors.New("signing key not found")
+	ErrInvalidSignature     = errors.New("invalid token signature")
+	ErrMissingExpiry        = errors.New("token has no exp claim")
+	ErrTokenExpired         = errors.New("token is expired")
+	ErrTokenNotValidYet     = errors.New("token is not valid yet")
+	ErrInvalidIssuer        = errors.New("invalid token issuer")
+	ErrInvalidAudience      = errors.New("invalid token audience")
+	ErrInsufficientScope    = errors.New("insufficient token scope")
+)
+
+// Claims is payload of token, numbers are json.Number
+type Claims map[string]interface{}
+
+// String returns claim as a string, list is joined by comma
+func (c Claims) String(name string) string {
+	switch v := c[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		s := make([]string, 0, len(v))
+		for _, e := range v {
+			s = append(s, fmt.Sprint(e))
+		}
+		return strings.Join(s, ",")
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// Subject returns sub claim
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// Issuer returns iss claim
+func (c Claims) Issuer() string {
+	return c.String("iss")
+}
+
+// Audience returns aud claim, which is a string or a list
+func (c Claims) Audience() []string {
+	return c.strings("aud", "")
+}
+
+// Scopes returns space separated scope claim, or scp claim which is a list
+func (c Claims) Scopes() []string {
+	if _, ok := c["scope"]; ok {
+		return c.strings("scope", " ")
+	}
+	return c.strings("scp", " ")
+}
+
+// HasScopes returns true if token has all of scopes
+func (c Claims) HasScopes(scopes []string) bool {
+	granted := make(map[string]bool)
+	for _, s := range c.Scopes() {
+		granted[s] = true
+	}
+	for _, s := range scopes {
+		if !granted[s] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Claims) strings(name, sep string) []string {
+	switch v := c[name].(type) {
+	case string:
+		if sep == "" {
+			return []string{v}
+		}
+		return strings.Fields(v)
+	case []interface{}:
+		s := make([]string, 0, len(v))
+		for _, e := range v {
+			if str, ok := e.(string); ok {
+				s = append(s, str)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	n, ok := c[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+// Validator checks signature, exp, nbf, iss and aud of token
+type Validator struct {
+	Keys KeySet
+	// Issuer is not checked if it is empty
+	Issuer string
+	// Audiences is not checked if it is empty, otherwise token must have one of them
+	Audiences []string
+	// ClockSkew is tolerance of exp and nbf checks
+	ClockSkew time.Duration
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Validate returns claims of token if it is valid
+func (v *Validator) Validate(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformedToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	key, err := v.Keys.Key(h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != "" && key.Algorithm != h.Alg {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	if err := verify(h.Alg, key.Key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+	claims := Claims{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if err := v.check(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Validator) check(c Claims) error {
+	now := time.Now()
+	exp, ok := c.time("exp")
+	if !ok {
+		return ErrMissingExpiry
+	}
+	if now.After(exp.Add(v.ClockSkew)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := c.time("nbf"); ok && now.Add(v.ClockSkew).Before(nbf) {
+		return ErrTokenNotValidYet
+	}
+	if v.Issuer != "" && c.Issuer() != v.Issuer {
+		return ErrInvalidIssuer
+	}
+	if len(v.Audiences) == 0 {
+		return nil
+	}
+	for _, aud := range c.Audience() {
+		for _, expected := range v.Audiences {
+			if aud == expected {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidAudience
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func hashOf(alg string) (crypto.Hash, error) {
+	if len(alg) != 5 {
+		return 0, ErrUnsupportedAlgorithm
+	}
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, nil
+	case "384":
+		return crypto.SHA384, nil
+	case "512":
+		return crypto.SHA512, nil
+	}
+	return 0, ErrUnsupportedAlgorithm
+}
+
+// verify checks signature of RS, PS and ES algorithms, none and HMAC algorithms are rejected
+func verify(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	hash, err := hashOf(alg)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+	switch alg[:2] {
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+		if alg[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+		} else {
+			err = rsa.VerifyPSS(pub, hash, digest, sig, nil)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+	return ErrUnsupportedAlgorithm
+}
@@ -0,0 +1,272 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/auth/jwt"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/stretchr/testify/assert"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func segment(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return b64(b)
+}
+
+// pad returns big endian bytes of n with length size
+func pad(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	return append(make([]byte, size-len(b)), b...)
+}
+
+type signer struct {
+	kid string
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func (s *signer) sign(t *testing.T, claims map[string]interface{}) string {
+	alg := "RS256"
+	if s.ec != nil {
+		alg = "ES256"
+	}
+	signed := segment(t, map[string]string{"alg": alg, "kid": s.kid, "typ": "JWT"}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	if s.ec != nil {
+		r, ss, err := ecdsa.Sign(rand.Reader, s.ec, digest[:])
+		assert.NoError(t, err)
+		sig = append(pad(r, 32), pad(ss, 32)...)
+	} else {
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, s.rsa, crypto.SHA256, digest[:])
+		assert.NoError(t, err)
+	}
+	return signed + "." + b64(sig)
+}
+
+func (s *signer) jwk() map[string]string {
+	if s.ec != nil {
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "use": "sig",
+			"x": b64(pad(s.ec.X, 32)), "y": b64(pad(s.ec.Y, 32))}
+	}
+	return map[string]string{"kty": "RSA", "kid": s.kid, "alg": "RS256",
+		"n": b64(s.rsa.N.Bytes()), "e": b64(big.NewInt(int64(s.rsa.E)).Bytes())}
+}
+
+func newSigners(t *testing.T) (*signer, *signer) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	return &signer{kid: "rsa", rsa: rsaKey}, &signer{kid: "ec", ec: ecKey}
+}
+
+func jwksOf(t *testing.T, signers ...*signer) []byte {
+	keys := make([]map[string]string, 0, len(signers))
+	for _, s := range signers {
+		keys = append(keys, s.jwk())
+	}
+	b, err := json.Marshal(map[string]interface{}{"keys": keys})
+	assert.NoError(t, err)
+	return b
+}
+
+func claims(exp time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":   "https://issuer",
+		"sub":   "alice",
+		"aud":   []string{"Server", "Other"},
+		"exp":   time.Now().Add(exp).Unix(),
+		"scope": "read write",
+		"roles": []string{"admin", "dev"},
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	rsaSigner, ecSigner := newSigners(t)
+	dir, err := ioutil.TempDir("", "jwks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "jwks.json")
+	assert.NoError(t, ioutil.WriteFile(file, jwksOf(t, rsaSigner, ecSigner), 0600))
+
+	v := &jwt.Validator{
+		Keys:      jwt.NewJWKS(file, time.Minute),
+		Issuer:    "https://issuer",
+		Audiences: []string{"Server"},
+	}
+	for _, s := range []*signer{rsaSigner, ecSigner} {
+		c, err := v.Validate(s.sign(t, claims(time.Minute)))
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", c.Subject())
+		assert.Equal(t, "admin,dev", c.String("roles"))
+		assert.Equal(t, []string{"read", "write"}, c.Scopes())
+		assert.True(t, c.HasScopes([]string{"read"}))
+		assert.False(t, c.HasScopes([]string{"read", "delete"}))
+	}
+
+	_, err = v.Validate(rsaSigner.sign(t, claims(-time.Minute)))
+	assert.Equal(t, jwt.ErrTokenExpired, err)
+	c := claims(time.Minute)
+	delete(c, "exp")
+	_, err = v.Validate(rsaSigner.sign(t, c))
+	assert.Equal(t, jwt.ErrMissingExpiry, err)
+	c = claims(time.Minute)
+	c["nbf"] = time.Now().Add(time.Minute).Unix()
+	_, err = v.Validate(rsaSigner.sign(t, c))
+	assert.Equal(t, jwt.ErrTokenNotValidYet, err)
+	c = claims(time.Minute)
+	c["iss"] = "https://other"
+	_, err = v.Validate(rsaSigner.sign(t, c))
+	assert.Equal(t, jwt.ErrInvalidIssuer, err)
+	c = claims(time.Minute)
+	c["aud"] = "Other"
+	_, err = v.Validate(rsaSigner.sign(t, c))
+	assert.Equal(t, jwt.ErrInvalidAudience, err)
+
+	// clock skew tolerates expiry
+	v.ClockSkew = 2 * time.Minute
+	_, err = v.Validate(rsaSigner.sign(t, claims(-time.Minute)))
+	assert.NoError(t, err)
+
+	// signature of another key
+	token := rsaSigner.sign(t, claims(time.Minute))
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, err = v.Validate((&signer{kid: "rsa", rsa: otherKey}).sign(t, claims(time.Minute)))
+	assert.Equal(t, jwt.ErrInvalidSignature, err)
+	_, err = v.Validate((&signer{kid: "unknown", rsa: otherKey}).sign(t, claims(time.Minute)))
+	assert.Equal(t, jwt.ErrKeyNotFound, err)
+
+	// alg none is rejected
+	none := segment(t, map[string]string{"alg": "none", "kid": "rsa"}) + "." + segment(t, claims(time.Minute)) + "."
+	_, err = v.Validate(none)
+	assert.Equal(t, jwt.ErrUnsupportedAlgorithm, err)
+
+	_, err = v.Validate("a.b")
+	assert.Equal(t, jwt.ErrMalformedToken, err)
+	_, err = v.Validate(token + "x")
+	assert.Error(t, err)
+}
+
+func TestJWKS_URL(t *testing.T) {
+	rsaSigner, ecSigner := newSigners(t)
+	var hits int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write(jwksOf(t, rsaSigner))
+	}))
+	defer s.Close()
+
+	keys := jwt.NewJWKS(s.URL, time.Minute)
+	k, err := keys.Key("rsa")
+	assert.NoError(t, err)
+	assert.Equal(t, "RS256", k.Algorithm)
+	// only key is used if token has no kid
+	_, err = keys.Key("")
+	assert.NoError(t, err)
+	_, err = keys.Key(ecSigner.kid)
+	assert.Equal(t, jwt.ErrKeyNotFound, err)
+	// keys are cached
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestJWKS_ConcurrentRefresh(t *testing.T) {
+	rsaSigner, _ := newSigners(t)
+	var hits int32
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Write(jwksOf(t, rsaSigner))
+	}))
+	defer s.Close()
+
+	keys := jwt.NewJWKS(s.URL, time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.Key("rsa")
+			assert.NoError(t, err)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestJWKS_Backoff(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	var hits int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	keys := jwt.NewJWKS(s.URL, time.Minute)
+	for i := 0; i < 5; i++ {
+		_, err := keys.Key("rsa")
+		assert.Equal(t, jwt.ErrKeyNotFound, err)
+	}
+	// loading is not retried before backoff expires
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	time.Sleep(1100 * time.Millisecond)
+	_, err := keys.Key("rsa")
+	assert.Equal(t, jwt.ErrKeyNotFound, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestParseJWKS(t *testing.T) {
+	_, err := jwt.ParseJWKS([]byte("{"))
+	assert.Error(t, err)
+	keys, err := jwt.ParseJWKS([]byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"},{"kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"}]}`))
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	_, err = jwt.ParseJWKS([]byte(`{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`))
+	assert.Error(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "abc", jwt.BearerToken("Bearer abc"))
+	assert.Equal(t, "abc", jwt.BearerToken("bearer abc"))
+	assert.Equal(t, "", jwt.BearerToken("Basic abc"))
+	assert.Equal(t, "", jwt.BearerToken("Bearer "))
+	assert.Equal(t, "", jwt.BearerToken(""))
+}
+
+func TestRequiredScopes(t *testing.T) {
+	jwt.RequireScopes("HelloSchema", "SayHello", "read")
+	assert.Equal(t, []string{"read"}, jwt.RequiredScopes("HelloSchema", "SayHello"))
+	assert.Empty(t, jwt.RequiredScopes("HelloSchema", "SayBye"))
+	ctx := jwt.WithToken(context.Background(), "abc")
+	assert.Equal(t, "abc", jwt.TokenFromContext(ctx))
+	assert.Equal(t, "", jwt.TokenFromContext(context.Background()))
+}
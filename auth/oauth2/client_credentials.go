@@ -0,0 +1,117 @@
+package oauth2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+)
+
+// ClientCredentialsName is name of client credentials token source plugin
+const ClientCredentialsName = "clientCredentials"
+
+// expiryDelta is how early a token is refreshed before it expires
+const expiryDelta = 30 * time.Second
+
+// ClientCredentials gets token from token endpoint with OAuth2 client credentials grant
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Client       *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Token returns cached token, or gets a new one if it is about to expire
+func (c *ClientCredentials) Token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && (c.expiry.IsZero() || time.Now().Add(expiryDelta).Before(c.expiry)) {
+		return c.token, nil
+	}
+	t, err := c.fetch()
+	if err != nil {
+		return "", err
+	}
+	c.token = t.AccessToken
+	c.expiry = time.Time{}
+	if t.ExpiresIn > 0 {
+		c.expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return c.token, nil
+}
+
+func (c *ClientCredentials) fetch() (*tokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) != 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get token from [%s] failed, status %d: %s", c.TokenURL, resp.StatusCode, body)
+	}
+	t := &tokenResponse{}
+	if err := json.Unmarshal(body, t); err != nil {
+		return nil, err
+	}
+	if t.AccessToken == "" {
+		return nil, errors.New("token endpoint returns no access_token")
+	}
+	if t.TokenType != "" && !strings.EqualFold(t.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported token type %s", t.TokenType)
+	}
+	return t, nil
+}
+
+func newClientCredentials() (TokenSource, error) {
+	c := config.GetOAuth2ClientCredentials()
+	if c.TokenURL == "" {
+		return nil, errors.New("token url of client credentials is empty")
+	}
+	return &ClientCredentials{
+		TokenURL:     c.TokenURL,
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Scopes:       c.Scopes,
+	}, nil
+}
+
+func init() {
+	InstallPlugin(ClientCredentialsName, newClientCredentials)
+}
@@ -0,0 +1,54 @@
+package oauth2_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/auth/oauth2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCredentials_Token(t *testing.T) {
+	var hits int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		assert.Equal(t, "read write", r.FormValue("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token1","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer s.Close()
+
+	c := &oauth2.ClientCredentials{
+		TokenURL:     s.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	}
+	token, err := c.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token1", token)
+	// token is cached until it expires
+	token, err = c.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token1", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	c = &oauth2.ClientCredentials{TokenURL: s.URL, ClientID: "client", ClientSecret: "wrong"}
+	_, err = c.Token()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_client")
+}
+
+func TestGetPlugin(t *testing.T) {
+	assert.NotNil(t, oauth2.GetPlugin(oauth2.ClientCredentialsName))
+	assert.Nil(t, oauth2.GetPlugin("unknown"))
+}
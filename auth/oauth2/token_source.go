@@ -0,0 +1,19 @@
+// Package oauth2 provides token sources used by consumer to get service tokens
+package oauth2
+
+// TokenSource returns access token of the service itself, implementation caches token until it expires
+type TokenSource interface {
+	Token() (string, error)
+}
+
+var tokenSources = make(map[string]func() (TokenSource, error))
+
+//InstallPlugin install token source plugin
+func InstallPlugin(name string, f func() (TokenSource, error)) {
+	tokenSources[name] = f
+}
+
+//GetPlugin return token source plugin
+func GetPlugin(name string) func() (TokenSource, error) {
+	return tokenSources[name]
+}
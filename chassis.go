@@ -9,6 +9,9 @@ import (
 	"sync"
 	"syscall"
 
+	// auth plugins used by auth-provider handler, chosen by cse.auth.plugin
+	_ "github.com/go-chassis/go-chassis/auth/acl"
+	_ "github.com/go-chassis/go-chassis/auth/noop"
 	"github.com/go-chassis/go-chassis/bootstrap"
 	// highway package handles remote procedure calls
 	_ "github.com/go-chassis/go-chassis/client/highway"
@@ -33,10 +36,18 @@ import (
 	_ "github.com/go-chassis/go-chassis/core/registry/pilot"
 	"github.com/go-chassis/go-chassis/core/router"
 	"github.com/go-chassis/go-chassis/core/server"
+	chassisTLS "github.com/go-chassis/go-chassis/core/tls"
 	"github.com/go-chassis/go-chassis/core/tracing"
 	"github.com/go-chassis/go-chassis/eventlistener"
+	"github.com/go-chassis/go-chassis/healthz/checker"
 	// metric plugin
+	"github.com/go-chassis/go-chassis/metrics/collector"
 	_ "github.com/go-chassis/go-chassis/metrics/prom"
+	// push metrics reporters, enabled by cse.metrics.reporters.<name>.enable
+	_ "github.com/go-chassis/go-chassis/metrics/graphite"
+	_ "github.com/go-chassis/go-chassis/metrics/opentsdb"
+	_ "github.com/go-chassis/go-chassis/metrics/otlp"
+	_ "github.com/go-chassis/go-chassis/metrics/statsd"
 	// aes package handles security related plugins
 	_ "github.com/go-chassis/go-chassis/security/plugins/aes"
 	_ "github.com/go-chassis/go-chassis/security/plugins/plain"
@@ -52,6 +63,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/metadata"
 	"github.com/go-chassis/go-chassis/metrics"
 	"github.com/go-chassis/go-chassis/pkg/runtime"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var goChassis *chassis
@@ -138,6 +150,8 @@ func (c *chassis) initialize() error {
 	}
 	bootstrap.Bootstrap()
 	if archaius.GetBool("cse.service.registry.disabled", false) != true {
+		// checker must subscribe instance events before first discovery
+		checker.Init()
 		err := registry.Enable()
 		if err != nil {
 			return err
@@ -160,6 +174,16 @@ func (c *chassis) initialize() error {
 	if err = metrics.Init(); err != nil {
 		return err
 	}
+	for _, c := range chassisTLS.Collectors() {
+		if err = metrics.GetSystemPrometheusRegistry().Register(c); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				return err
+			}
+		}
+	}
+	if err = collector.Init(); err != nil {
+		return err
+	}
 	eventlistener.Init()
 	c.Initialized = true
 	return nil
@@ -218,7 +242,12 @@ func SetDefaultProviderChains(c map[string]string) {
 }
 
 //Run bring up the service,it will not return error,instead just waiting for os signal,and shutdown gracefully
-func Run() {
+//use WithoutSignalHandler if you want to handle os signals and call Shutdown by yourself
+func Run(opts ...RunOption) {
+	o := &RunOptions{SignalHandler: true}
+	for _, opt := range opts {
+		opt(o)
+	}
 	err := goChassis.start()
 	if err != nil {
 		lager.Logger.Error("run chassis fail:", err)
@@ -231,27 +260,20 @@ func Run() {
 	}
 	//Graceful shutdown
 	c := make(chan os.Signal)
-	signal.Notify(c, syscall.SIGINT, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGILL, syscall.SIGTRAP, syscall.SIGABRT)
+	if o.SignalHandler {
+		signal.Notify(c, syscall.SIGINT, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGILL, syscall.SIGTRAP, syscall.SIGABRT)
+	}
 	select {
 	case s := <-c:
 		lager.Logger.Info("got os signal " + s.String())
 	case err := <-server.ErrRuntime:
 		lager.Logger.Info("got Server Error " + err.Error())
+	case <-shutdownDone:
+		return
 	}
-	for name, s := range server.GetServers() {
-		lager.Logger.Info("stopping server " + name + "...")
-		err := s.Stop()
-		if err != nil {
-			lager.Logger.Errorf(err, "servers failed to stop")
-		}
-		lager.Logger.Info(name + " server stop success")
-	}
-	if !config.GetRegistratorDisable() {
-		if err = server.UnRegistrySelfInstances(); err != nil {
-			lager.Logger.Errorf(err, "servers failed to unregister")
-		}
+	if err := gracefulShutdown(); err != nil {
+		lager.Logger.Errorf(err, "graceful shutdown failed")
 	}
-
 }
 
 //Init prepare the chassis framework runtime
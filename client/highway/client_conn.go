@@ -99,7 +99,7 @@ func (hwClientConn *ClientConnection) processMsg(protoObj *ProtocolObject) {
 		protoObj.DeSerializeRsp(ctx.Rsp)
 		ctx.Done()
 	} else {
-		lager.Logger.Info(fmt.Sprintf("Cann't find the msg, perhaps it's timeout:%d", protoObj.FrHead.MsgID))
+		lager.Component(lager.ComponentHighway).Info(fmt.Sprintf("Cann't find the msg, perhaps it's timeout:%d", protoObj.FrHead.MsgID))
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"github.com/go-chassis/go-chassis/core/lager"
+	secCommon "github.com/go-chassis/go-chassis/security/common"
 	"net"
 	"sync"
 	"time"
@@ -74,7 +75,7 @@ func (mgr *ClientMgr) GetClient(connParmas *ConnParams) (*BaseClient, error) {
 		delete(mgr.clients, connParmas.Addr)
 	}
 
-	lager.Logger.Info("GetClient from new open addr:" + connParmas.Addr)
+	lager.Component(lager.ComponentHighway).Info("GetClient from new open addr:" + connParmas.Addr)
 	tmp := newHighwayBaseClient(connParmas)
 	err := tmp.Open()
 	if err != nil {
@@ -85,6 +86,21 @@ func (mgr *ClientMgr) GetClient(connParmas *ConnParams) (*BaseClient, error) {
 
 }
 
+//ConnectionCounts returns number of open connections to each peer address
+func (mgr *ClientMgr) ConnectionCounts() map[string]int {
+	mgr.mapMutex.Lock()
+	clients := make([]*BaseClient, 0, len(mgr.clients))
+	for _, c := range mgr.clients {
+		clients = append(clients, c)
+	}
+	mgr.mapMutex.Unlock()
+	counts := make(map[string]int, len(clients))
+	for _, c := range clients {
+		counts[c.GetAddr()] += c.openConns()
+	}
+	return counts
+}
+
 func newHighwayBaseClient(connParmas *ConnParams) *BaseClient {
 	tmp := &BaseClient{}
 	tmp.addr = connParmas.Addr
@@ -105,18 +121,20 @@ func (baseClient *BaseClient) makeConnection() (*ClientConnection, error) {
 
 	if baseClient.connParams.TLSConfig != nil {
 		dialer := &net.Dialer{Timeout: baseClient.connParams.Timeout * time.Second}
-		baseConn, errDial = tls.DialWithDialer(dialer, "tcp", baseClient.addr, baseClient.connParams.TLSConfig)
+		// server certificate is verified against the dialed host
+		tlsConfig := secCommon.WithServerName(baseClient.connParams.TLSConfig, baseClient.addr)
+		baseConn, errDial = tls.DialWithDialer(dialer, "tcp", baseClient.addr, tlsConfig)
 	} else {
 		baseConn, errDial = net.DialTimeout("tcp", baseClient.addr, baseClient.connParams.Timeout*time.Second)
 	}
 	if errDial != nil {
-		lager.Logger.Error("the addr: "+baseClient.addr, errDial)
+		lager.Component(lager.ComponentHighway).Error("the addr: "+baseClient.addr, errDial)
 		return nil, errDial
 	}
 	highwayConn := NewHighwayClientConnection(baseConn, baseClient)
 	err := highwayConn.Open()
 	if err != nil {
-		lager.Logger.Error("highwayConn open: "+baseClient.addr, errDial)
+		lager.Component(lager.ComponentHighway).Error("highwayConn open: "+baseClient.addr, errDial)
 		return nil, err
 	}
 
@@ -174,6 +192,22 @@ func (baseClient *BaseClient) close() {
 	baseClient.closed = true
 }
 
+//openConns returns number of open connections of client
+func (baseClient *BaseClient) openConns() int {
+	baseClient.mtx.Lock()
+	defer baseClient.mtx.Unlock()
+	if baseClient.closed {
+		return 0
+	}
+	n := 0
+	for _, conn := range baseClient.highwayConns {
+		if conn != nil && !conn.Closed() {
+			n++
+		}
+	}
+	return n
+}
+
 func (baseClient *BaseClient) clearConns() {
 	for i := 0; i < baseClient.connParams.ConnNum; i++ {
 		conn := baseClient.highwayConns[i]
@@ -239,7 +273,7 @@ func (baseClient *BaseClient) Send(req *Request, rsp *Response, timeout time.Dur
 		if err != nil {
 			baseClient.RemoveWaitMsg(msgID)
 			rsp.Err = err.Error()
-			lager.Logger.Error("AsyncSendMsg err:", err)
+			lager.Component(lager.ComponentHighway).Error("AsyncSendMsg err:", err)
 			return err
 		}
 
@@ -264,7 +298,7 @@ func (baseClient *BaseClient) Send(req *Request, rsp *Response, timeout time.Dur
 		// Respond of postMsg  is  needless
 		err := highwayConn.PostMsg(req)
 		if err != nil {
-			lager.Logger.Error("PostMsg err:", err)
+			lager.Component(lager.ComponentHighway).Error("PostMsg err:", err)
 			return err
 		}
 	}
@@ -3,10 +3,12 @@ package highway
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/go-chassis/go-chassis/core/client"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/tracing"
 )
 
 //const timeout
@@ -54,10 +56,12 @@ func (c *highwayClient) Call(ctx context.Context, addr string, inv *invocation.I
 	connParams.TLSConfig = c.opts.TLSConfig
 	connParams.Addr = addr
 	connParams.Timeout = DefaultConnectTimeOut
+	start := time.Now()
 	baseClient, err := CachedClients.GetClient(connParams)
 	if err != nil {
 		return err
 	}
+	tracing.AddEvent(ctx, tracing.EventConnect, "duration", time.Since(start).String())
 	tmpRsp := &Response{0, Ok, "", 0, rsp, nil}
 	highwayReq := invocation2Req(inv)
 	//Current only twoway
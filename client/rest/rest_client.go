@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/client"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/invocation"
+	secCommon "github.com/go-chassis/go-chassis/security/common"
 	"net"
 	"time"
 )
@@ -74,15 +76,21 @@ func NewRestClient(opts client.Options) client.ProtocolClient {
 		poolSize = opts.PoolSize
 	}
 
+	dialer := &net.Dialer{
+		KeepAlive: DefaultKeepAliveSecond,
+		Timeout:   DefaultTimeoutBySecond,
+	}
 	tp := &http.Transport{
 		MaxIdleConns:        poolSize,
 		MaxIdleConnsPerHost: poolSize,
-		DialContext: (&net.Dialer{
-			KeepAlive: DefaultKeepAliveSecond,
-			Timeout:   DefaultTimeoutBySecond,
-		}).DialContext}
+		DialContext:         dialer.DialContext}
 	if opts.TLSConfig != nil {
 		tp.TLSClientConfig = opts.TLSConfig
+		// server certificate is verified against the dialed host, ip host is not sent as server name
+		tp.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			d := &tls.Dialer{NetDialer: dialer, Config: secCommon.WithServerName(opts.TLSConfig, addr)}
+			return d.DialContext(ctx, network, addr)
+		}
 	}
 	rc := &Client{
 		opts: opts,
@@ -151,8 +159,10 @@ func (c *Client) Call(ctx context.Context, addr string, inv *invocation.Invocati
 	//increase the max connection per host to prevent error "no free connection available" error while sending more requests.
 	c.c.Transport.(*http.Transport).MaxIdleConnsPerHost = 512 * 20
 
+	// each attempt sends its own copy, inv.Args is reused by retries
+	attempt := &Request{Req: traceConnection(ctx, reqSend.Req)}
 	errChan := make(chan error, 1)
-	go func() { errChan <- c.Do(reqSend, resp) }()
+	go func() { errChan <- c.Do(attempt, resp) }()
 
 	select {
 	case <-ctx.Done():
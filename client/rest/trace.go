@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// eventConnect is the same as tracing.EventConnect,
+// core/tracing imports this package, so span is got from opentracing directly
+const eventConnect = "transport.connect"
+
+// traceConnection returns a copy of req which records how long it takes to get a connection to the active span in ctx,
+// req is returned if there is no active span. req is not modified, so that retries of the same request do not stack traces
+func traceConnection(ctx context.Context, req *http.Request) *http.Request {
+	if ctx == nil {
+		return req
+	}
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return req
+	}
+	var start time.Time
+	t := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			start = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			span.LogKV("event", eventConnect, "reused", info.Reused, "duration", time.Since(start).String())
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), t))
+}
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceConnection(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/hello", nil)
+	assert.NoError(t, err)
+	// no active span
+	assert.True(t, traceConnection(nil, req) == req)
+	assert.True(t, traceConnection(context.Background(), req) == req)
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("call")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+	for i := 0; i < 2; i++ {
+		attempt := traceConnection(ctx, req)
+		assert.True(t, attempt != req)
+		trace := httptrace.ContextClientTrace(attempt.Context())
+		assert.NotNil(t, trace)
+		trace.GetConn("127.0.0.1:80")
+		trace.GotConn(httptrace.GotConnInfo{Reused: i > 0})
+	}
+	// request of invocation is not changed by attempts
+	assert.Nil(t, httptrace.ContextClientTrace(req.Context()))
+	span.Finish()
+	assert.Len(t, tracer.FinishedSpans()[0].Logs(), 2)
+}
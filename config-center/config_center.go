@@ -42,7 +42,7 @@ func InitConfigCenter() error {
 	var enableSSL bool
 	tlsConfig, tlsError := getTLSForClient(configCenterURL)
 	if tlsError != nil {
-		lager.Logger.Errorf(tlsError, "Get %s.%s TLS config failed, err:", Name, common.Consumer)
+		lager.Component(lager.ComponentConfigCenter).Errorf(tlsError, "Get %s.%s TLS config failed, err:", Name, common.Consumer)
 		return tlsError
 	}
 
@@ -56,7 +56,7 @@ func InitConfigCenter() error {
 
 	if dimensionInfo == "" {
 		err := errors.New("empty dimension info: " + emptyDimeInfo)
-		lager.Logger.Error("empty dimension info", err)
+		lager.Component(lager.ComponentConfigCenter).Error("empty dimension info", err)
 		return err
 	}
 
@@ -72,11 +72,11 @@ func InitConfigCenter() error {
 		dimensionInfo, config.GlobalDefinition.Cse.Config.Client.TenantName,
 		enableSSL, tlsConfig)
 	if err != nil {
-		lager.Logger.Error("failed to init config center", err)
+		lager.Component(lager.ComponentConfigCenter).Error("failed to init config center", err)
 		return err
 	}
 
-	lager.Logger.Warnf("config center init success")
+	lager.Component(lager.ComponentConfigCenter).Warnf("config center init success")
 	return nil
 }
 
@@ -85,7 +85,7 @@ func isConfigCenter() (string, error) {
 	if configCenterURL == "" {
 		ccURL, err := endpoint.GetEndpointFromServiceCenter("default", "CseConfigCenter", "latest")
 		if err != nil {
-			lager.Logger.Warnf("empty config center endpoint in service center %s", err.Error())
+			lager.Component(lager.ComponentConfigCenter).Warnf("empty config center endpoint in service center %s", err.Error())
 			return "", err
 		}
 
@@ -101,7 +101,7 @@ func getTLSForClient(configCenterURL string) (*tls.Config, error) {
 	}
 	ccURL, err := url.Parse(configCenterURL)
 	if err != nil {
-		lager.Logger.Error("Error occurred while parsing config center Server Uri", err)
+		lager.Component(lager.ComponentConfigCenter).Error("Error occurred while parsing config center Server Uri", err)
 		return nil, err
 	}
 	if ccURL.Scheme == common.HTTP {
@@ -116,7 +116,7 @@ func getTLSForClient(configCenterURL string) (*tls.Config, error) {
 		}
 		return nil, err
 	}
-	lager.Logger.Warnf("%s TLS mode, verify peer: %t, cipher plugin: %s.",
+	lager.Component(lager.ComponentConfigCenter).Warnf("%s TLS mode, verify peer: %t, cipher plugin: %s.",
 		sslTag, sslConfig.VerifyPeer, sslConfig.CipherPlugin)
 
 	return tlsConfig, nil
@@ -139,7 +139,7 @@ func getUniqueIDForDimInfo() string {
 	}
 
 	if len(serviceName) > maxValue {
-		lager.Logger.Errorf(nil, "exceeded max value %d for dimensionInfo %s with length %d", maxValue, serviceName,
+		lager.Component(lager.ComponentConfigCenter).Errorf(nil, "exceeded max value %d for dimensionInfo %s with length %d", maxValue, serviceName,
 			len(serviceName))
 		return ""
 	}
@@ -147,12 +147,12 @@ func getUniqueIDForDimInfo() string {
 	dimeExp := `\A([^\$\%\&\+\(/)\[\]\" "\"])*\z`
 	dimRegexVar, err := regexp.Compile(dimeExp)
 	if err != nil {
-		lager.Logger.Error("not a valid regular expression", err)
+		lager.Component(lager.ComponentConfigCenter).Error("not a valid regular expression", err)
 		return ""
 	}
 
 	if !dimRegexVar.Match([]byte(serviceName)) {
-		lager.Logger.Errorf(nil, "invalid value for dimension info, doesnot setisfy the regular expression for dimInfo:%s",
+		lager.Component(lager.ComponentConfigCenter).Errorf(nil, "invalid value for dimension info, doesnot setisfy the regular expression for dimInfo:%s",
 			serviceName)
 		return ""
 	}
@@ -164,7 +164,7 @@ func initConfigCenter(ccEndpoint, dimensionInfo, tenantName string, enableSSL bo
 
 	refreshMode := archaius.GetInt("cse.config.client.refreshMode", common.DefaultRefreshMode)
 	if refreshMode != 0 && refreshMode != 1 {
-		lager.Logger.Error(ErrRefreshMode.Error(), ErrRefreshMode)
+		lager.Component(lager.ComponentConfigCenter).Error(ErrRefreshMode.Error(), ErrRefreshMode)
 		return ErrRefreshMode
 	}
 
@@ -182,7 +182,7 @@ func initConfigCenter(ccEndpoint, dimensionInfo, tenantName string, enableSSL bo
 
 	err = archaius.DefaultConf.ConfigFactory.AddSource(configCenterSource)
 	if err != nil {
-		lager.Logger.Error("failed to do add source operation!!", err)
+		lager.Component(lager.ComponentConfigCenter).Error("failed to do add source operation!!", err)
 		return err
 	}
 	eventHandler := EventListener{
@@ -193,7 +193,7 @@ func initConfigCenter(ccEndpoint, dimensionInfo, tenantName string, enableSSL bo
 	archaius.DefaultConf.ConfigFactory.RegisterListener(eventHandler, "a*")
 
 	if err := refreshGlobalConfig(); err != nil {
-		lager.Logger.Error("failed to refresh global config for lb and cb", err)
+		lager.Component(lager.ComponentConfigCenter).Error("failed to refresh global config for lb and cb", err)
 		return err
 	}
 	return nil
@@ -208,7 +208,7 @@ type EventListener struct {
 //Event is a method
 func (e EventListener) Event(event *core.Event) {
 	value := e.Factory.GetConfigurationByKey(event.Key)
-	lager.Logger.Infof("config value %s | %s", event.Key, value)
+	lager.Component(lager.ComponentConfigCenter).Infof("config value %s | %s", event.Key, value)
 }
 
 func refreshGlobalConfig() error {
@@ -2,6 +2,7 @@ package archaius
 
 import (
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/go-chassis/go-chassis/core/lager"
@@ -71,6 +72,8 @@ func NewConfig(essentialfiles, commonfiles []string) (*Config, error) {
 	}
 
 	factory.RegisterListener(eventHandler, "a*")
+	// encrypted values are decrypted once here and when they are updated
+	secrets.load(factory.GetConfigurations())
 
 	lager.Logger.Infof("Configuration files: %s", strings.Join(files, ", "))
 	return conf, nil
@@ -82,10 +85,11 @@ type EventListener struct {
 	Factory goarchaius.ConfigurationFactory
 }
 
-// Event is invoked while generating events at run time
+// Event is invoked while generating events at run time, encrypted value is decrypted and cached
 func (e EventListener) Event(event *core.Event) {
 	value := e.Factory.GetConfigurationByKey(event.Key)
 	lager.Logger.Infof("config value after change %s | %s", event.Key, value)
+	secrets.update(event.Key, value)
 }
 
 // Init is to initialize the archaius
@@ -109,9 +113,18 @@ func Init() error {
 	return err
 }
 
-// Get is for to get the value of configuration key
+// Get is for to get the value of configuration key, plaintext of string value marked as encrypted is returned
 func Get(key string) interface{} {
-	return DefaultConf.ConfigFactory.GetConfigurationByKey(key)
+	value := DefaultConf.ConfigFactory.GetConfigurationByKey(key)
+	if s, ok := value.(string); ok {
+		plaintext, err := decrypt(key, s)
+		if err != nil {
+			lager.Logger.Errorf(err, "decrypt value of [%s] failed", key)
+			return nil
+		}
+		return plaintext
+	}
+	return value
 }
 
 // Exist is check the configuration key existence
@@ -119,9 +132,14 @@ func Exist(key string) bool {
 	return DefaultConf.ConfigFactory.IsKeyExist(key)
 }
 
-// UnmarshalConfig is for unmarshalling the configuraions of receiving object
+// UnmarshalConfig is for unmarshalling the configuraions of receiving object,
+// string fields marked as encrypted are replaced with plaintext
 func UnmarshalConfig(obj interface{}) error {
-	return DefaultConf.ConfigFactory.Unmarshal(obj)
+	if err := DefaultConf.ConfigFactory.Unmarshal(obj); err != nil {
+		return err
+	}
+	decryptValue(reflect.ValueOf(obj), 0)
+	return nil
 }
 
 // GetBool is gives the key value in the form of bool
@@ -151,13 +169,19 @@ func GetInt(key string, defaultValue int) int {
 	return result
 }
 
-// GetString gives the key value in the form of GetString
+// GetString gives the key value in the form of GetString,
+// plaintext of value marked as encrypted by envelope cipher is returned, defaultValue is returned if decryption fails
 func GetString(key string, defaultValue string) string {
 	result, err := DefaultConf.ConfigFactory.GetValue(key).ToString()
 	if err != nil {
 		return defaultValue
 	}
-	return result
+	plaintext, err := decrypt(key, result)
+	if err != nil {
+		lager.Logger.Errorf(err, "decrypt value of [%s] failed", key)
+		return defaultValue
+	}
+	return plaintext
 }
 
 // GetConfigs gives the information about all configurations
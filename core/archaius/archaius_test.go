@@ -16,6 +16,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/loadbalancer"
 	"github.com/go-chassis/go-chassis/pkg/util/fileutil"
+	"github.com/go-chassis/go-chassis/security/plugins/envelope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -307,6 +308,24 @@ cse:
 		t.Error("failed to get the value in float64")
 	}
 
+	keyring, err := envelope.NewKeyring("k1", map[string][]byte{"k1": make([]byte, 32)})
+	assert.NoError(t, err)
+	envelope.SetKeyManager(keyring)
+	defer envelope.SetKeyManager(nil)
+	encrypted, err := envelope.EncryptValue("secret")
+	assert.NoError(t, err)
+	archaius.AddKeyValue("encryptedkey", encrypted)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, "secret", archaius.GetString("encryptedkey", ""))
+	assert.Equal(t, "secret", archaius.Get("encryptedkey"))
+	archaius.AddKeyValue("cse.credentials.secretKey", encrypted)
+	time.Sleep(10 * time.Millisecond)
+	globalDef := model.GlobalCfg{}
+	assert.NoError(t, archaius.UnmarshalConfig(&globalDef))
+	assert.Equal(t, "secret", globalDef.Cse.Credentials.SecretKey)
+	archaius.AddKeyValue("encryptedkey", encrypted[:len(encrypted)-2])
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, "default", archaius.GetString("encryptedkey", "default"))
 }
 
 func (e EListener) Event(event *core.Event) {
@@ -0,0 +1,156 @@
+package archaius
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/security/plugins/envelope"
+)
+
+// maxDecryptDepth limits nesting of objects walked by UnmarshalConfig
+const maxDecryptDepth = 32
+
+// secretCache holds plaintext of config values encrypted by envelope cipher,
+// values are decrypted once when a source loads or updates them, so reading a config does not decrypt it again
+type secretCache struct {
+	mu sync.RWMutex
+	// plaintexts is plaintext of ciphertext
+	plaintexts map[string]string
+	// ciphertexts is ciphertext of key, plaintext of former value is dropped when key changes
+	ciphertexts map[string]string
+}
+
+var secrets = newSecretCache()
+
+func newSecretCache() *secretCache {
+	return &secretCache{
+		plaintexts:  make(map[string]string),
+		ciphertexts: make(map[string]string),
+	}
+}
+
+// load decrypts all encrypted values of configs
+func (c *secretCache) load(configs map[string]interface{}) {
+	for k, v := range configs {
+		c.update(k, v)
+	}
+}
+
+// update decrypts value of key if it is encrypted, decryption error is logged and tried again at next read
+func (c *secretCache) update(key string, value interface{}) {
+	s, _ := value.(string)
+	c.mu.Lock()
+	if old, ok := c.ciphertexts[key]; ok && old != s {
+		delete(c.plaintexts, old)
+		delete(c.ciphertexts, key)
+	}
+	c.mu.Unlock()
+	if !envelope.IsEncrypted(s) {
+		return
+	}
+	if _, err := c.plaintext(key, s); err != nil {
+		lager.Logger.Errorf(err, "decrypt value of [%s] failed", key)
+	}
+}
+
+// plaintext returns plaintext of ciphertext s which is value of key, empty key means key is unknown.
+// s is decrypted and cached if it is not loaded yet, for example key manager is not ready when source loads
+func (c *secretCache) plaintext(key, s string) (string, error) {
+	c.mu.RLock()
+	p, ok := c.plaintexts[s]
+	c.mu.RUnlock()
+	if ok {
+		return p, nil
+	}
+	p, err := envelope.DecryptValue(s)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.plaintexts[s] = p
+	if key != "" {
+		if old, ok := c.ciphertexts[key]; ok && old != s {
+			delete(c.plaintexts, old)
+		}
+		c.ciphertexts[key] = s
+	}
+	c.mu.Unlock()
+	return p, nil
+}
+
+// decrypt returns plaintext of s if s is encrypted, otherwise s is returned
+func decrypt(key, s string) (string, error) {
+	if !envelope.IsEncrypted(s) {
+		return s, nil
+	}
+	return secrets.plaintext(key, s)
+}
+
+// decryptValue replaces encrypted strings in v with plaintext, string which fails to be decrypted is cleared.
+// it returns true if v is changed, so that copies of map elements can be set back
+func decryptValue(v reflect.Value, depth int) bool {
+	if depth > maxDecryptDepth {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if !envelope.IsEncrypted(s) || !v.CanSet() {
+			return false
+		}
+		p, err := secrets.plaintext("", s)
+		if err != nil {
+			lager.Logger.Errorf(err, "decrypt value of unmarshalled config failed")
+		}
+		v.SetString(p)
+		return true
+	case reflect.Ptr:
+		if v.IsNil() {
+			return false
+		}
+		return decryptValue(v.Elem(), depth+1)
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return false
+		}
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+		if decryptValue(e, depth+1) {
+			v.Set(e)
+			return true
+		}
+	case reflect.Struct:
+		changed := false
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() && decryptValue(f, depth+1) {
+				changed = true
+			}
+		}
+		return changed
+	case reflect.Slice, reflect.Array:
+		changed := false
+		for i := 0; i < v.Len(); i++ {
+			if decryptValue(v.Index(i), depth+1) {
+				changed = true
+			}
+		}
+		return changed
+	case reflect.Map:
+		if v.IsNil() {
+			return false
+		}
+		changed := false
+		for _, k := range v.MapKeys() {
+			// map elements are not addressable, a copy is decrypted and set back
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			if decryptValue(e, depth+1) {
+				v.SetMapIndex(k, e)
+				changed = true
+			}
+		}
+		return changed
+	}
+	return false
+}
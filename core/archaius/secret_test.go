@@ -0,0 +1,113 @@
+package archaius
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/security/plugins/envelope"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingKeyManager counts unwrapping of data keys
+type countingKeyManager struct {
+	envelope.KeyManager
+	unwraps int
+}
+
+func (m *countingKeyManager) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	m.unwraps++
+	return m.KeyManager.UnwrapKey(keyID, wrapped)
+}
+
+func setCountingKeyManager(t *testing.T) *countingKeyManager {
+	keyring, err := envelope.NewKeyring("k1", map[string][]byte{"k1": make([]byte, 32)})
+	assert.NoError(t, err)
+	km := &countingKeyManager{KeyManager: keyring}
+	envelope.SetKeyManager(km)
+	return km
+}
+
+func TestSecretCache(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	km := setCountingKeyManager(t)
+	defer envelope.SetKeyManager(nil)
+	encrypted, err := envelope.EncryptValue("secret")
+	assert.NoError(t, err)
+	updated, err := envelope.EncryptValue("updated")
+	assert.NoError(t, err)
+
+	c := newSecretCache()
+	c.load(map[string]interface{}{"a": encrypted, "b": "plain", "c": 1})
+	assert.Equal(t, 1, km.unwraps)
+	// read does not decrypt again
+	for i := 0; i < 3; i++ {
+		p, err := c.plaintext("a", encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", p)
+	}
+	assert.Equal(t, 1, km.unwraps)
+
+	c.update("a", updated)
+	assert.Equal(t, 2, km.unwraps)
+	p, err := c.plaintext("a", updated)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", p)
+	assert.Equal(t, 2, km.unwraps)
+	assert.Equal(t, map[string]string{updated: "updated"}, c.plaintexts)
+
+	// plaintext of deleted value is dropped
+	c.update("a", nil)
+	assert.Empty(t, c.plaintexts)
+
+	// failure is not cached
+	_, err = c.plaintext("d", encrypted[:len(encrypted)-2])
+	assert.Error(t, err)
+	assert.Empty(t, c.plaintexts)
+}
+
+func TestDecryptValue(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	setCountingKeyManager(t)
+	defer envelope.SetKeyManager(nil)
+	encrypted, err := envelope.EncryptValue("secret")
+	assert.NoError(t, err)
+
+	type credential struct {
+		SecretKey string
+		private   string
+	}
+	type config struct {
+		Credential  credential
+		Pointer     *credential
+		Services    map[string]credential
+		Ssl         map[string]string
+		Values      map[string]interface{}
+		Keys        []string
+		Unencrypted string
+	}
+	c := &config{
+		Credential:  credential{SecretKey: encrypted, private: encrypted},
+		Pointer:     &credential{SecretKey: encrypted},
+		Services:    map[string]credential{"Server": {SecretKey: encrypted}},
+		Ssl:         map[string]string{"keyPassphase": encrypted},
+		Values:      map[string]interface{}{"nested": map[string]interface{}{"secret": encrypted}, "secret": encrypted},
+		Keys:        []string{encrypted, "plain"},
+		Unencrypted: "plain",
+	}
+	assert.True(t, decryptValue(reflect.ValueOf(c), 0))
+	assert.Equal(t, &config{
+		Credential:  credential{SecretKey: "secret", private: encrypted},
+		Pointer:     &credential{SecretKey: "secret"},
+		Services:    map[string]credential{"Server": {SecretKey: "secret"}},
+		Ssl:         map[string]string{"keyPassphase": "secret"},
+		Values:      map[string]interface{}{"nested": map[string]interface{}{"secret": "secret"}, "secret": "secret"},
+		Keys:        []string{"secret", "plain"},
+		Unencrypted: "plain",
+	}, c)
+
+	// value which fails to be decrypted is cleared
+	broken := &credential{SecretKey: encrypted[:len(encrypted)-2]}
+	decryptValue(reflect.ValueOf(broken), 0)
+	assert.Equal(t, "", broken.SecretKey)
+}
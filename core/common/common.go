@@ -58,6 +58,13 @@ const (
 	SslKeyFileKey      = "keyFile"
 	SslCertPwdFileKey  = "certPwdFile"
 	AKSKCustomCipher   = "cse.credentials.akskCustomCipher"
+
+	SslMaxProtocolKey              = "maxProtocol"
+	SslCurvePreferencesKey         = "curvePreferences"
+	SslALPNProtocolsKey            = "alpnProtocols"
+	SslSessionTicketKeyFileKey     = "sessionTicketKeyFile"
+	SslSessionTicketKeyRotationKey = "sessionTicketKeyRotation"
+	SslOCSPStapleFileKey           = "ocspStapleFile"
 )
 
 // constant for protocol types
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/common"
+)
+
+const (
+	authPrefix         = "cse.auth"
+	propertyAuthPlugin = "plugin"
+	aclAllow           = "acl.allow"
+	aclDeny            = "acl.deny"
+	aclRequireIdentity = "acl.requireIdentity"
+
+	//DefaultAuthPlugin is default plugin of auth-provider handler
+	DefaultAuthPlugin = "acl"
+)
+
+// GetAuthPlugin returns name of auth plugin used by auth-provider handler
+func GetAuthPlugin() string {
+	return archaius.GetString(genKey(authPrefix, propertyAuthPlugin), DefaultAuthPlugin)
+}
+
+// ACLSettings is rules of acl auth plugin in auth.yaml
+type ACLSettings struct {
+	// RequireIdentity means caller must be authenticated by mutual tls
+	RequireIdentity bool
+	// Allow is operations allowed for each source service, * means any service
+	Allow map[string][]string
+	// Deny is operations denied for each source service, * means any service
+	Deny map[string][]string
+}
+
+// GetACLSettings returns rules under cse.auth.acl
+func GetACLSettings() ACLSettings {
+	return ACLSettings{
+		RequireIdentity: archaius.GetBool(genKey(authPrefix, aclRequireIdentity), false),
+		Allow:           getACLRules(genKey(authPrefix, aclAllow) + "."),
+		Deny:            getACLRules(genKey(authPrefix, aclDeny) + "."),
+	}
+}
+
+// getACLRules collects {prefix}{source}, value is a list or a comma separated string of operations
+func getACLRules(prefix string) map[string][]string {
+	m := make(map[string][]string)
+	for k, v := range archaius.GetConfigs() {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		source := strings.TrimPrefix(k, prefix)
+		var operations []string
+		switch value := v.(type) {
+		case string:
+			operations = strings.Split(value, ",")
+		case []interface{}:
+			for _, o := range value {
+				if s, ok := o.(string); ok {
+					operations = append(operations, s)
+				}
+			}
+		}
+		for _, o := range operations {
+			if o = strings.TrimSpace(o); o != "" {
+				m[source] = append(m[source], o)
+			}
+		}
+	}
+	return m
+}
+
+// constants for consumer token modes
+const (
+	// TokenModeForward forwards token of caller to providers
+	TokenModeForward = "forward"
+	// TokenModeService sends token of the service itself got from token source
+	TokenModeService = "service"
+
+	//DefaultJWTClockSkew is default tolerance of exp and nbf checks
+	DefaultJWTClockSkew = 30 * time.Second
+	//DefaultJWKSRefreshInterval is default interval of reloading jwks
+	DefaultJWKSRefreshInterval = 5 * time.Minute
+	//DefaultTokenSource is default token source plugin of service mode
+	DefaultTokenSource = "clientCredentials"
+)
+
+// JWTConfig is config of jwt-provider and jwt-consumer handlers under cse.auth.jwt
+type JWTConfig struct {
+	// JWKS is a file path or an http(s) url
+	JWKS                string
+	JWKSRefreshInterval time.Duration
+	Issuer              string
+	Audiences           []string
+	ClockSkew           time.Duration
+	// ClaimsToMetadata maps claim name to invocation metadata key
+	ClaimsToMetadata map[string]string
+	// ConsumerMode is forward or service
+	ConsumerMode string
+	// TokenSource is name of oauth2 token source plugin used in service mode
+	TokenSource string
+}
+
+// GetJWTConfig returns config under cse.auth.jwt
+func GetJWTConfig() JWTConfig {
+	prefix := genKey(authPrefix, "jwt")
+	c := JWTConfig{
+		JWKS:                archaius.GetString(genKey(prefix, "jwks"), ""),
+		JWKSRefreshInterval: getDuration(genKey(prefix, "jwksRefreshInterval"), DefaultJWKSRefreshInterval),
+		Issuer:              archaius.GetString(genKey(prefix, "issuer"), ""),
+		ClockSkew:           getDuration(genKey(prefix, "clockSkew"), DefaultJWTClockSkew),
+		ClaimsToMetadata:    make(map[string]string),
+		ConsumerMode:        archaius.GetString(genKey(prefix, "consumer", "mode"), TokenModeForward),
+		TokenSource:         archaius.GetString(genKey(prefix, "consumer", "tokenSource"), DefaultTokenSource),
+	}
+	for _, aud := range strings.Split(archaius.GetString(genKey(prefix, "audiences"), ""), ",") {
+		if aud = strings.TrimSpace(aud); aud != "" {
+			c.Audiences = append(c.Audiences, aud)
+		}
+	}
+	claimsPrefix := genKey(prefix, "claimsToMetadata") + "."
+	for k, v := range archaius.GetConfigs() {
+		if s, ok := v.(string); ok && strings.HasPrefix(k, claimsPrefix) {
+			c.ClaimsToMetadata[strings.TrimPrefix(k, claimsPrefix)] = s
+		}
+	}
+	return c
+}
+
+// OAuth2ClientCredentials is config of client credentials token source under cse.auth.oauth2.clientCredentials
+type OAuth2ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// GetOAuth2ClientCredentials returns config of client credentials token source
+func GetOAuth2ClientCredentials() OAuth2ClientCredentials {
+	prefix := genKey(authPrefix, "oauth2", "clientCredentials")
+	return OAuth2ClientCredentials{
+		TokenURL:     archaius.GetString(genKey(prefix, "tokenURL"), ""),
+		ClientID:     archaius.GetString(genKey(prefix, "clientID"), ""),
+		ClientSecret: archaius.GetString(genKey(prefix, "clientSecret"), ""),
+		Scopes:       strings.Fields(archaius.GetString(genKey(prefix, "scopes"), "")),
+	}
+}
+
+const (
+	//DefaultAKSKCipher is default cipher plugin decrypting secret keys
+	DefaultAKSKCipher = "default"
+	//DefaultAKSKKeyStore is default key store of aksk-provider handler
+	DefaultAKSKKeyStore = "config"
+	//DefaultAKSKMaxClockSkew is default max difference between request timestamp and local time
+	DefaultAKSKMaxClockSkew = 5 * time.Minute
+)
+
+// AKSKConfig is config of aksk-consumer and aksk-provider handlers,
+// credentials of consumer are under cse.credentials, others are under cse.auth.aksk
+type AKSKConfig struct {
+	AccessKey string
+	// SecretKey is encrypted by Cipher
+	SecretKey string
+	Cipher    string
+	KeyStore  string
+	// SignedHeaders is headers covered by signature of consumer
+	SignedHeaders []string
+	MaxClockSkew  time.Duration
+}
+
+// GetAKSKConfig returns config of request signing
+func GetAKSKConfig() AKSKConfig {
+	prefix := genKey(authPrefix, "aksk")
+	c := AKSKConfig{
+		AccessKey:    archaius.GetString("cse.credentials.accessKey", ""),
+		SecretKey:    archaius.GetString("cse.credentials.secretKey", ""),
+		Cipher:       archaius.GetString(common.AKSKCustomCipher, DefaultAKSKCipher),
+		KeyStore:     archaius.GetString(genKey(prefix, "keyStore"), DefaultAKSKKeyStore),
+		MaxClockSkew: getDuration(genKey(prefix, "maxClockSkew"), DefaultAKSKMaxClockSkew),
+	}
+	for _, h := range strings.Split(archaius.GetString(genKey(prefix, "signedHeaders"), "Content-Type"), ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			c.SignedHeaders = append(c.SignedHeaders, h)
+		}
+	}
+	return c
+}
+
+// GetAKSKSecretKey returns encrypted secret key of access key under cse.auth.aksk.keys
+func GetAKSKSecretKey(accessKey string) string {
+	return archaius.GetString(genKey(authPrefix, "aksk", "keys", accessKey), "")
+}
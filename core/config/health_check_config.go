@@ -0,0 +1,93 @@
+package config
+
+import (
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+const (
+	healthCheckPrefix                 = "cse.healthCheck"
+	propertyHealthCheckEnabled        = "enabled"
+	propertyHealthCheckInterval       = "interval"
+	propertyHealthCheckTimeout        = "timeout"
+	propertyHealthyThreshold          = "healthyThreshold"
+	propertyUnhealthyThreshold        = "unhealthyThreshold"
+	propertyHealthCheckProbe          = "probe.type"
+	propertyHealthCheckProtocol       = "probe.protocol"
+	propertyHealthCheckPath           = "probe.path"
+	propertyHealthCheckExpectedStatus = "probe.expectedStatus"
+
+	//DefaultHealthCheckInterval is default value for probe interval
+	DefaultHealthCheckInterval = 10 * time.Second
+	//DefaultHealthCheckTimeout is default value for probe timeout
+	DefaultHealthCheckTimeout = 3 * time.Second
+	//DefaultHealthyThreshold is default value for successive successes to mark instance healthy
+	DefaultHealthyThreshold = 1
+	//DefaultUnhealthyThreshold is default value for successive failures to mark instance unhealthy
+	DefaultUnhealthyThreshold = 3
+	//DefaultHealthCheckProbe is default probe type
+	DefaultHealthCheckProbe = "tcp"
+	//DefaultHealthCheckPath is default path of http probe
+	DefaultHealthCheckPath = "/healthz"
+)
+
+// HealthCheckConfig is active health check config of a provider service
+type HealthCheckConfig struct {
+	Enabled            bool
+	Interval           time.Duration
+	Timeout            time.Duration
+	HealthyThreshold   int
+	UnhealthyThreshold int
+	Probe              string
+	// Protocol is the endpoint protocol to probe, empty means any
+	Protocol       string
+	Path           string
+	ExpectedStatus int
+}
+
+func getHealthCheckString(service, property, defaultValue string) string {
+	global := archaius.GetString(genKey(healthCheckPrefix, property), defaultValue)
+	return archaius.GetString(genKey(healthCheckPrefix, service, property), global)
+}
+
+func getHealthCheckInt(service, property string, defaultValue int) int {
+	global := archaius.GetInt(genKey(healthCheckPrefix, property), defaultValue)
+	return archaius.GetInt(genKey(healthCheckPrefix, service, property), global)
+}
+
+func getHealthCheckDuration(service, property string, defaultValue time.Duration) time.Duration {
+	s := getHealthCheckString(service, property, "")
+	if s == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// GetHealthCheck returns active health check config of service,
+// service config under cse.healthCheck.{service} overrides global config under cse.healthCheck
+func GetHealthCheck(service string) HealthCheckConfig {
+	global := archaius.GetBool(genKey(healthCheckPrefix, propertyHealthCheckEnabled), false)
+	c := HealthCheckConfig{
+		Enabled:            archaius.GetBool(genKey(healthCheckPrefix, service, propertyHealthCheckEnabled), global),
+		Interval:           getHealthCheckDuration(service, propertyHealthCheckInterval, DefaultHealthCheckInterval),
+		Timeout:            getHealthCheckDuration(service, propertyHealthCheckTimeout, DefaultHealthCheckTimeout),
+		HealthyThreshold:   getHealthCheckInt(service, propertyHealthyThreshold, DefaultHealthyThreshold),
+		UnhealthyThreshold: getHealthCheckInt(service, propertyUnhealthyThreshold, DefaultUnhealthyThreshold),
+		Probe:              getHealthCheckString(service, propertyHealthCheckProbe, DefaultHealthCheckProbe),
+		Protocol:           getHealthCheckString(service, propertyHealthCheckProtocol, ""),
+		Path:               getHealthCheckString(service, propertyHealthCheckPath, DefaultHealthCheckPath),
+		ExpectedStatus:     getHealthCheckInt(service, propertyHealthCheckExpectedStatus, 200),
+	}
+	if c.HealthyThreshold <= 0 {
+		c.HealthyThreshold = DefaultHealthyThreshold
+	}
+	if c.UnhealthyThreshold <= 0 {
+		c.UnhealthyThreshold = DefaultUnhealthyThreshold
+	}
+	return c
+}
@@ -12,12 +12,14 @@ const (
 	propertyStrategyName                     = "strategy.name"
 	propertySessionStickinessRuleTimeout     = "SessionStickinessRule.sessionTimeoutInSeconds"
 	propertySessionStickinessRuleFailedTimes = "SessionStickinessRule.successiveFailedTimes"
+	propertySessionStickinessRuleStore       = "SessionStickinessRule.store"
 	propertyRetryEnabled                     = "retryEnabled"
 	propertyRetryOnNext                      = "retryOnNext"
 	propertyRetryOnSame                      = "retryOnSame"
 	propertyBackoffKind                      = "backoff.kind"
 	propertyBackoffMinMs                     = "backoff.minMs"
 	propertyBackoffMaxMs                     = "backoff.maxMs"
+	propertyTestingHeader                    = "testingHeader"
 
 	//DefaultStrategy is default value for strategy
 	DefaultStrategy = "RoundRobin"
@@ -25,6 +27,12 @@ const (
 	DefaultSessionTimeout = 30
 	//DefaultFailedTimes is default value for failed times
 	DefaultFailedTimes = 5
+	//DefaultSessionStore is default session store keeping sessions in memory
+	DefaultSessionStore = "memory"
+	//DefaultSessionCookieCipher is default cipher plugin encrypting session cookie
+	DefaultSessionCookieCipher = "envelope"
+	//DefaultSessionCookieSameSite is default SameSite attribute of session cookie
+	DefaultSessionCookieSameSite = "Lax"
 )
 
 var lbMutex = sync.RWMutex{}
@@ -67,6 +75,51 @@ func GetSessionTimeout(source, service string) int {
 	return ms
 }
 
+// GetSessionStore return name of session store plugin, it is shared by all services
+func GetSessionStore() string {
+	lbMutex.RLock()
+	defer lbMutex.RUnlock()
+	global := DefaultSessionStore
+	if lb := GetLoadBalancing(); lb != nil && lb.SessionStickinessRule.Store != "" {
+		global = lb.SessionStickinessRule.Store
+	}
+	return archaius.GetString(genKey(lbPrefix, propertySessionStickinessRuleStore), global)
+}
+
+// SessionCookie is config of sticky session cookie under cse.loadbalance.SessionStickinessRule.cookie
+type SessionCookie struct {
+	// Secret signs cookie with hmac, a random secret of process is used if it is empty
+	Secret string
+	// Encrypt hides session id and endpoint of cookie with cipher plugin
+	Encrypt      bool
+	CipherPlugin string
+	Secure       bool
+	HTTPOnly     bool
+	// SameSite is Lax, Strict or None
+	SameSite string
+	Domain   string
+	Path     string
+	// MaxAge is max age of cookie in seconds, 0 means cookie expires when browser closes,
+	// cookie issued longer than max age ago is rejected
+	MaxAge int
+}
+
+// GetSessionCookie returns config of sticky session cookie, it is shared by all services
+func GetSessionCookie() SessionCookie {
+	prefix := genKey(lbPrefix, "SessionStickinessRule", "cookie")
+	return SessionCookie{
+		Secret:       archaius.GetString(genKey(prefix, "secret"), ""),
+		Encrypt:      archaius.GetBool(genKey(prefix, "encrypt"), false),
+		CipherPlugin: archaius.GetString(genKey(prefix, "cipherPlugin"), DefaultSessionCookieCipher),
+		Secure:       archaius.GetBool(genKey(prefix, "secure"), false),
+		HTTPOnly:     archaius.GetBool(genKey(prefix, "httpOnly"), true),
+		SameSite:     archaius.GetString(genKey(prefix, "sameSite"), DefaultSessionCookieSameSite),
+		Domain:       archaius.GetString(genKey(prefix, "domain"), ""),
+		Path:         archaius.GetString(genKey(prefix, "path"), "/"),
+		MaxAge:       archaius.GetInt(genKey(prefix, "maxAge"), 0),
+	}
+}
+
 // StrategySuccessiveFailedTimes strategy successive failed times
 func StrategySuccessiveFailedTimes(source, service string) int {
 	lbMutex.RLock()
@@ -131,3 +184,10 @@ func BackOffMaxMs(source, service string) int {
 	ms := archaius.GetInt(genKey(lbPrefix, service, propertyBackoffMaxMs), global)
 	return ms
 }
+
+//GetTestingHeader returns name of the header which routes request to TESTING instances,
+//empty means TESTING instances receive no request
+func GetTestingHeader(source, service string) string {
+	global := archaius.GetString(genKey(lbPrefix, propertyTestingHeader), "")
+	return archaius.GetString(genKey(lbPrefix, service, propertyTestingHeader), global)
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+// constants for config keys of runtime log level
+const (
+	LoggerLevelKey       = "cse.logger.level"
+	LoggerLevelPrefix    = "cse.logger.levels."
+	LoggerRevertAfterKey = "cse.logger.revertAfter"
+)
+
+// GetLoggerLevel returns global log level set in config center, it is empty if not set
+func GetLoggerLevel() string {
+	return archaius.GetString(LoggerLevelKey, "")
+}
+
+// GetLoggerComponentLevels returns log level of each component set in config center
+func GetLoggerComponentLevels() map[string]string {
+	levels := make(map[string]string)
+	for k := range archaius.GetConfigs() {
+		if strings.HasPrefix(k, LoggerLevelPrefix) {
+			levels[strings.TrimPrefix(k, LoggerLevelPrefix)] = archaius.GetString(k, "")
+		}
+	}
+	return levels
+}
+
+// GetLoggerRevertAfter returns duration after which log level changed in config center reverts, 0 means never
+func GetLoggerRevertAfter() time.Duration {
+	return getDuration(LoggerRevertAfterKey, 0)
+}
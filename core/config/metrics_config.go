@@ -0,0 +1,117 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+//DefaultMetricsMaxLabelValues is default number of distinct values a request metrics label can have
+const DefaultMetricsMaxLabelValues = 100
+
+// GetMetricsMaxLabelValues returns the cardinality limit of each request metrics label,
+// values beyond the limit are aggregated, so that unexpected values like rest path with ids do not flood prometheus
+func GetMetricsMaxLabelValues() int {
+	n := archaius.GetInt("cse.metrics.maxLabelValues", DefaultMetricsMaxLabelValues)
+	if n <= 0 {
+		return DefaultMetricsMaxLabelValues
+	}
+	return n
+}
+
+//DefaultNativeHistogramBucketFactor is growth factor of native histogram buckets
+const DefaultNativeHistogramBucketFactor = 1.1
+
+// LatencyMetricsConfig decides how request duration is exported
+type LatencyMetricsConfig struct {
+	// Buckets is upper bounds of classic histogram buckets in seconds, nil means prometheus default buckets
+	Buckets []float64
+	// NativeHistogramBucketFactor enables prometheus native histogram if it is greater than 1
+	NativeHistogramBucketFactor float64
+	// Summary exports quantiles calculated in process besides histogram
+	Summary bool
+	// Exemplar attaches trace id of current span to histogram observations
+	Exemplar bool
+}
+
+// GetLatencyMetricsConfig returns config under cse.metrics.latency
+func GetLatencyMetricsConfig() LatencyMetricsConfig {
+	c := LatencyMetricsConfig{
+		Summary:  archaius.GetBool("cse.metrics.latency.summary", false),
+		Exemplar: archaius.GetBool("cse.metrics.latency.exemplar", true),
+	}
+	if archaius.GetBool("cse.metrics.latency.nativeHistogram", false) {
+		c.NativeHistogramBucketFactor = DefaultNativeHistogramBucketFactor
+	}
+	for _, b := range strings.Split(archaius.GetString("cse.metrics.latency.buckets", ""), ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if err != nil {
+			continue
+		}
+		c.Buckets = append(c.Buckets, f)
+	}
+	sort.Float64s(c.Buckets)
+	return c
+}
+
+const (
+	reporterPrefix = "cse.metrics.reporters"
+	//DefaultMetricsFlushInterval is default interval of collecting and pushing metrics
+	DefaultMetricsFlushInterval = 10 * time.Second
+)
+
+// GetMetricsFlushInterval returns cse.metrics.flushInterval
+func GetMetricsFlushInterval() time.Duration {
+	if GlobalDefinition != nil {
+		if d, err := time.ParseDuration(GlobalDefinition.Cse.Metrics.FlushInterval); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultMetricsFlushInterval
+}
+
+// GoRuntimeMetricsEnabled returns true if go runtime, process and chassis metrics are collected, default is true
+func GoRuntimeMetricsEnabled() bool {
+	return archaius.GetBool("cse.metrics.enableGoRuntimeMetrics", true)
+}
+
+// ReporterConfig is config of a push metrics reporter under cse.metrics.reporters.<name>
+type ReporterConfig struct {
+	Enable bool
+	// Address is host:port of udp/tcp reporters, or url of http reporters
+	Address string
+	// Protocol is transport protocol, it depends on reporter, for example udp or tcp for statsd, http or grpc for otlp
+	Protocol      string
+	Prefix        string
+	FlushInterval time.Duration
+	// Tags is attached to every metric, it overrides tags generated by chassis
+	Tags map[string]string
+}
+
+// GetReporterConfig returns config of reporter,
+// flush interval is cse.metrics.flushInterval if reporter does not set it
+func GetReporterConfig(name string) ReporterConfig {
+	prefix := genKey(reporterPrefix, name)
+	interval := GetMetricsFlushInterval()
+	c := ReporterConfig{
+		Enable:        archaius.GetBool(genKey(prefix, "enable"), false),
+		Address:       archaius.GetString(genKey(prefix, "address"), ""),
+		Protocol:      archaius.GetString(genKey(prefix, "protocol"), ""),
+		Prefix:        archaius.GetString(genKey(prefix, "prefix"), ""),
+		FlushInterval: getDuration(genKey(prefix, "flushInterval"), interval),
+		Tags:          make(map[string]string),
+	}
+	tagPrefix := genKey(prefix, "tags") + "."
+	for k := range archaius.GetConfigs() {
+		if strings.HasPrefix(k, tagPrefix) {
+			c.Tags[strings.TrimPrefix(k, tagPrefix)] = archaius.GetString(k, "")
+		}
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = interval
+	}
+	return c
+}
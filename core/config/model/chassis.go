@@ -174,4 +174,6 @@ type TracingStruct struct {
 	// if collectorType is http, the target is zipkin server
 	// if collectorType is log, the target is log file
 	CollectorTarget string `yaml:"collectorTarget"`
+	// Propagation is formats of trace context separated by comma, tracecontext|baggage|b3
+	Propagation string `yaml:"propagation"`
 }
@@ -36,6 +36,8 @@ type LoadBalancingSpec struct {
 type SessionStickinessRule struct {
 	SessionTimeoutInSeconds int `yaml:"sessionTimeoutInSeconds"`
 	SuccessiveFailedTimes   int `yaml:"successiveFailedTimes"`
+	// Store is name of session store plugin
+	Store string `yaml:"store"`
 }
 
 // BackoffStrategy back off strategy
@@ -55,6 +55,15 @@ type ServiceDiscoveryStruct struct {
 	ConfigPath      string                   `yaml:"configPath"`
 	APIVersion      RegistryAPIVersionStruct `yaml:"api"`
 	HealthCheck     bool                     `yaml:"healthCheck"`
+	Snapshot        SnapshotStruct           `yaml:"snapshot"`
+}
+
+//SnapshotStruct instance cache snapshot config struct
+type SnapshotStruct struct {
+	Enabled      bool   `yaml:"enabled"`
+	Path         string `yaml:"path"`
+	Interval     string `yaml:"interval"`
+	MaxStaleness string `yaml:"maxStaleness"`
 }
 
 //ContractDiscoveryStruct contract discovery config struct
@@ -79,3 +79,23 @@ func GetServiceDiscoveryConfigPath() string {
 
 // GetGlobalAppID returns appID of definition
 func GetGlobalAppID() string { return GlobalDefinition.AppID }
+
+// GetServiceDiscoverySnapshotEnabled returns whether instance cache snapshot is enabled
+func GetServiceDiscoverySnapshotEnabled() bool {
+	return archaius.GetBool("cse.service.registry.serviceDiscovery.snapshot.enabled", false)
+}
+
+// GetServiceDiscoverySnapshotPath returns the file path of instance cache snapshot
+func GetServiceDiscoverySnapshotPath() string {
+	return archaius.GetString("cse.service.registry.serviceDiscovery.snapshot.path", "")
+}
+
+// GetServiceDiscoverySnapshotInterval returns the interval of writing instance cache snapshot
+func GetServiceDiscoverySnapshotInterval() string {
+	return archaius.GetString("cse.service.registry.serviceDiscovery.snapshot.interval", "")
+}
+
+// GetServiceDiscoverySnapshotMaxStaleness returns how long snapshot instances can be used
+func GetServiceDiscoverySnapshotMaxStaleness() string {
+	return archaius.GetString("cse.service.registry.serviceDiscovery.snapshot.maxStaleness", "")
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+const (
+	//DefaultShutdownStatus is the instance status set before servers stop
+	DefaultShutdownStatus = "DOWN"
+	//DefaultShutdownPropagationDelay is the time to wait for consumers to notice instance status
+	DefaultShutdownPropagationDelay = 3 * time.Second
+	//DefaultShutdownTimeout is the deadline of whole graceful shutdown
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	s := archaius.GetString(key, "")
+	if s == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// GetShutdownStatus returns instance status set at the beginning of graceful shutdown, DOWN or OUTOFSERVICE
+func GetShutdownStatus() string {
+	return archaius.GetString("cse.shutdown.status", DefaultShutdownStatus)
+}
+
+// GetShutdownPropagationDelay returns how long to wait after instance status changed
+func GetShutdownPropagationDelay() time.Duration {
+	return getDuration("cse.shutdown.propagationDelay", DefaultShutdownPropagationDelay)
+}
+
+// GetShutdownTimeout returns deadline of graceful shutdown
+func GetShutdownTimeout() time.Duration {
+	return getDuration("cse.shutdown.timeout", DefaultShutdownTimeout)
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+// DefaultSSLReloadInterval is default interval of checking certificate files
+const DefaultSSLReloadInterval = time.Minute
+
+// GetSSLReloadInterval returns interval of checking certificate, key and ca files, 0 disables reloading
+func GetSSLReloadInterval() time.Duration {
+	return getDuration("ssl.reloadInterval", DefaultSSLReloadInterval)
+}
+
+// GetSSLVerifySourceService returns true if provider rejects requests
+// whose source service does not match identity of mutual tls peer certificate
+func GetSSLVerifySourceService() bool {
+	return archaius.GetBool("ssl.verifySourceService", false)
+}
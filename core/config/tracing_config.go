@@ -0,0 +1,125 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+)
+
+const (
+	samplerPrefix              = "tracing.sampler"
+	propertySamplerType        = "type"
+	propertySamplerParam       = "param"
+	propertySamplerParentBased = "parentBased"
+	samplerServices            = "services"
+	samplerOperations          = "operations"
+
+	//DefaultSamplerType is default sampler type
+	DefaultSamplerType = "probabilistic"
+)
+
+// SamplerConfig is type and param of a tracing sampler
+type SamplerConfig struct {
+	Type string
+	// Param is sampling rate of probabilistic sampler, traces per second of rate limiting sampler
+	Param float64
+}
+
+// SamplerSettings is sampler config of all services and operations
+type SamplerSettings struct {
+	Default SamplerConfig
+	// ParentBased means span follows the sampling decision of its parent
+	ParentBased bool
+	// Services is sampler config of each target service
+	Services map[string]SamplerConfig
+	// Operations is sampler config of each operation, it overrides service config
+	Operations map[string]SamplerConfig
+}
+
+// GetSamplerSettings returns sampler config under tracing.sampler,
+// sampling rate is tracing.samplingRate if tracing.sampler.param is not set
+func GetSamplerSettings() SamplerSettings {
+	rate := 1.0
+	if GlobalDefinition != nil && GlobalDefinition.Tracing.SamplingRate > 0 {
+		rate = GlobalDefinition.Tracing.SamplingRate
+	}
+	rate = archaius.GetFloat64("tracing.samplingRate", rate)
+	return SamplerSettings{
+		Default: SamplerConfig{
+			Type:  archaius.GetString(genKey(samplerPrefix, propertySamplerType), DefaultSamplerType),
+			Param: archaius.GetFloat64(genKey(samplerPrefix, propertySamplerParam), rate),
+		},
+		ParentBased: archaius.GetBool(genKey(samplerPrefix, propertySamplerParentBased), true),
+		Services:    getSamplerOverrides(genKey(samplerPrefix, samplerServices) + "."),
+		Operations:  getSamplerOverrides(genKey(samplerPrefix, samplerOperations) + "."),
+	}
+}
+
+// getSamplerOverrides collects {prefix}{name}.type and {prefix}{name}.param,
+// name may contain dots, for example a rest path
+func getSamplerOverrides(prefix string) map[string]SamplerConfig {
+	names := make(map[string]struct{})
+	for k := range archaius.GetConfigs() {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(k, prefix)
+		for _, p := range []string{propertySamplerType, propertySamplerParam} {
+			if strings.HasSuffix(name, "."+p) {
+				names[strings.TrimSuffix(name, "."+p)] = struct{}{}
+			}
+		}
+	}
+	m := make(map[string]SamplerConfig, len(names))
+	for name := range names {
+		m[name] = SamplerConfig{
+			Type:  archaius.GetString(prefix+name+"."+propertySamplerType, DefaultSamplerType),
+			Param: archaius.GetFloat64(prefix+name+"."+propertySamplerParam, 1),
+		}
+	}
+	return m
+}
+
+const (
+	//DefaultTracingQueueSize is default capacity of span buffer
+	DefaultTracingQueueSize = 2048
+	//DefaultTracingBatchSize is default number of spans exported at once
+	DefaultTracingBatchSize = 256
+	//DefaultTracingFlushInterval is default max time a span waits in buffer
+	DefaultTracingFlushInterval = time.Second
+	//DefaultTracingDropPolicy drops new spans if buffer is full
+	DefaultTracingDropPolicy = "dropNewest"
+)
+
+// TracingBatchConfig is config of batched span collector
+type TracingBatchConfig struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	// DropPolicy is dropNewest or dropOldest
+	DropPolicy string
+}
+
+// GetTracingBatchConfig returns config under tracing.batch
+func GetTracingBatchConfig() TracingBatchConfig {
+	c := TracingBatchConfig{
+		QueueSize:     archaius.GetInt("tracing.batch.queueSize", DefaultTracingQueueSize),
+		BatchSize:     archaius.GetInt("tracing.batch.batchSize", DefaultTracingBatchSize),
+		FlushInterval: getDuration("tracing.batch.flushInterval", DefaultTracingFlushInterval),
+		DropPolicy:    archaius.GetString("tracing.batch.dropPolicy", DefaultTracingDropPolicy),
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = DefaultTracingQueueSize
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultTracingBatchSize
+	}
+	if c.BatchSize > c.QueueSize {
+		c.BatchSize = c.QueueSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = DefaultTracingFlushInterval
+	}
+	return c
+}
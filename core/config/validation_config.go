@@ -0,0 +1,21 @@
+package config
+
+import "github.com/go-chassis/go-chassis/core/archaius"
+
+const validationPrefix = "cse.validation"
+
+// ValidationConfig is config of validation-provider and validation-consumer handlers under cse.validation
+type ValidationConfig struct {
+	// Strict rejects rest requests which match no operation in contract of the service
+	Strict bool
+	// Response makes validation-consumer validate response body against contract
+	Response bool
+}
+
+// GetValidationConfig returns config of contract validation
+func GetValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		Strict:   archaius.GetBool(genKey(validationPrefix, "strict"), false),
+		Response: archaius.GetBool(genKey(validationPrefix, "response"), false),
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-chassis/go-chassis/core/config/model"
 	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/tracing"
 )
 
 var (
@@ -134,7 +135,7 @@ func ApplyFaultInjection(fault *model.Fault, inv *invocation.Invocation, configu
 	if percentage == failureCount && initialCount != 1 {
 		initialKeyCount[key]++
 		incrementKeyCount(key, count+1)
-		err := injectFault(faultType, fault)
+		err := injectFault(faultType, fault, inv)
 		return err
 
 	}
@@ -142,7 +143,7 @@ func ApplyFaultInjection(fault *model.Fault, inv *invocation.Invocation, configu
 	if percentage != failureCount && percentage > 1 {
 		faultKeyCount[key]++
 		incrementKeyCount(key, count+1)
-		err := injectFault(faultType, fault)
+		err := injectFault(faultType, fault, inv)
 		return err
 	}
 
@@ -169,9 +170,10 @@ func resetFaultKeyCount(key string) {
 }
 
 //injectFault apply fault based on the type
-func injectFault(faultType string, fault *model.Fault) error {
+func injectFault(faultType string, fault *model.Fault, inv *invocation.Invocation) error {
 	if faultType == "delay" {
 		delayApplied = true
+		tracing.AddEvent(inv.Ctx, tracing.EventFaultDelay, "delay", fault.Delay.FixedDelay.String())
 		time.Sleep(fault.Delay.FixedDelay)
 	}
 
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/auth/aksk"
+	"github.com/go-chassis/go-chassis/client/rest"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// AKSKConsumerHandler signs requests with access key and secret key of cse.credentials
+type AKSKConsumerHandler struct {
+	once          sync.Once
+	accessKey     string
+	secretKey     string
+	signedHeaders []string
+	err           error
+}
+
+func newAKSKConsumerHandler() Handler {
+	return &AKSKConsumerHandler{}
+}
+
+// Name returns aksk-consumer
+func (h *AKSKConsumerHandler) Name() string {
+	return AKSKConsumer
+}
+
+func (h *AKSKConsumerHandler) init() {
+	c := config.GetAKSKConfig()
+	h.accessKey = c.AccessKey
+	h.signedHeaders = c.SignedHeaders
+	h.secretKey, h.err = aksk.DecryptSecretKey(c.SecretKey, c.Cipher)
+	if h.err == nil && (h.accessKey == "" || h.secretKey == "") {
+		h.err = aksk.ErrEmptyCredentials
+	}
+	if h.err != nil {
+		lager.Component(lager.ComponentHandler).Errorf(h.err, "init aksk credentials failed")
+	}
+}
+
+// Handle adds signature headers to request
+func (h *AKSKConsumerHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.once.Do(h.init)
+	if h.err != nil {
+		writeErr(h.err, cb)
+		return
+	}
+	if i.Ctx == nil {
+		i.Ctx = common.NewContext(nil)
+	}
+	r, err := signedRequest(i)
+	if err != nil {
+		writeErr(err, cb)
+		return
+	}
+	headers, err := aksk.Sign(r, h.accessKey, h.secretKey, h.signedHeaders, time.Now())
+	if err != nil {
+		writeErr(err, cb)
+		return
+	}
+	for k, v := range headers {
+		i.SetHeader(k, v)
+	}
+	chain.Next(i, cb)
+}
+
+// AKSKProviderHandler verifies signature of requests, it rejects invalid or replayed requests with 401
+type AKSKProviderHandler struct {
+	once     sync.Once
+	verifier *aksk.Verifier
+	err      error
+}
+
+func newAKSKProviderHandler() Handler {
+	return &AKSKProviderHandler{}
+}
+
+// Name returns aksk-provider
+func (h *AKSKProviderHandler) Name() string {
+	return AKSKProvider
+}
+
+func (h *AKSKProviderHandler) init() {
+	c := config.GetAKSKConfig()
+	f := aksk.GetPlugin(c.KeyStore)
+	if f == nil {
+		h.err = aksk.ErrKeyStoreNotFound
+		lager.Component(lager.ComponentHandler).Errorf(h.err, "key store [%s] of aksk-provider", c.KeyStore)
+		return
+	}
+	keys, err := f()
+	if err != nil {
+		h.err = err
+		lager.Component(lager.ComponentHandler).Errorf(err, "init key store [%s] failed", c.KeyStore)
+		return
+	}
+	h.verifier = aksk.NewVerifier(keys, c.MaxClockSkew)
+}
+
+// Handle verifies signature and puts access key in invocation metadata
+func (h *AKSKProviderHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.once.Do(h.init)
+	if h.err != nil {
+		writeStatusErr(http.StatusUnauthorized, h.err, cb)
+		return
+	}
+	r, err := signedRequest(i)
+	if err != nil {
+		writeStatusErr(http.StatusBadRequest, err, cb)
+		return
+	}
+	accessKey, err := h.verifier.Verify(r)
+	if err != nil {
+		logger(i.Ctx).Warnf("reject request of [%s], %s", i.SourceMicroService, err)
+		writeStatusErr(http.StatusUnauthorized, err, cb)
+		return
+	}
+	i.SetMetadata(aksk.MetadataAccessKey, accessKey)
+	chain.Next(i, cb)
+}
+
+// signedRequest returns part of request covered by signature, body of rest request is read and restored.
+// it returns aksk.ErrNotRestRequest for highway request, because serialized args can not be signed reliably
+func signedRequest(i *invocation.Invocation) (*aksk.Request, error) {
+	switch req := i.Args.(type) {
+	case *rest.Request:
+		// transport sets default content type and headers in context later, they are signed as they will be sent
+		if req.GetContentType() == "" {
+			req.SetContentType(common.JSON)
+		}
+		body, err := readBody(req.Req)
+		if err != nil {
+			return nil, err
+		}
+		return &aksk.Request{
+			Method: req.Req.Method,
+			Path:   req.Req.URL.Path,
+			Query:  req.Req.URL.Query(),
+			Header: func(name string) string {
+				for k, v := range common.FromContext(i.Ctx) {
+					if strings.EqualFold(k, name) {
+						return v
+					}
+				}
+				return req.Req.Header.Get(name)
+			},
+			Body: body,
+		}, nil
+	case *restful.Request:
+		body, err := readBody(req.Request)
+		if err != nil {
+			return nil, err
+		}
+		return &aksk.Request{
+			Method: req.Request.Method,
+			Path:   req.Request.URL.Path,
+			Query:  req.Request.URL.Query(),
+			Header: req.Request.Header.Get,
+			Body:   body,
+		}, nil
+	}
+	return nil, aksk.ErrNotRestRequest
+}
+
+// readBody reads body of req and restores it, so that it can be read again
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/auth/aksk"
+	"github.com/go-chassis/go-chassis/client/rest"
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	_ "github.com/go-chassis/go-chassis/security/plugins/plain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAKSKHandlers(t *testing.T) {
+	initEnv()
+	archaius.AddKeyValue("cse.credentials.accessKey", "ak")
+	archaius.AddKeyValue("cse.credentials.secretKey", "sk")
+	archaius.AddKeyValue("cse.auth.aksk.keys.ak", "sk")
+
+	body := []byte(`{"name":"peter"}`)
+	req, err := rest.NewRequest(http.MethodPost, "cse://Server/hello?a=1", body)
+	assert.NoError(t, err)
+	consumer := &invocation.Invocation{MicroServiceName: "Server", Args: req, Ctx: common.NewContext(nil)}
+	c := handler.Chain{}
+	h, err := handler.CreateHandler(handler.AKSKConsumer)
+	assert.NoError(t, err)
+	c.AddHandler(h)
+	c.Next(consumer, func(r *invocation.Response) error {
+		assert.NoError(t, r.Err)
+		return r.Err
+	})
+
+	// provider receives request with headers of context
+	httpReq := httptest.NewRequest(http.MethodPost, "/hello?a=1", bytes.NewReader(body))
+	httpReq.Header = req.Req.Header
+	for k, v := range consumer.Headers() {
+		httpReq.Header.Set(k, v)
+	}
+	provider, err := handler.CreateHandler(handler.AKSKProvider)
+	assert.NoError(t, err)
+	call := func() *invocation.Response {
+		c := handler.Chain{}
+		c.AddHandler(provider)
+		i := &invocation.Invocation{Args: restful.NewRequest(httpReq), Ctx: common.NewContext(nil)}
+		var resp *invocation.Response
+		c.Next(i, func(r *invocation.Response) error {
+			resp = r
+			if r.Err == nil {
+				assert.Equal(t, "ak", i.Metadata[aksk.MetadataAccessKey])
+			}
+			return r.Err
+		})
+		return resp
+	}
+	assert.NoError(t, call().Err)
+	// body is readable after verification
+	b := new(bytes.Buffer)
+	b.ReadFrom(httpReq.Body)
+	assert.Equal(t, body, b.Bytes())
+
+	httpReq.Body = httptest.NewRequest(http.MethodPost, "/hello", bytes.NewReader(body)).Body
+	r := call()
+	assert.Equal(t, aksk.ErrReplayedRequest, r.Err)
+	assert.Equal(t, http.StatusUnauthorized, r.Status)
+}
+
+func TestAKSKHandlersRejectHighway(t *testing.T) {
+	initEnv()
+	archaius.AddKeyValue("cse.credentials.accessKey", "ak")
+	archaius.AddKeyValue("cse.credentials.secretKey", "sk")
+	archaius.AddKeyValue("cse.auth.aksk.keys.ak", "sk")
+
+	for _, name := range []string{handler.AKSKConsumer, handler.AKSKProvider} {
+		h, err := handler.CreateHandler(name)
+		assert.NoError(t, err)
+		c := handler.Chain{}
+		c.AddHandler(h)
+		i := &invocation.Invocation{
+			MicroServiceName: "Server",
+			SchemaID:         "schema",
+			OperationID:      "SayHello",
+			Protocol:         common.ProtocolHighway,
+			Args:             &struct{ Name string }{Name: "peter"},
+			Ctx:              common.NewContext(nil),
+		}
+		var respErr error
+		c.Next(i, func(r *invocation.Response) error {
+			respErr = r.Err
+			return r.Err
+		})
+		assert.Equal(t, aksk.ErrNotRestRequest, respErr)
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/auth"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+var errAuthPluginNotInstalled = errors.New("auth plugin is not installed")
+
+// AuthProviderHandler checks whether caller is authorized to call the operation by auth plugin,
+// it rejects unauthenticated caller with 401 and unauthorized caller with 403
+type AuthProviderHandler struct {
+	once   sync.Once
+	plugin auth.Auth
+}
+
+func newAuthProviderHandler() Handler {
+	return &AuthProviderHandler{}
+}
+
+// Name returns auth-provider
+func (h *AuthProviderHandler) Name() string {
+	return AuthProvider
+}
+
+// Handle rejects request if auth plugin returns error
+func (h *AuthProviderHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.once.Do(h.init)
+	if h.plugin == nil {
+		// reject all requests, rather than let them pass without authorization
+		writeStatusErr(http.StatusForbidden, errAuthPluginNotInstalled, cb)
+		return
+	}
+	r := h.plugin.CheckAuthorization(newAuthCheck(i))
+	if r != nil && r.Err != nil {
+		logger(i.Ctx).Warnf("reject [%s] calling [%s.%s], %s", i.SourceMicroService, i.SchemaID, i.OperationID, r.Message)
+		status := http.StatusForbidden
+		if r.Err == auth.ErrUnauthenticated {
+			status = http.StatusUnauthorized
+		}
+		writeStatusErr(status, r.Err, cb)
+		return
+	}
+	chain.Next(i, cb)
+}
+
+func (h *AuthProviderHandler) init() {
+	name := config.GetAuthPlugin()
+	f := auth.GetPlugin(name)
+	if f == nil {
+		lager.Component(lager.ComponentHandler).Errorf(nil, "auth plugin [%s] is not installed", name)
+		return
+	}
+	var props map[string]string
+	if config.MicroserviceDefinition != nil {
+		props = config.MicroserviceDefinition.ServiceDescription.Properties
+	}
+	h.plugin = f(common.Provider, config.SelfServiceName, props)
+}
+
+func writeStatusErr(status int, err error, cb invocation.ResponseCallBack) {
+	cb(&invocation.Response{
+		Status: status,
+		Err:    err,
+	})
+}
+
+// newAuthCheck builds check of target operation, source service and headers of i
+func newAuthCheck(i *invocation.Invocation) *auth.Check {
+	check := &auth.Check{
+		TargetService:  i.MicroServiceName,
+		TargetSchema:   i.SchemaID,
+		TargetMethod:   i.OperationID,
+		SourceService:  i.SourceMicroService,
+		SourceIdentity: i.SourceIdentity,
+		Protocol:       i.Protocol,
+		Headers:        make(map[string]string),
+	}
+	if config.MicroserviceDefinition != nil {
+		check.TargetServiceProperties = config.MicroserviceDefinition.ServiceDescription.Properties
+	}
+	for k, v := range common.FromContext(i.Ctx) {
+		check.Headers[k] = v
+	}
+	if req, ok := i.Args.(*restful.Request); ok {
+		for k := range req.Request.Header {
+			check.Headers[k] = req.Request.Header.Get(k)
+		}
+	}
+	return check
+}
@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/auth"
+	"github.com/go-chassis/go-chassis/auth/acl"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthProviderHandler_Handle(t *testing.T) {
+	initEnv()
+	acl.Update(config.ACLSettings{
+		Allow: map[string][]string{"Client": {"HelloSchema.SayHello"}},
+	})
+	h, err := handler.CreateHandler(handler.AuthProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.AuthProvider, h.Name())
+
+	call := func(source, operation string) *invocation.Response {
+		c := handler.Chain{}
+		c.AddHandler(h)
+		i := &invocation.Invocation{
+			SourceMicroService: source,
+			SchemaID:           "HelloSchema",
+			OperationID:        operation,
+			Ctx:                context.Background(),
+		}
+		var resp *invocation.Response
+		c.Next(i, func(r *invocation.Response) error {
+			resp = r
+			return r.Err
+		})
+		return resp
+	}
+
+	r := call("Client", "SayHello")
+	assert.NoError(t, r.Err)
+	r = call("Client", "SayBye")
+	assert.Equal(t, auth.ErrForbidden, r.Err)
+	assert.Equal(t, http.StatusForbidden, r.Status)
+
+	acl.Update(config.ACLSettings{RequireIdentity: true})
+	r = call("Client", "SayHello")
+	assert.Equal(t, auth.ErrUnauthenticated, r.Err)
+	assert.Equal(t, http.StatusUnauthorized, r.Status)
+}
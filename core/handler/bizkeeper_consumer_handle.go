@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/go-chassis/go-chassis/client/rest"
 	"github.com/go-chassis/go-chassis/control"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/config"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/tracing"
 	"github.com/go-chassis/go-chassis/third_party/forked/afex/hystrix-go/hystrix"
 	"io"
 	"io/ioutil"
@@ -26,6 +26,13 @@ type BizKeeperConsumerHandler struct{}
 func (bk *BizKeeperConsumerHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
 	command, cmdConfig := control.DefaultPanel.GetCircuitBreaker(*i, common.Consumer)
 	hystrix.ConfigureCommand(command, cmdConfig)
+	if open, err := hystrix.IsCircuitBreakerOpen(command); err == nil {
+		state := "closed"
+		if open {
+			state = "open"
+		}
+		tracing.SetTag(i.Ctx, tracing.TagCircuitState, state)
+	}
 
 	finish := make(chan *invocation.Response, 1)
 	err := hystrix.Do(command, func() (err error) {
@@ -59,11 +66,13 @@ func GetFallbackFun(cmd, t string, i *invocation.Invocation, finish chan *invoca
 			if err.Error() == hystrix.ErrForceFallback.Error() || err.Error() == hystrix.ErrCircuitOpen.Error() ||
 				err.Error() == hystrix.ErrMaxConcurrency.Error() || err.Error() == hystrix.ErrTimeout.Error() {
 				// isolation happened, so lead to callback
-				lager.Logger.Errorf(err, fmt.Sprintf("fallback for %v", cmd))
+				logger(i.Ctx).Errorf(err, "fallback for %v", cmd)
+				policy := config.GetPolicy(i.MicroServiceName, t)
+				tracing.AddEvent(i.Ctx, tracing.EventFallback, "command", cmd, "reason", err.Error(), "policy", policy)
 				resp := &invocation.Response{}
 
 				var code = http.StatusOK
-				if config.PolicyNull == config.GetPolicy(i.MicroServiceName, t) {
+				if config.PolicyNull == policy {
 					resp.Err = hystrix.FallbackNullError{Message: "return null"}
 				} else {
 					resp.Err = hystrix.CircuitError{Message: i.MicroServiceName + " is isolated because of error: " + err.Error()}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strings"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/fault"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/tracing"
 
 	"github.com/valyala/fasthttp"
 )
@@ -44,7 +44,7 @@ func (rl *FaultHandler) Handle(chain *Chain, inv *invocation.Invocation, cb invo
 	faultInject, ok := fault.Injectors[inv.Protocol]
 	r := &invocation.Response{}
 	if !ok {
-		lager.Logger.Warnf("fault injection doesn't support for protocol ", errors.New(inv.Protocol))
+		logger(inv.Ctx).Warnf("fault injection doesn't support for protocol %s", inv.Protocol)
 		r.Err = nil
 		cb(r)
 		return
@@ -53,6 +53,7 @@ func (rl *FaultHandler) Handle(chain *Chain, inv *invocation.Invocation, cb invo
 	faultValue := faultConfig.Fault[inv.Protocol]
 	err := faultInject(faultValue, inv)
 	if err != nil {
+		tracing.AddEvent(inv.Ctx, tracing.EventFaultAbort, "error", err.Error())
 		if strings.Contains(err.Error(), "injecting abort") {
 			switch inv.Reply.(type) {
 			case *rest.Response:
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/util/string"
 )
 
@@ -13,7 +15,13 @@ var errViolateBuildIn = errors.New("can not replace build-in handler func")
 //ErrDuplicatedHandler means you registered more than 1 handler with same name
 var ErrDuplicatedHandler = errors.New("duplicated handler registration")
 var buildIn = []string{BizkeeperConsumer, BizkeeperProvider, Loadbalance, Router, TracingConsumer,
-	TracingProvider, RatelimiterConsumer, RatelimiterProvider, Transport, FaultInject}
+	TracingProvider, RatelimiterConsumer, RatelimiterProvider, Transport, FaultInject, MetricsConsumer, MetricsProvider, AuthProvider,
+	JWTProvider, JWTConsumer, AKSKProvider, AKSKConsumer, ValidationProvider, ValidationConsumer}
+
+// logger returns request scoped logger with log level of handler component
+func logger(ctx context.Context) *lager.ContextLogger {
+	return lager.FromContext(ctx).WithComponent(lager.ComponentHandler)
+}
 
 // HandlerFuncMap handler function map
 var HandlerFuncMap = make(map[string]func() Handler)
@@ -30,6 +38,15 @@ const (
 	RatelimiterProvider = "ratelimiter-provider"
 	Router              = "router"
 	FaultInject         = "fault-inject"
+	MetricsConsumer     = "metrics-consumer"
+	MetricsProvider     = "metrics-provider"
+	AuthProvider        = "auth-provider"
+	JWTProvider         = "jwt-provider"
+	JWTConsumer         = "jwt-consumer"
+	AKSKProvider        = "aksk-provider"
+	AKSKConsumer        = "aksk-consumer"
+	ValidationProvider  = "validation-provider"
+	ValidationConsumer  = "validation-consumer"
 )
 
 // init is for to initialize the all handlers at boot time
@@ -45,6 +62,15 @@ func init() {
 	HandlerFuncMap[TracingConsumer] = newTracingConsumerHandler
 	HandlerFuncMap[Router] = newRouterHandler
 	HandlerFuncMap[FaultInject] = newFaultHandler
+	HandlerFuncMap[MetricsConsumer] = newMetricsConsumerHandler
+	HandlerFuncMap[MetricsProvider] = newMetricsProviderHandler
+	HandlerFuncMap[AuthProvider] = newAuthProviderHandler
+	HandlerFuncMap[JWTProvider] = newJWTProviderHandler
+	HandlerFuncMap[JWTConsumer] = newJWTConsumerHandler
+	HandlerFuncMap[AKSKProvider] = newAKSKProviderHandler
+	HandlerFuncMap[AKSKConsumer] = newAKSKConsumerHandler
+	HandlerFuncMap[ValidationProvider] = newValidationProviderHandler
+	HandlerFuncMap[ValidationConsumer] = newValidationConsumerHandler
 }
 
 // Handler interface for handlers
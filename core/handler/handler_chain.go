@@ -8,6 +8,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
 )
 
 var errEmptyChain = errors.New("chain can not be empty")
@@ -38,10 +39,33 @@ func (c *Chain) Next(i *invocation.Invocation, f invocation.ResponseCallBack) {
 		f(r)
 		return
 	}
+	if index == 0 {
+		i.Ctx = lager.WithFields(i.Ctx, logFields(c.ServiceType, i))
+	}
 	c.HandlerIndex++
 	c.Handlers[index].Handle(c, i, f)
 }
 
+// logFields returns fields of invocation attached to logs of lager.FromContext,
+// instance id of consumer is unknown until load balancer picks one
+func logFields(serviceType string, i *invocation.Invocation) lager.Fields {
+	operation := i.OperationID
+	if operation == "" {
+		operation = i.URLPathFormat
+	} else if i.SchemaID != "" {
+		operation = i.SchemaID + "." + operation
+	}
+	fields := lager.Fields{
+		lager.FieldSourceService: i.SourceMicroService,
+		lager.FieldTargetService: i.MicroServiceName,
+		lager.FieldOperation:     operation,
+	}
+	if serviceType == common.Provider {
+		fields[lager.FieldInstanceID] = runtime.InstanceID
+	}
+	return fields
+}
+
 // Reset for to reset the handler index
 func (c *Chain) Reset() {
 	c.HandlerIndex = 0
@@ -96,7 +120,7 @@ func CreateChain(serviceType string, chainName string, handlerNames ...string) (
 		ServiceType: serviceType,
 		Name:        chainName,
 	}
-	lager.Logger.Debugf("add [%d] handlers for chain [%s]", len(handlerNames), chainName)
+	lager.Component(lager.ComponentHandler).Debugf("add [%d] handlers for chain [%s]", len(handlerNames), chainName)
 
 	for _, name := range handlerNames {
 		err := addHandler(c, name)
@@ -106,7 +130,7 @@ func CreateChain(serviceType string, chainName string, handlerNames ...string) (
 	}
 
 	if len(c.Handlers) == 0 {
-		lager.Logger.Warnf("Chain "+chainName+" is Empty", errEmptyChain)
+		lager.Component(lager.ComponentHandler).Warnf("Chain "+chainName+" is Empty", errEmptyChain)
 		return c, nil
 	}
 	return c, nil
@@ -7,6 +7,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/handler"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
 	"github.com/stretchr/testify/assert"
 	"log"
 	"os"
@@ -74,3 +75,45 @@ func BenchmarkChain_Next(b *testing.B) {
 		c.Reset()
 	}
 }
+
+type fieldsHandler struct {
+	fields lager.Fields
+}
+
+func (h *fieldsHandler) Handle(chain *handler.Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.fields = lager.FieldsFromContext(i.Ctx)
+	chain.Next(i, cb)
+}
+
+func (h *fieldsHandler) Name() string {
+	return "fields"
+}
+
+func TestChainLogFields(t *testing.T) {
+	h := &fieldsHandler{}
+	c := &handler.Chain{ServiceType: common.Provider, Handlers: []handler.Handler{h}}
+	runtime.InstanceID = "instance1"
+	i := &invocation.Invocation{
+		SourceMicroService: "consumer",
+		MicroServiceName:   "provider",
+		SchemaID:           "schema",
+		OperationID:        "op",
+	}
+	c.Next(i, func(r *invocation.Response) error {
+		return r.Err
+	})
+	assert.Equal(t, lager.Fields{
+		lager.FieldSourceService: "consumer",
+		lager.FieldTargetService: "provider",
+		lager.FieldOperation:     "schema.op",
+		lager.FieldInstanceID:    "instance1",
+	}, h.fields)
+
+	c = &handler.Chain{ServiceType: common.Consumer, Handlers: []handler.Handler{h}}
+	i = &invocation.Invocation{MicroServiceName: "provider", URLPathFormat: "/hello"}
+	c.Next(i, func(r *invocation.Response) error {
+		return r.Err
+	})
+	assert.Equal(t, "/hello", h.fields[lager.FieldOperation])
+	assert.NotContains(t, h.fields, lager.FieldInstanceID)
+}
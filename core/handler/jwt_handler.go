@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/auth/jwt"
+	"github.com/go-chassis/go-chassis/auth/oauth2"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// authorizationHeader is header carrying bearer token
+const authorizationHeader = "Authorization"
+
+// JWTProviderHandler validates bearer token in Authorization header,
+// it rejects request without valid token with 401, and token without scopes required by operation with 403
+type JWTProviderHandler struct {
+	once      sync.Once
+	validator *jwt.Validator
+	claims    map[string]string
+}
+
+func newJWTProviderHandler() Handler {
+	return &JWTProviderHandler{}
+}
+
+// Name returns jwt-provider
+func (h *JWTProviderHandler) Name() string {
+	return JWTProvider
+}
+
+func (h *JWTProviderHandler) init() {
+	c := config.GetJWTConfig()
+	if c.JWKS == "" {
+		lager.Component(lager.ComponentHandler).Errorf(nil, "jwks of jwt-provider is not configured, all requests are rejected")
+		return
+	}
+	h.validator = &jwt.Validator{
+		Keys:      jwt.NewJWKS(c.JWKS, c.JWKSRefreshInterval),
+		Issuer:    c.Issuer,
+		Audiences: c.Audiences,
+		ClockSkew: c.ClockSkew,
+	}
+	h.claims = c.ClaimsToMetadata
+}
+
+// Handle validates token, puts mapped claims in invocation metadata, and saves token in context for forwarding
+func (h *JWTProviderHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.once.Do(h.init)
+	token := jwt.BearerToken(requestHeader(i, authorizationHeader))
+	if token == "" {
+		writeStatusErr(http.StatusUnauthorized, jwt.ErrNoToken, cb)
+		return
+	}
+	if h.validator == nil {
+		writeStatusErr(http.StatusUnauthorized, jwt.ErrKeyNotFound, cb)
+		return
+	}
+	claims, err := h.validator.Validate(token)
+	if err != nil {
+		logger(i.Ctx).Warnf("reject token of [%s], %s", i.SourceMicroService, err)
+		writeStatusErr(http.StatusUnauthorized, err, cb)
+		return
+	}
+	if !claims.HasScopes(jwt.RequiredScopes(i.SchemaID, i.OperationID)) {
+		logger(i.Ctx).Warnf("token of [%s] has no scopes required by [%s.%s]", claims.Subject(), i.SchemaID, i.OperationID)
+		writeStatusErr(http.StatusForbidden, jwt.ErrInsufficientScope, cb)
+		return
+	}
+	for claim, key := range h.claims {
+		if v := claims.String(claim); v != "" {
+			i.SetMetadata(key, v)
+		}
+	}
+	i.Ctx = jwt.WithToken(i.Ctx, token)
+	chain.Next(i, cb)
+}
+
+// requestHeader returns header of rest request, or attachment of highway request
+func requestHeader(i *invocation.Invocation, name string) string {
+	if req, ok := i.Args.(*restful.Request); ok {
+		return req.Request.Header.Get(name)
+	}
+	for k, v := range common.FromContext(i.Ctx) {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// JWTConsumerHandler sets Authorization header of requests to providers,
+// in forward mode it forwards token saved by jwt-provider, in service mode it sends token of token source
+type JWTConsumerHandler struct {
+	once   sync.Once
+	mode   string
+	source oauth2.TokenSource
+	err    error
+}
+
+func newJWTConsumerHandler() Handler {
+	return &JWTConsumerHandler{}
+}
+
+// Name returns jwt-consumer
+func (h *JWTConsumerHandler) Name() string {
+	return JWTConsumer
+}
+
+func (h *JWTConsumerHandler) init() {
+	c := config.GetJWTConfig()
+	h.mode = c.ConsumerMode
+	if h.mode != config.TokenModeService {
+		return
+	}
+	f := oauth2.GetPlugin(c.TokenSource)
+	if f == nil {
+		h.err = fmt.Errorf("token source [%s] is not installed", c.TokenSource)
+	} else {
+		h.source, h.err = f()
+	}
+	if h.err != nil {
+		lager.Component(lager.ComponentHandler).Errorf(h.err, "init token source failed")
+	}
+}
+
+// Handle sets Authorization header
+func (h *JWTConsumerHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	h.once.Do(h.init)
+	if h.mode != config.TokenModeService {
+		if token := jwt.TokenFromContext(i.Ctx); token != "" {
+			i.SetHeader(authorizationHeader, "Bearer "+token)
+		}
+		chain.Next(i, cb)
+		return
+	}
+	if h.err != nil {
+		writeErr(h.err, cb)
+		return
+	}
+	token, err := h.source.Token()
+	if err != nil {
+		logger(i.Ctx).Errorf(err, "get service token failed")
+		writeErr(err, cb)
+		return
+	}
+	i.SetHeader(authorizationHeader, "Bearer "+token)
+	chain.Next(i, cb)
+}
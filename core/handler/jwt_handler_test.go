@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/auth/jwt"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTProviderHandler_NoToken(t *testing.T) {
+	initEnv()
+	c := handler.Chain{}
+	h, err := handler.CreateHandler(handler.JWTProvider)
+	assert.NoError(t, err)
+	c.AddHandler(h)
+	i := &invocation.Invocation{
+		SchemaID:    "HelloSchema",
+		OperationID: "SayHello",
+		Ctx:         common.NewContext(map[string]string{"Authorization": "Basic abc"}),
+	}
+	c.Next(i, func(r *invocation.Response) error {
+		assert.Equal(t, jwt.ErrNoToken, r.Err)
+		assert.Equal(t, http.StatusUnauthorized, r.Status)
+		return r.Err
+	})
+}
+
+func TestJWTConsumerHandler_Forward(t *testing.T) {
+	initEnv()
+	c := handler.Chain{}
+	h, err := handler.CreateHandler(handler.JWTConsumer)
+	assert.NoError(t, err)
+	c.AddHandler(h)
+	i := &invocation.Invocation{
+		MicroServiceName: "Server",
+		Ctx:              jwt.WithToken(common.NewContext(nil), "abc"),
+	}
+	c.Next(i, func(r *invocation.Response) error {
+		assert.NoError(t, r.Err)
+		return r.Err
+	})
+	assert.Equal(t, "Bearer abc", i.Headers()["Authorization"])
+
+	// no token to forward
+	c.Reset()
+	i = &invocation.Invocation{MicroServiceName: "Server", Ctx: context.Background()}
+	c.Next(i, func(r *invocation.Response) error {
+		assert.NoError(t, r.Err)
+		return r.Err
+	})
+	assert.Empty(t, common.FromContext(i.Ctx)["Authorization"])
+}
@@ -4,15 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/go-chassis/go-chassis/client/rest"
 	"github.com/go-chassis/go-chassis/control"
 	"github.com/go-chassis/go-chassis/core/archaius"
 	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/loadbalancer"
+	"github.com/go-chassis/go-chassis/core/tracing"
 	backoffUtil "github.com/go-chassis/go-chassis/pkg/backoff"
 	"github.com/go-chassis/go-chassis/session"
 )
@@ -27,13 +30,13 @@ func (lb *LBHandler) getEndpoint(i *invocation.Invocation, lbConfig control.Load
 		i.Strategy = lbConfig.Strategy
 		strategyFun, err = loadbalancer.GetStrategyPlugin(i.Strategy)
 		if err != nil {
-			lager.Logger.Errorf(err, loadbalancer.LBError{
+			logger(i.Ctx).Errorf(err, loadbalancer.LBError{
 				Message: "Get strategy [" + i.Strategy + "] failed."}.Error())
 		}
 	} else {
 		strategyFun, err = loadbalancer.GetStrategyPlugin(i.Strategy)
 		if err != nil {
-			lager.Logger.Errorf(err, loadbalancer.LBError{
+			logger(i.Ctx).Errorf(err, loadbalancer.LBError{
 				Message: "Get strategy [" + i.Strategy + "] failed."}.Error())
 		}
 	}
@@ -47,7 +50,7 @@ func (lb *LBHandler) getEndpoint(i *invocation.Invocation, lbConfig control.Load
 	}
 
 	s, err := loadbalancer.BuildStrategy(i.SourceServiceID, i.MicroServiceName, i.Protocol,
-		sessionID, i.Filters, strategyFun(), i.RouteTags)
+		sessionID, i.Filters, strategyFun(), i.RouteTags, loadbalancer.WithTesting(isTesting(i)))
 	if err != nil {
 		return "", err
 	}
@@ -73,9 +76,14 @@ func (lb *LBHandler) getEndpoint(i *invocation.Invocation, lbConfig control.Load
 		errStr := fmt.Sprintf("No available instance support ["+i.Protocol+"] protocol,"+
 			" msName: "+i.MicroServiceName+" %v", ins.EndpointsMap)
 		lbErr := loadbalancer.LBError{Message: errStr}
-		lager.Logger.Errorf(nil, lbErr.Error())
+		logger(i.Ctx).Errorf(nil, lbErr.Error())
 		return "", lbErr
 	}
+	i.Ctx = lager.WithFields(i.Ctx, lager.Fields{lager.FieldInstanceID: ins.InstanceID})
+	tracing.SetTag(i.Ctx, tracing.TagLBStrategy, i.Strategy)
+	tracing.SetTag(i.Ctx, tracing.TagLBInstance, ins.InstanceID)
+	tracing.AddEvent(i.Ctx, tracing.EventLBPick, "strategy", i.Strategy,
+		"instance", ins.InstanceID, "endpoint", ep)
 	return ep, nil
 }
 
@@ -134,7 +142,11 @@ func (lb *LBHandler) handleWithRetry(chain *Chain, i *invocation.Invocation, lbC
 			})
 			return respErr
 		}
-		if err = backoff.Retry(operation, lbBackoff); err == nil {
+		notify := func(err error, d time.Duration) {
+			tracing.AddEvent(i.Ctx, tracing.EventLBRetry, "endpoint", ep, "attempt", callTimes,
+				"error", err.Error(), "backoff", d.String())
+		}
+		if err = backoff.RetryNotify(operation, lbBackoff, notify); err == nil {
 			break
 		}
 	}
@@ -179,3 +191,15 @@ func getSessionID(i *invocation.Invocation) string {
 
 	return metadata.(string)
 }
+
+// isTesting returns true if invocation carries the configured testing header
+func isTesting(i *invocation.Invocation) bool {
+	h := config.GetTestingHeader(i.SourceMicroService, i.MicroServiceName)
+	if h == "" {
+		return false
+	}
+	if req, ok := i.Args.(*rest.Request); ok && req.Req != nil && req.Req.Header.Get(h) != "" {
+		return true
+	}
+	return common.FromContext(i.Ctx)[h] != ""
+}
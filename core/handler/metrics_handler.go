@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chassis/go-chassis/client/rest"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/tracing"
+	"github.com/go-chassis/go-chassis/metrics"
+)
+
+// constant for status label value of protocols without status code
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+// MetricsConsumerHandler records rate, errors and duration of outgoing calls
+type MetricsConsumerHandler struct{}
+
+// Handle records invocation metrics after response
+func (h *MetricsConsumerHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	start := time.Now()
+	chain.Next(i, func(r *invocation.Response) error {
+		err := cb(r)
+		recordRequest(metrics.RoleConsumer, i, r, start, r.Err != nil)
+		return err
+	})
+}
+
+// Name returns metrics-consumer string
+func (h *MetricsConsumerHandler) Name() string {
+	return MetricsConsumer
+}
+
+func newMetricsConsumerHandler() Handler {
+	return &MetricsConsumerHandler{}
+}
+
+// MetricsProviderHandler records rate, errors and duration of incoming calls
+type MetricsProviderHandler struct{}
+
+// Handle records invocation metrics after business logic returns
+func (h *MetricsProviderHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	start := time.Now()
+	chain.Next(i, func(r *invocation.Response) error {
+		err := cb(r)
+		recordRequest(metrics.RoleProvider, i, r, start, err != nil || r.Err != nil)
+		return err
+	})
+}
+
+// Name returns metrics-provider string
+func (h *MetricsProviderHandler) Name() string {
+	return MetricsProvider
+}
+
+func newMetricsProviderHandler() Handler {
+	return &MetricsProviderHandler{}
+}
+
+func recordRequest(role string, i *invocation.Invocation, r *invocation.Response, start time.Time, failed bool) {
+	operation := i.OperationID
+	if operation == "" {
+		// rest consumer has no operation id, label guard keeps paths with ids from flooding
+		operation = i.URLPathFormat
+	}
+	code := statusCode(i, r)
+	// server errors count as failures even if transport succeeded
+	failed = failed || code >= http.StatusInternalServerError
+	status := strconv.Itoa(code)
+	if code == 0 {
+		status = statusOK
+		if failed {
+			status = statusError
+		}
+	}
+	var traceID string
+	if metrics.ExemplarEnabled() {
+		traceID = tracing.TraceID(i.Ctx)
+	}
+	metrics.RecordRequest(metrics.RequestLabels{
+		Role:      role,
+		Source:    i.SourceMicroService,
+		Target:    i.MicroServiceName,
+		Schema:    i.SchemaID,
+		Operation: operation,
+		Protocol:  i.Protocol,
+		Status:    status,
+	}, time.Since(start), failed, traceID)
+}
+
+// statusCode returns http status code of rest invocation, it returns 0 if protocol has no status code
+func statusCode(i *invocation.Invocation, r *invocation.Response) int {
+	if r.Status != 0 {
+		return r.Status
+	}
+	if reply, ok := i.Reply.(*rest.Response); ok && reply != nil {
+		return reply.GetStatusCode()
+	}
+	return 0
+}
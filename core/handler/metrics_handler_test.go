@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/metrics"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestCount(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := metrics.GetSystemPrometheusRegistry().Gather()
+	assert.NoError(t, err)
+	var sum float64
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if matchLabels(m, labels) {
+				sum += m.GetCounter().GetValue()
+			}
+		}
+	}
+	return sum
+}
+
+func matchLabels(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, l := range m.GetLabel() {
+		if v, ok := labels[l.GetName()]; ok {
+			if v != l.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	c, err := handler.CreateChain(common.Consumer, "metrics", handler.MetricsConsumer)
+	assert.NoError(t, err)
+	inv := &invocation.Invocation{
+		SourceMicroService: "Client",
+		MicroServiceName:   "Server",
+		SchemaID:           "HelloServer",
+		OperationID:        "SayHello",
+		Protocol:           common.ProtocolHighway,
+	}
+	labels := map[string]string{metrics.LabelRole: metrics.RoleConsumer,
+		metrics.LabelTarget: "Server", metrics.LabelOperation: "SayHello"}
+	c.Next(inv, func(r *invocation.Response) error {
+		return nil
+	})
+	c.Reset()
+	c.Next(inv, func(r *invocation.Response) error {
+		return r.Err
+	})
+	assert.Equal(t, float64(2), requestCount(t, metrics.RequestTotal, labels))
+	assert.Equal(t, float64(0), requestCount(t, metrics.RequestErrorTotal, labels))
+
+	c, err = handler.CreateChain(common.Provider, "metrics", handler.MetricsProvider)
+	assert.NoError(t, err)
+	labels[metrics.LabelRole] = metrics.RoleProvider
+	c.Next(inv, func(r *invocation.Response) error {
+		r.Status = 503
+		return errors.New("unavailable")
+	})
+	labels[metrics.LabelStatus] = "503"
+	assert.Equal(t, float64(1), requestCount(t, metrics.RequestTotal, labels))
+	assert.Equal(t, float64(1), requestCount(t, metrics.RequestErrorTotal, labels))
+}
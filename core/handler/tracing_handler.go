@@ -30,6 +30,7 @@ func (t *TracingProviderHandler) Handle(chain *Chain, i *invocation.Invocation,
 		err           error
 		interfaceName = "unknown"
 		carrier       interface{}
+		format        interface{} = opentracing.TextMap
 	)
 
 	// extract span context
@@ -41,6 +42,8 @@ func (t *TracingProviderHandler) Handle(chain *Chain, i *invocation.Invocation,
 		case *restful.Request:
 			req := i.Args.(*restful.Request)
 			carrier = (opentracing.HTTPHeadersCarrier)(req.Request.Header)
+			// http header names are canonicalized, tracer must look them up case insensitively
+			format = opentracing.HTTPHeaders
 		case *fasthttp.Request:
 			req := i.Args.(*fasthttp.Request)
 			headerMap := make(map[string]string)
@@ -49,7 +52,7 @@ func (t *TracingProviderHandler) Handle(chain *Chain, i *invocation.Invocation,
 			})
 			carrier = &tracing.HeaderCarrier{Header: headerMap}
 		default:
-			lager.Logger.Error("rest consumer call arg is neither *restful.Request|*fasthttp.Request type.", nil)
+			logger(i.Ctx).Error("rest consumer call arg is neither *restful.Request|*fasthttp.Request type.", nil)
 			err = errors.New("type invalid")
 		}
 		if err != nil {
@@ -57,7 +60,7 @@ func (t *TracingProviderHandler) Handle(chain *Chain, i *invocation.Invocation,
 		}
 		// set url path to span name
 		if u, e := url.Parse(i.URLPathFormat); e != nil {
-			lager.Logger.Error("parse request url failed.", e)
+			logger(i.Ctx).Error("parse request url failed.", e)
 		} else {
 			interfaceName = u.Path
 		}
@@ -66,28 +69,28 @@ func (t *TracingProviderHandler) Handle(chain *Chain, i *invocation.Invocation,
 
 		// header stored in context
 		if i.Ctx == nil {
-			lager.Logger.Debug("No metadata found in Invocation.Ctx")
+			logger(i.Ctx).Debug("No metadata found in Invocation.Ctx")
 			break
 		}
 		at, ok := i.Ctx.Value(common.ContextHeaderKey{}).(map[string]string)
 		if !ok {
-			lager.Logger.Debug("No metadata found in Invocation.Ctx")
+			logger(i.Ctx).Debug("No metadata found in Invocation.Ctx")
 			break
 		}
 		carrier = (opentracing.TextMapCarrier)(at)
 	}
 
 	wireContext, err = tracer.Extract(
-		opentracing.TextMap,
+		format,
 		carrier,
 	)
 
 	switch err {
 	case nil:
 	case opentracing.ErrSpanContextNotFound:
-		lager.Logger.Debug(err.Error())
+		logger(i.Ctx).Debug(err.Error())
 	default:
-		lager.Logger.Errorf(err, "Extract span failed")
+		logger(i.Ctx).Errorf(err, "Extract span failed")
 	}
 	operationName := genOperationName(i.MicroServiceName, interfaceName)
 	span := tracer.StartSpan(operationName, ext.RPCServerOption(wireContext))
@@ -178,7 +181,7 @@ func (t *TracingConsumerHandler) Handle(chain *Chain, i *invocation.Invocation,
 		case *fasthttp.Request:
 			carrier = &(i.Args.(*fasthttp.Request).Header)
 		default:
-			lager.Logger.Error("rest consumer call arg is neither *rest.Request|*fasthttp.Request type.", nil)
+			logger(i.Ctx).Error("rest consumer call arg is neither *rest.Request|*fasthttp.Request type.", nil)
 			err = errors.New("type invalid")
 		}
 		if err != nil {
@@ -186,7 +189,7 @@ func (t *TracingConsumerHandler) Handle(chain *Chain, i *invocation.Invocation,
 		}
 
 		if err = tracer.Inject(span.Context(), opentracing.TextMap, carrier); err != nil {
-			lager.Logger.Errorf(err, "Inject span failed")
+			logger(i.Ctx).Errorf(err, "Inject span failed")
 		}
 	default:
 		// header stored in context
@@ -204,7 +207,7 @@ func (t *TracingConsumerHandler) Handle(chain *Chain, i *invocation.Invocation,
 			opentracing.TextMap,
 			(opentracing.TextMapCarrier)(header),
 		); err != nil {
-			lager.Logger.Errorf(err, "Inject span failed")
+			logger(i.Ctx).Errorf(err, "Inject span failed")
 		} else {
 			i.Ctx = context.WithValue(i.Ctx, common.ContextHeaderKey{}, header)
 		}
@@ -233,7 +236,7 @@ func setInterfaceName(interfaceName string, i *invocation.Invocation) string {
 	case common.ProtocolRest:
 		// set url path to span name
 		if u, e := url.Parse(i.URLPathFormat); e != nil {
-			lager.Logger.Error("parse request url failed.", e)
+			logger(i.Ctx).Error("parse request url failed.", e)
 		} else {
 			interfaceName = u.Path
 		}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/loadbalancer"
+	"github.com/go-chassis/go-chassis/core/tracing"
 	"github.com/go-chassis/go-chassis/session"
 )
 
@@ -20,11 +21,11 @@ type TransportHandler struct{}
 func (th *TransportHandler) Name() string {
 	return "transport"
 }
-func errNotNill(err error, cb invocation.ResponseCallBack) {
+func errNotNill(i *invocation.Invocation, err error, cb invocation.ResponseCallBack) {
 	r := &invocation.Response{
 		Err: err,
 	}
-	lager.Logger.Error("GetClient got Error", err)
+	logger(i.Ctx).Error("GetClient got Error", err)
 	cb(r)
 	return
 }
@@ -33,7 +34,7 @@ func errNotNill(err error, cb invocation.ResponseCallBack) {
 func (th *TransportHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
 	c, err := client.GetClient(i.Protocol, i.MicroServiceName)
 	if err != nil {
-		errNotNill(err, cb)
+		errNotNill(i, err, cb)
 	}
 
 	r := &invocation.Response{}
@@ -41,10 +42,17 @@ func (th *TransportHandler) Handle(chain *Chain, i *invocation.Invocation, cb in
 	//taking the time elapsed to check for latency aware strategy
 	timeBefore := time.Now()
 	err = c.Call(i.Ctx, i.Endpoint, i, i.Reply)
+	if err != nil {
+		tracing.AddEvent(i.Ctx, tracing.EventTransport, "endpoint", i.Endpoint,
+			"duration", time.Since(timeBefore).String(), "error", err.Error())
+	} else {
+		tracing.AddEvent(i.Ctx, tracing.EventTransport, "endpoint", i.Endpoint,
+			"duration", time.Since(timeBefore).String())
+	}
 
 	if err != nil {
 		r.Err = err
-		lager.Logger.Errorf(err, "Call got Error")
+		logger(i.Ctx).Errorf(err, "Call got Error")
 		if i.Strategy == loadbalancer.StrategySessionStickiness {
 			ProcessSpecialProtocol(i)
 			ProcessSuccessiveFailure(i)
@@ -84,7 +92,8 @@ func ProcessSpecialProtocol(inv *invocation.Invocation) {
 	}
 }
 
-//ProcessSuccessiveFailure handles special logic for protocol
+//ProcessSuccessiveFailure handles special logic for protocol,
+// failures are counted on session id since cookie is signed again on every response
 func ProcessSuccessiveFailure(i *invocation.Invocation) {
 	var cookie string
 	var reply *rest.Response
@@ -95,25 +104,24 @@ func ProcessSuccessiveFailure(i *invocation.Invocation) {
 			reply = i.Reply.(*rest.Response)
 		}
 		cookie = session.GetSessionCookie(nil, reply.GetResponse())
-		if cookie != "" {
-			loadbalancer.IncreaseSuccessiveFailureCount(cookie)
-			errCount := loadbalancer.GetSuccessiveFailureCount(cookie)
-			if errCount == config.StrategySuccessiveFailedTimes(i.SourceServiceID, i.MicroServiceName) {
-				session.DeletingKeySuccessiveFailure(reply.GetResponse())
-				loadbalancer.DeleteSuccessiveFailureCount(cookie)
-			}
-		}
 	default:
 		cookie = session.GetSessionCookie(i.Ctx, nil)
-		if cookie != "" {
-			loadbalancer.IncreaseSuccessiveFailureCount(cookie)
-			errCount := loadbalancer.GetSuccessiveFailureCount(cookie)
-			if errCount == config.StrategySuccessiveFailedTimes(i.SourceServiceID, i.MicroServiceName) {
-				session.DeletingKeySuccessiveFailure(nil)
-				loadbalancer.DeleteSuccessiveFailureCount(cookie)
-			}
-		}
 	}
+	sid := session.SessionIDOfCookie(cookie)
+	if sid == "" {
+		return
+	}
+	loadbalancer.IncreaseSuccessiveFailureCount(sid)
+	errCount := loadbalancer.GetSuccessiveFailureCount(sid)
+	if errCount != config.StrategySuccessiveFailedTimes(i.SourceServiceID, i.MicroServiceName) {
+		return
+	}
+	if i.Protocol == common.ProtocolRest {
+		session.DeletingKeySuccessiveFailure(reply.GetResponse())
+	} else {
+		i.Ctx = session.DeleteSessionFromContext(i.Ctx)
+	}
+	loadbalancer.DeleteSuccessiveFailureCount(sid)
 }
 
 func newTransportHandler() Handler {
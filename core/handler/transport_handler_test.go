@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-chassis/go-chassis"
@@ -14,9 +15,12 @@ import (
 	"github.com/go-chassis/go-chassis/core/handler"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/loadbalancer"
+	"github.com/go-chassis/go-chassis/core/registry"
 	"github.com/go-chassis/go-chassis/core/server"
 	"github.com/go-chassis/go-chassis/examples/schemas"
 	"github.com/go-chassis/go-chassis/examples/schemas/helloworld"
+	"github.com/go-chassis/go-chassis/session"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -145,3 +149,40 @@ func TestTransportHandler_HandleRest(t *testing.T) {
 	})
 
 }
+
+func TestProcessSuccessiveFailure(t *testing.T) {
+	p := filepath.Join(os.Getenv("GOPATH"), "src", "github.com", "go-chassis", "go-chassis", "examples", "discovery", "client")
+	os.Setenv("CHASSIS_HOME", p)
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	config.Init()
+	instances := []*registry.MicroServiceInstance{
+		{EndpointsMap: map[string]string{"rest": "1"}},
+		{EndpointsMap: map[string]string{"rest": "2"}},
+	}
+	i := &invocation.Invocation{Protocol: common.ProtocolHighway, MicroServiceName: "Server", Endpoint: "1"}
+	i.Ctx = session.SaveSessionIDFromContext(context.Background(), "1", 0)
+	pick := func() map[string]bool {
+		s := &loadbalancer.SessionStickinessStrategy{}
+		cookie := strings.TrimPrefix(session.GetContextMetadata(i.Ctx, common.LBSessionID), common.LBSessionID+"=")
+		s.ReceiveData(instances, "", "", cookie)
+		picked := make(map[string]bool)
+		for n := 0; n < len(instances); n++ {
+			instance, err := s.Pick()
+			assert.NoError(t, err)
+			picked[instance.EndpointsMap["rest"]] = true
+		}
+		return picked
+	}
+
+	times := config.StrategySuccessiveFailedTimes("", i.MicroServiceName)
+	for n := 0; n < times-1; n++ {
+		handler.ProcessSpecialProtocol(i)
+		handler.ProcessSuccessiveFailure(i)
+	}
+	assert.Equal(t, map[string]bool{"1": true}, pick())
+
+	// session is evicted, endpoint of former cookie is not picked by hint
+	handler.ProcessSpecialProtocol(i)
+	handler.ProcessSuccessiveFailure(i)
+	assert.True(t, pick()["2"])
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/client/rest"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/validator"
+)
+
+// ValidationProviderHandler validates rest requests against contract of the service before resource function runs,
+// request violating contract is rejected with 400 and field errors in json
+type ValidationProviderHandler struct{}
+
+func newValidationProviderHandler() Handler {
+	return &ValidationProviderHandler{}
+}
+
+// Name returns validation-provider
+func (h *ValidationProviderHandler) Name() string {
+	return ValidationProvider
+}
+
+// Handle validates path, query, header, form and body parameters, highway requests are not checked
+func (h *ValidationProviderHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	req, ok := i.Args.(*restful.Request)
+	if !ok {
+		chain.Next(i, cb)
+		return
+	}
+	body, err := readBody(req.Request)
+	if err != nil {
+		writeStatusErr(http.StatusBadRequest, err, cb)
+		return
+	}
+	r := &validator.Request{
+		Method: req.Request.Method,
+		Path:   req.Request.URL.Path,
+		Query:  req.Request.URL.Query(),
+		Header: req.Request.Header,
+		Body:   body,
+	}
+	if _, err := validator.Validate(i.MicroServiceName, r, config.GetValidationConfig().Strict); err != nil {
+		logger(i.Ctx).Warnf("reject request of [%s], %s", i.SourceMicroService, err)
+		writeStatusErr(http.StatusBadRequest, err, cb)
+		return
+	}
+	chain.Next(i, cb)
+}
+
+// ValidationConsumerHandler validates rest requests against contract of provider before they are sent,
+// it is mostly used in tests to find requests which providers would reject.
+// response body is validated too if cse.validation.response is true
+type ValidationConsumerHandler struct{}
+
+func newValidationConsumerHandler() Handler {
+	return &ValidationConsumerHandler{}
+}
+
+// Name returns validation-consumer
+func (h *ValidationConsumerHandler) Name() string {
+	return ValidationConsumer
+}
+
+// Handle validates request, and response if it is enabled
+func (h *ValidationConsumerHandler) Handle(chain *Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	req, ok := i.Args.(*rest.Request)
+	if !ok {
+		chain.Next(i, cb)
+		return
+	}
+	body, err := readBody(req.Req)
+	if err != nil {
+		writeErr(err, cb)
+		return
+	}
+	// headers in context are sent with request by transport
+	header := make(http.Header, len(req.Req.Header))
+	for k, v := range req.Req.Header {
+		header[k] = v
+	}
+	for k, v := range common.FromContext(i.Ctx) {
+		header.Set(k, v)
+	}
+	r := &validator.Request{
+		Method: req.Req.Method,
+		Path:   req.Req.URL.Path,
+		Query:  req.Req.URL.Query(),
+		Header: header,
+		Body:   body,
+	}
+	c := config.GetValidationConfig()
+	op, err := validator.Validate(i.MicroServiceName, r, c.Strict)
+	if err != nil {
+		writeErr(err, cb)
+		return
+	}
+	if op == nil || !c.Response {
+		chain.Next(i, cb)
+		return
+	}
+	chain.Next(i, func(ir *invocation.Response) error {
+		if ir.Err == nil {
+			if reply, ok := i.Reply.(*rest.Response); ok {
+				ir.Err = validateResponse(op, reply.GetResponse())
+			}
+		}
+		return cb(ir)
+	})
+}
+
+// validateResponse validates body of resp and restores it, so that it can be read by caller
+func validateResponse(op *validator.Operation, resp *http.Response) error {
+	if resp == nil {
+		return nil
+	}
+	var body []byte
+	if resp.Body != nil && resp.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return op.ValidateResponse(resp.StatusCode, resp.Header.Get("Content-Type"), body)
+}
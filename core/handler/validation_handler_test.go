@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/client/rest"
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/core/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func registerValidationContract(t *testing.T, service string) {
+	assert.NoError(t, validator.Register(service, &registry.SchemaContent{
+		Paths: map[string]map[string]registry.MethodInfo{
+			"/hello/{name}": {
+				"post": {
+					OperationID: "SayHello",
+					Parameters: []registry.Parameter{
+						{Name: "name", In: "path", Required: true, Type: "string", Pattern: "^[a-z]+$"},
+						{Name: "body", In: "body", Required: true, Schema: registry.SchemaValue{Reference: "#/definitions/Hello"}},
+					},
+					Response: map[string]registry.Response{
+						"200": {Schema: map[string]string{"$ref": "#/definitions/Hello"}},
+					},
+				},
+			},
+		},
+		Definition: map[string]registry.Definition{
+			"Hello": {
+				Types:      "object",
+				Required:   []string{"greeting"},
+				Properties: map[string]interface{}{"greeting": map[interface{}]interface{}{"type": "string"}},
+			},
+		},
+	}))
+}
+
+func TestValidationProviderHandler(t *testing.T) {
+	initEnv()
+	registerValidationContract(t, "ValidationProvider")
+	h, err := handler.CreateHandler(handler.ValidationProvider)
+	assert.NoError(t, err)
+	call := func(path, body string) *invocation.Response {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", common.JSON)
+		c := handler.Chain{}
+		c.AddHandler(h)
+		i := &invocation.Invocation{MicroServiceName: "ValidationProvider", Args: restful.NewRequest(req), Ctx: common.NewContext(nil)}
+		var resp *invocation.Response
+		c.Next(i, func(r *invocation.Response) error {
+			resp = r
+			return r.Err
+		})
+		if resp.Err == nil {
+			// body is readable by resource function
+			b, _ := ioutil.ReadAll(req.Body)
+			assert.Equal(t, body, string(b))
+		}
+		return resp
+	}
+	assert.NoError(t, call("/hello/peter", `{"greeting":"hi"}`).Err)
+	// operations not in contract pass unless strict is enabled
+	assert.NoError(t, call("/bye", ``).Err)
+
+	r := call("/hello/Peter", `{}`)
+	assert.Equal(t, http.StatusBadRequest, r.Status)
+	e, ok := r.Err.(*validator.Error)
+	assert.True(t, ok)
+	assert.Equal(t, "SayHello", e.Operation)
+	assert.Equal(t, 2, len(e.Errors))
+
+	archaius.AddKeyValue("cse.validation.strict", true)
+	defer archaius.DeleteKeyValue("cse.validation.strict", true)
+	assert.Equal(t, http.StatusBadRequest, call("/bye", ``).Status)
+}
+
+func TestValidationConsumerHandler(t *testing.T) {
+	initEnv()
+	registerValidationContract(t, "ValidationConsumer")
+	h, err := handler.CreateHandler(handler.ValidationConsumer)
+	assert.NoError(t, err)
+	call := func(path, body string, reply *rest.Response) error {
+		req, err := rest.NewRequest(http.MethodPost, "cse://ValidationConsumer"+path, []byte(body))
+		assert.NoError(t, err)
+		c := handler.Chain{}
+		c.AddHandler(h)
+		c.AddHandler(&replyHandler{reply: reply})
+		i := &invocation.Invocation{MicroServiceName: "ValidationConsumer", Args: req, Reply: reply, Ctx: common.NewContext(nil)}
+		var respErr error
+		c.Next(i, func(r *invocation.Response) error {
+			respErr = r.Err
+			return r.Err
+		})
+		return respErr
+	}
+	reply := func(body string) *rest.Response {
+		resp := rest.NewResponse()
+		resp.Resp.StatusCode = http.StatusOK
+		resp.Resp.Body = ioutil.NopCloser(bytes.NewReader([]byte(body)))
+		return resp
+	}
+	assert.NoError(t, call("/hello/peter", `{"greeting":"hi"}`, reply(`{}`)))
+	_, ok := call("/hello/peter", `{"greeting":1}`, reply(`{}`)).(*validator.Error)
+	assert.True(t, ok)
+
+	archaius.AddKeyValue("cse.validation.response", true)
+	defer archaius.DeleteKeyValue("cse.validation.response", true)
+	assert.Error(t, call("/hello/peter", `{"greeting":"hi"}`, reply(`{}`)))
+	resp := reply(`{"greeting":"hello"}`)
+	assert.NoError(t, call("/hello/peter", `{"greeting":"hi"}`, resp))
+	// body is readable by caller
+	assert.Equal(t, `{"greeting":"hello"}`, string(resp.ReadBody()))
+}
+
+// replyHandler responds with reply instead of sending request
+type replyHandler struct {
+	reply *rest.Response
+}
+
+func (h *replyHandler) Handle(chain *handler.Chain, i *invocation.Invocation, cb invocation.ResponseCallBack) {
+	cb(&invocation.Response{Status: h.reply.GetStatusCode(), Result: h.reply})
+}
+
+func (h *replyHandler) Name() string {
+	return "reply"
+}
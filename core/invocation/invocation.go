@@ -30,6 +30,7 @@ type Invocation struct {
 	Protocol           string
 	SourceServiceID    string
 	SourceMicroService string
+	SourceIdentity     string //identity of verified mutual tls peer certificate, it is empty if caller is not authenticated
 	MicroServiceName   string //Target micro service name
 	SchemaID           string //correspond struct name
 	OperationID        string //correspond struct func name
@@ -49,6 +50,7 @@ func (inv *Invocation) Reset() {
 	inv.Protocol = ""
 	inv.SourceServiceID = ""
 	inv.SourceMicroService = ""
+	inv.SourceIdentity = ""
 	inv.MicroServiceName = ""
 	inv.SchemaID = ""
 	inv.OperationID = ""
@@ -0,0 +1,163 @@
+package lager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-chassis/paas-lager/third_party/forked/cloudfoundry/lager"
+)
+
+// constants for field names of request scoped logs, they are stable so that logs can be joined with traces
+const (
+	FieldTraceID       = "trace_id"
+	FieldSpanID        = "span_id"
+	FieldSourceService = "source_service"
+	FieldTargetService = "target_service"
+	FieldOperation     = "operation"
+	FieldInstanceID    = "instance_id"
+)
+
+// Fields is key value pairs written in data of a json log line
+type Fields map[string]interface{}
+
+// ContextFieldsFunc returns fields which are not stored by WithFields, for example trace id of the active span in ctx
+type ContextFieldsFunc func(ctx context.Context) Fields
+
+var (
+	fieldsFuncs   []ContextFieldsFunc
+	fieldsFuncsMu sync.RWMutex
+)
+
+// RegisterContextFields registers f, fields of f are attached to every log line of FromContext
+func RegisterContextFields(f ContextFieldsFunc) {
+	fieldsFuncsMu.Lock()
+	fieldsFuncs = append(fieldsFuncs, f)
+	fieldsFuncsMu.Unlock()
+}
+
+type fieldsKey struct{}
+
+// WithFields returns a copy of ctx holding fields, fields already in ctx are kept unless they are overwritten
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	old, _ := ctx.Value(fieldsKey{}).(Fields)
+	merged := make(Fields, len(old)+len(fields))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FieldsFromContext returns fields stored in ctx and fields of registered ContextFieldsFunc
+func FieldsFromContext(ctx context.Context) Fields {
+	fields := make(Fields)
+	if ctx == nil {
+		return fields
+	}
+	if stored, ok := ctx.Value(fieldsKey{}).(Fields); ok {
+		for k, v := range stored {
+			fields[k] = v
+		}
+	}
+	fieldsFuncsMu.RLock()
+	defer fieldsFuncsMu.RUnlock()
+	for _, f := range fieldsFuncs {
+		for k, v := range f(ctx) {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// ContextLogger writes logs with request scoped fields, fields are put in data of json log line,
+// for example:
+//
+//	lager.FromContext(inv.Ctx).Errorf(err, "call %s failed", inv.MicroServiceName)
+type ContextLogger struct {
+	fields    Fields
+	component string
+}
+
+// FromContext returns logger attaching fields of ctx, such as trace id, span id, source and target service
+func FromContext(ctx context.Context) *ContextLogger {
+	return &ContextLogger{fields: FieldsFromContext(ctx)}
+}
+
+// With returns a copy of logger with additional fields
+func (l *ContextLogger) With(fields Fields) *ContextLogger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &ContextLogger{fields: merged, component: l.component}
+}
+
+// WithComponent returns a copy of logger writing with level of component
+func (l *ContextLogger) WithComponent(component string) *ContextLogger {
+	return &ContextLogger{fields: l.fields, component: component}
+}
+
+// Fields returns fields attached by logger
+func (l *ContextLogger) Fields() Fields {
+	return l.fields
+}
+
+func (l *ContextLogger) logger() lager.Logger {
+	if l.component == "" {
+		return Logger
+	}
+	return Component(l.component)
+}
+
+func (l *ContextLogger) data() lager.Data {
+	return lager.Data(l.fields)
+}
+
+// Debug writes debug log
+func (l *ContextLogger) Debug(msg string) {
+	l.logger().Debug(msg, l.data())
+}
+
+// Info writes info log
+func (l *ContextLogger) Info(msg string) {
+	l.logger().Info(msg, l.data())
+}
+
+// Warn writes warn log
+func (l *ContextLogger) Warn(msg string) {
+	l.logger().Warn(msg, l.data())
+}
+
+// Error writes error log, err can be nil
+func (l *ContextLogger) Error(msg string, err error) {
+	l.logger().Error(msg, err, l.data())
+}
+
+// Debugf writes formatted debug log
+func (l *ContextLogger) Debugf(format string, args ...interface{}) {
+	l.logger().Debug(fmt.Sprintf(format, args...), l.data())
+}
+
+// Infof writes formatted info log
+func (l *ContextLogger) Infof(format string, args ...interface{}) {
+	l.logger().Info(fmt.Sprintf(format, args...), l.data())
+}
+
+// Warnf writes formatted warn log
+func (l *ContextLogger) Warnf(format string, args ...interface{}) {
+	l.logger().Warn(fmt.Sprintf(format, args...), l.data())
+}
+
+// Errorf writes formatted error log, err can be nil
+func (l *ContextLogger) Errorf(err error, format string, args ...interface{}) {
+	l.logger().Error(fmt.Sprintf(format, args...), err, l.data())
+}
@@ -0,0 +1,34 @@
+package lager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type userKey struct{}
+
+func TestWithFields(t *testing.T) {
+	assert.Empty(t, FieldsFromContext(nil))
+
+	ctx := WithFields(nil, Fields{FieldSourceService: "consumer", FieldOperation: "op1"})
+	child := WithFields(ctx, Fields{FieldOperation: "op2", FieldInstanceID: "1"})
+	assert.Equal(t, Fields{FieldSourceService: "consumer", FieldOperation: "op1"}, FieldsFromContext(ctx))
+	assert.Equal(t, Fields{FieldSourceService: "consumer", FieldOperation: "op2", FieldInstanceID: "1"},
+		FieldsFromContext(child))
+}
+
+func TestRegisterContextFields(t *testing.T) {
+	RegisterContextFields(func(ctx context.Context) Fields {
+		if user, ok := ctx.Value(userKey{}).(string); ok {
+			return Fields{"user": user}
+		}
+		return nil
+	})
+	ctx := WithFields(context.WithValue(context.Background(), userKey{}, "alice"), Fields{FieldTargetService: "provider"})
+	l := FromContext(ctx)
+	assert.Equal(t, Fields{"user": "alice", FieldTargetService: "provider"}, l.Fields())
+	assert.Equal(t, Fields{"user": "alice", FieldTargetService: "provider", "retry": 1}, l.With(Fields{"retry": 1}).Fields())
+	assert.NotContains(t, l.Fields(), "retry")
+}
@@ -0,0 +1,100 @@
+package lager
+
+import (
+	"sync/atomic"
+
+	"github.com/go-chassis/paas-lager/third_party/forked/cloudfoundry/lager"
+)
+
+// globalLogger is the value of Logger, it forwards logs to logger of global level,
+// so that changing global level at runtime never writes Logger which is read by all goroutines
+type globalLogger struct{}
+
+// holder wraps logger, because atomic.Value requires values of the same concrete type
+type holder struct {
+	l lager.Logger
+}
+
+// current holds logger of global level
+var current atomic.Value
+
+var global lager.Logger = globalLogger{}
+
+// setGlobal switches logger of global level
+func setGlobal(l lager.Logger) {
+	current.Store(holder{l: l})
+}
+
+func (globalLogger) logger() lager.Logger {
+	return current.Load().(holder).l
+}
+
+// RegisterSink registers sink to logger of global level
+func (g globalLogger) RegisterSink(sink lager.Sink) {
+	g.logger().RegisterSink(sink)
+}
+
+// Session returns session of logger of global level
+func (g globalLogger) Session(task string, data ...lager.Data) lager.Logger {
+	return g.logger().Session(task, data...)
+}
+
+// SessionName returns session name of logger of global level
+func (g globalLogger) SessionName() string {
+	return g.logger().SessionName()
+}
+
+// WithData returns logger of global level with data
+func (g globalLogger) WithData(data lager.Data) lager.Logger {
+	return g.logger().WithData(data)
+}
+
+// Debug writes debug log
+func (g globalLogger) Debug(action string, data ...lager.Data) {
+	g.logger().Debug(action, data...)
+}
+
+// Info writes info log
+func (g globalLogger) Info(action string, data ...lager.Data) {
+	g.logger().Info(action, data...)
+}
+
+// Warn writes warn log
+func (g globalLogger) Warn(action string, data ...lager.Data) {
+	g.logger().Warn(action, data...)
+}
+
+// Error writes error log
+func (g globalLogger) Error(action string, err error, data ...lager.Data) {
+	g.logger().Error(action, err, data...)
+}
+
+// Fatal writes fatal log
+func (g globalLogger) Fatal(action string, err error, data ...lager.Data) {
+	g.logger().Fatal(action, err, data...)
+}
+
+// Debugf writes formatted debug log
+func (g globalLogger) Debugf(format string, args ...interface{}) {
+	g.logger().Debugf(format, args...)
+}
+
+// Infof writes formatted info log
+func (g globalLogger) Infof(format string, args ...interface{}) {
+	g.logger().Infof(format, args...)
+}
+
+// Warnf writes formatted warn log
+func (g globalLogger) Warnf(format string, args ...interface{}) {
+	g.logger().Warnf(format, args...)
+}
+
+// Errorf writes formatted error log
+func (g globalLogger) Errorf(err error, format string, args ...interface{}) {
+	g.logger().Errorf(err, format, args...)
+}
+
+// Fatalf writes formatted fatal log
+func (g globalLogger) Fatalf(err error, format string, args ...interface{}) {
+	g.logger().Fatalf(err, format, args...)
+}
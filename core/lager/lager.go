@@ -18,7 +18,8 @@ const (
 	RollingPolicySize = "size"
 )
 
-// Logger is the global variable for the object of lager.Logger
+// Logger is the global variable for the object of lager.Logger,
+// it is the same value after Initialize, logs are written with global level which can be changed at runtime
 var Logger lager.Logger
 
 // logFilePath log file path
@@ -51,7 +52,9 @@ func Initialize(writers, loggerLevel, loggerFile, rollingPolicy string, logForma
 	}
 
 	log.Println("Enable log tool")
-	Logger = newLog(lag)
+	l := newLog(lag)
+	initLevels(lag, l)
+	Logger = global
 	initLogRotate(logFilePath, lag)
 	return
 }
@@ -67,19 +70,22 @@ func newLog(lag *Lager) lager.Logger {
 		createLogFile(os.Getenv("CHASSIS_HOME"), lag.LoggerFile)
 		logFilePath = filepath.Join(os.Getenv("CHASSIS_HOME"), lag.LoggerFile)
 	}
+	return newLevelLogger(lag, lag.LoggerLevel)
+}
+
+// newLevelLogger creates logger writing to writers of lag with level
+func newLevelLogger(lag *Lager, level string) lager.Logger {
 	writers := strings.Split(strings.TrimSpace(lag.Writers), ",")
 	if len(strings.TrimSpace(lag.Writers)) == 0 {
 		writers = []string{"stdout"}
 	}
 	paaslager.Init(paaslager.Config{
 		Writers:       writers,
-		LoggerLevel:   lag.LoggerLevel,
+		LoggerLevel:   level,
 		LoggerFile:    logFilePath,
 		LogFormatText: lag.LogFormatText,
 	})
-
-	logger := paaslager.NewLogger(lag.LoggerFile)
-	return logger
+	return paaslager.NewLogger(lag.LoggerFile)
 }
 
 // checkPassLagerDefinition check pass lager definition
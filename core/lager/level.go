@@ -0,0 +1,241 @@
+package lager
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/paas-lager/third_party/forked/cloudfoundry/lager"
+)
+
+// constants for log levels
+const (
+	LevelDebug = "DEBUG"
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+	LevelFatal = "FATAL"
+)
+
+// constants for components whose log level can be changed separately
+const (
+	ComponentRegistry     = "registry"
+	ComponentLoadBalancer = "loadbalancer"
+	ComponentHandler      = "handler"
+	ComponentConfigCenter = "config-center"
+	ComponentHighway      = "highway"
+)
+
+// Components is the list of components whose log level can be changed separately
+var Components = []string{
+	ComponentRegistry,
+	ComponentLoadBalancer,
+	ComponentHandler,
+	ComponentConfigCenter,
+	ComponentHighway,
+}
+
+// errors of level control
+var (
+	ErrInvalidLevel     = errors.New("invalid log level, level must be one of DEBUG, INFO, WARN, ERROR, FATAL")
+	ErrUnknownComponent = errors.New("unknown log component")
+)
+
+// levelState holds levels changed at runtime,
+// a logger is created for each level in use, because level of paas-lager logger is fixed
+type levelState struct {
+	mu  sync.RWMutex
+	lag *Lager
+	// initial is level of lager.yaml
+	initial    string
+	global     string
+	components map[string]string
+	loggers    map[string]lager.Logger
+	timers     map[string]*time.Timer
+}
+
+var levels = &levelState{
+	components: make(map[string]string),
+	loggers:    make(map[string]lager.Logger),
+	timers:     make(map[string]*time.Timer),
+}
+
+func initLevels(lag *Lager, l lager.Logger) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	for _, t := range levels.timers {
+		t.Stop()
+	}
+	levels.lag = lag
+	levels.initial = strings.ToUpper(lag.LoggerLevel)
+	if level, err := ParseLevel(lag.LoggerLevel); err == nil {
+		levels.initial = level
+	}
+	levels.global = levels.initial
+	levels.components = make(map[string]string)
+	levels.loggers = map[string]lager.Logger{levels.initial: l}
+	setGlobal(l)
+	levels.timers = make(map[string]*time.Timer)
+}
+
+// ParseLevel returns upper case level, it returns ErrInvalidLevel if level is unknown
+func ParseLevel(level string) (string, error) {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	switch level {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal:
+		return level, nil
+	}
+	return "", ErrInvalidLevel
+}
+
+// IsComponent returns true if level of name can be changed separately
+func IsComponent(name string) bool {
+	for _, c := range Components {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLevel changes log level of component at runtime, empty component means global level,
+// which is used by Logger and components without their own level.
+// if revertAfter is positive, level reverts to the former one after revertAfter,
+// so that debug logging switched on in production turns itself off
+func SetLevel(component, level string, revertAfter time.Duration) error {
+	level, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	if component != "" && !IsComponent(component) {
+		return ErrUnknownComponent
+	}
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	former, ok := levels.get(component)
+	levels.set(component, level, true)
+	levels.stopTimer(component)
+	if revertAfter > 0 {
+		var t *time.Timer
+		t = time.AfterFunc(revertAfter, func() {
+			levels.mu.Lock()
+			defer levels.mu.Unlock()
+			// level is changed again after t is created
+			if levels.timers[component] != t {
+				return
+			}
+			delete(levels.timers, component)
+			levels.set(component, former, ok)
+			if Logger != nil {
+				Logger.Infof("log level of [%s] reverts to [%s]", componentName(component), levels.level(component))
+			}
+		})
+		levels.timers[component] = t
+	}
+	return nil
+}
+
+// ResetLevel removes level set by SetLevel, component uses global level again,
+// global level reverts to level of lager.yaml
+func ResetLevel(component string) error {
+	if component != "" && !IsComponent(component) {
+		return ErrUnknownComponent
+	}
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.stopTimer(component)
+	levels.set(component, "", false)
+	return nil
+}
+
+// Level returns level in effect of component, empty component means global level
+func Level(component string) string {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	return levels.level(component)
+}
+
+// Levels returns global level and levels set separately for components
+func Levels() (string, map[string]string) {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	components := make(map[string]string, len(levels.components))
+	for k, v := range levels.components {
+		components[k] = v
+	}
+	return levels.global, components
+}
+
+// Component returns logger with level of component, it returns Logger if lager is not initialized
+func Component(name string) lager.Logger {
+	levels.mu.RLock()
+	l, ok := levels.loggers[levels.level(name)]
+	levels.mu.RUnlock()
+	if ok {
+		return l
+	}
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	return levels.logger(levels.level(name))
+}
+
+func componentName(component string) string {
+	if component == "" {
+		return "global"
+	}
+	return component
+}
+
+func (s *levelState) level(component string) string {
+	if l, ok := s.components[component]; ok {
+		return l
+	}
+	return s.global
+}
+
+func (s *levelState) get(component string) (string, bool) {
+	if component == "" {
+		return s.global, true
+	}
+	l, ok := s.components[component]
+	return l, ok
+}
+
+func (s *levelState) set(component, level string, ok bool) {
+	if component != "" {
+		if ok {
+			s.components[component] = level
+		} else {
+			delete(s.components, component)
+		}
+		return
+	}
+	if !ok {
+		level = s.initial
+	}
+	s.global = level
+	if s.lag != nil {
+		setGlobal(s.logger(level))
+	}
+}
+
+func (s *levelState) stopTimer(component string) {
+	if t, ok := s.timers[component]; ok {
+		t.Stop()
+		delete(s.timers, component)
+	}
+}
+
+// logger returns logger of level, the logger is created if it does not exist
+func (s *levelState) logger(level string) lager.Logger {
+	if l, ok := s.loggers[level]; ok {
+		return l
+	}
+	if s.lag == nil {
+		return Logger
+	}
+	l := newLevelLogger(s.lag, level)
+	s.loggers[level] = l
+	return l
+}
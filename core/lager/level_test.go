@@ -0,0 +1,97 @@
+package lager
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/paas-lager/third_party/forked/cloudfoundry/lager"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetLevels() {
+	levels = &levelState{
+		initial:    LevelInfo,
+		global:     LevelInfo,
+		components: make(map[string]string),
+		loggers:    make(map[string]lager.Logger),
+		timers:     make(map[string]*time.Timer),
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	resetLevels()
+	assert.Equal(t, ErrInvalidLevel, SetLevel("", "verbose", 0))
+	assert.Equal(t, ErrUnknownComponent, SetLevel("unknown", LevelDebug, 0))
+
+	assert.NoError(t, SetLevel(ComponentRegistry, "debug", 0))
+	assert.Equal(t, LevelDebug, Level(ComponentRegistry))
+	assert.Equal(t, LevelInfo, Level(ComponentHighway))
+
+	assert.NoError(t, SetLevel("", LevelWarn, 0))
+	global, components := Levels()
+	assert.Equal(t, LevelWarn, global)
+	assert.Equal(t, map[string]string{ComponentRegistry: LevelDebug}, components)
+	assert.Equal(t, LevelWarn, Level(ComponentHighway))
+
+	assert.NoError(t, ResetLevel(ComponentRegistry))
+	assert.Equal(t, LevelWarn, Level(ComponentRegistry))
+	assert.NoError(t, ResetLevel(""))
+	assert.Equal(t, LevelInfo, Level(""))
+}
+
+func TestSetLevelRevert(t *testing.T) {
+	resetLevels()
+	assert.NoError(t, SetLevel(ComponentHandler, LevelDebug, 20*time.Millisecond))
+	assert.NoError(t, SetLevel("", LevelDebug, 20*time.Millisecond))
+	assert.Equal(t, LevelDebug, Level(ComponentHandler))
+	assert.Equal(t, LevelDebug, Level(""))
+	time.Sleep(100 * time.Millisecond)
+	_, components := Levels()
+	assert.Empty(t, components)
+	assert.Equal(t, LevelInfo, Level(""))
+
+	// setting level again cancels revert
+	assert.NoError(t, SetLevel(ComponentHandler, LevelDebug, 20*time.Millisecond))
+	assert.NoError(t, SetLevel(ComponentHandler, LevelError, 0))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, LevelError, Level(ComponentHandler))
+}
+
+// countLogger counts info logs
+type countLogger struct {
+	lager.Logger
+	count *int32
+}
+
+func (l countLogger) Info(action string, data ...lager.Data) {
+	atomic.AddInt32(l.count, 1)
+}
+
+func TestSetGlobalLevel(t *testing.T) {
+	resetLevels()
+	var info, debug int32
+	levels.lag = &Lager{}
+	levels.loggers[LevelInfo] = countLogger{count: &info}
+	levels.loggers[LevelDebug] = countLogger{count: &debug}
+	setGlobal(levels.loggers[LevelInfo])
+	defer resetLevels()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			global.Info("concurrent")
+		}
+	}()
+	assert.NoError(t, SetLevel("", LevelDebug, 0))
+	<-done
+	assert.Equal(t, int32(100), atomic.LoadInt32(&info)+atomic.LoadInt32(&debug))
+
+	before := atomic.LoadInt32(&debug)
+	global.Info("debug")
+	assert.Equal(t, before+1, atomic.LoadInt32(&debug))
+	assert.NoError(t, ResetLevel(""))
+	global.Info("info")
+	assert.Equal(t, before+1, atomic.LoadInt32(&debug))
+}
@@ -0,0 +1,41 @@
+package loadbalancer
+
+import (
+	"sync"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/session"
+)
+
+var subscribeOnce sync.Once
+
+// subscribeInstanceEvents invalidates state of unavailable instances immediately
+// instead of waiting for next cache refresh
+func subscribeInstanceEvents() {
+	subscribeOnce.Do(func() {
+		registry.Subscribe(registry.AllServices, onInstanceEvent)
+	})
+}
+
+func onInstanceEvent(e registry.InstanceEvent) {
+	if e.Instance == nil {
+		return
+	}
+	switch e.Action {
+	case registry.EventDelete:
+	case registry.EventUpdate:
+		if e.Instance.Status == "" || e.Instance.Status == common.DefaultStatus {
+			return
+		}
+	default:
+		return
+	}
+	for _, ep := range e.Instance.EndpointsMap {
+		DeleteLatency(ep)
+		session.DeleteByEndpoint(ep)
+	}
+	lager.Component(lager.ComponentLoadBalancer).Debugf("Instance [%s] of service [%s] is unavailable, clear its lb stats and sessions",
+		e.Instance.InstanceID, e.ServiceName)
+}
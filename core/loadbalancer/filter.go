@@ -3,11 +3,14 @@ package loadbalancer
 import (
 	"github.com/go-chassis/go-chassis/core/config"
 	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/healthz/checker"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
 )
 
-// constant string for zoneaware
+// constant string for filter names
 const (
 	ZoneAware = "zoneaware"
+	Healthy   = "healthy"
 )
 
 // Filters is a map of string and array of *registry.MicroServiceInstance
@@ -20,6 +23,42 @@ func InstallFilter(name string, f Filter) {
 
 func init() {
 	InstallFilter(ZoneAware, FilterAvailableZoneAffinity)
+	InstallFilter(Healthy, FilterHealthy)
+}
+
+//FilterHealthy removes instances which active health check marks unhealthy,
+//if all instances are unhealthy, it returns all of them rather than none
+func FilterHealthy(old []*registry.MicroServiceInstance, c []*Criteria) []*registry.MicroServiceInstance {
+	instances := make([]*registry.MicroServiceInstance, 0, len(old))
+	for _, ins := range old {
+		if checker.IsHealthy(ins.InstanceID) {
+			instances = append(instances, ins)
+		}
+	}
+	if len(instances) == 0 {
+		return old
+	}
+	return instances
+}
+
+//FilterStatus removes instances which should not receive the request according to instance status,
+//UP instances receive normal requests, OUTOFSERVICE instances receive nothing,
+//TESTING instances receive only testing requests, a testing request goes to UP instances if there is no TESTING instance
+func FilterStatus(old []*registry.MicroServiceInstance, testing bool) []*registry.MicroServiceInstance {
+	ups := make([]*registry.MicroServiceInstance, 0, len(old))
+	testings := make([]*registry.MicroServiceInstance, 0)
+	for _, ins := range old {
+		switch ins.Status {
+		case "", runtime.StatusRunning:
+			ups = append(ups, ins)
+		case runtime.StatusTesting:
+			testings = append(testings, ins)
+		}
+	}
+	if testing && len(testings) != 0 {
+		return testings
+	}
+	return ups
 }
 
 //FilterAvailableZoneAffinity is a region and zone based Select Filter which will Do the selection of instance in the same region and zone, if not Do the selection of instance in any zone in same region , if not Do the selection of instance in any zone of any region
@@ -74,3 +74,17 @@ func TestFilterAvailableZoneAffinity(t *testing.T) {
 func TestInstallFilter(t *testing.T) {
 
 }
+
+func TestFilterStatus(t *testing.T) {
+	up := &registry.MicroServiceInstance{InstanceID: "up", Status: "UP"}
+	noStatus := &registry.MicroServiceInstance{InstanceID: "none"}
+	oos := &registry.MicroServiceInstance{InstanceID: "oos", Status: "OUTOFSERVICE"}
+	tst := &registry.MicroServiceInstance{InstanceID: "testing", Status: "TESTING"}
+	instances := []*registry.MicroServiceInstance{up, noStatus, oos, tst}
+
+	assert.Equal(t, []*registry.MicroServiceInstance{up, noStatus}, loadbalancer.FilterStatus(instances, false))
+	assert.Equal(t, []*registry.MicroServiceInstance{tst}, loadbalancer.FilterStatus(instances, true))
+	// testing request falls back to UP instances
+	assert.Equal(t, []*registry.MicroServiceInstance{up}, loadbalancer.FilterStatus([]*registry.MicroServiceInstance{up, oos}, true))
+	assert.Empty(t, loadbalancer.FilterStatus([]*registry.MicroServiceInstance{oos}, false))
+}
@@ -116,7 +116,7 @@ func init() {
 				if useLatencyAware {
 					CalculateAvgLatency()
 					SortLatency()
-					lager.Logger.Info("Preparing data for Weighted Response Strategy")
+					lager.Component(lager.ComponentLoadBalancer).Info("Preparing data for Weighted Response Strategy")
 				}
 			}
 
@@ -159,3 +159,18 @@ func (r *WeightedResponseStrategy) Pick() (*registry.MicroServiceInstance, error
 	return node, nil
 
 }
+
+// DeleteLatency removes stats of the instance address from all services and protocols
+func DeleteLatency(addr string) {
+	LatencyMapRWMutex.Lock()
+	for key, stats := range ProtocolStatsMap {
+		left := make([]*ProtocolStats, 0, len(stats))
+		for _, v := range stats {
+			if v.Addr != addr {
+				left = append(left, v)
+			}
+		}
+		ProtocolStatsMap[key] = left
+	}
+	LatencyMapRWMutex.Unlock()
+}
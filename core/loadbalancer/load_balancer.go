@@ -10,6 +10,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/registry"
 	"github.com/go-chassis/go-chassis/pkg/util/tags"
+	"github.com/go-chassis/go-chassis/session"
 )
 
 // constant strings for load balance variables
@@ -39,9 +40,29 @@ func (e LBError) Error() string {
 	return "lb: " + e.Message
 }
 
+// BuildOptions is optional params of BuildStrategy
+type BuildOptions struct {
+	// Testing means the request asks for TESTING instances
+	Testing bool
+}
+
+// BuildOption is used to set BuildOptions
+type BuildOption func(*BuildOptions)
+
+// WithTesting marks whether the request should be sent to TESTING instances
+func WithTesting(testing bool) BuildOption {
+	return func(o *BuildOptions) {
+		o.Testing = testing
+	}
+}
+
 // BuildStrategy query instance list and give it to Strategy then return Strategy
 func BuildStrategy(consumerID, serviceName, protocol, sessionID string, fs []string,
-	s Strategy, tags utiltags.Tags) (Strategy, error) {
+	s Strategy, tags utiltags.Tags, options ...BuildOption) (Strategy, error) {
+	opts := BuildOptions{}
+	for _, o := range options {
+		o(&opts)
+	}
 	if s == nil {
 		s = &RoundRobinStrategy{}
 	}
@@ -57,7 +78,7 @@ func BuildStrategy(consumerID, serviceName, protocol, sessionID string, fs []str
 	instances, err := registry.DefaultServiceDiscoveryService.FindMicroServiceInstances(consumerID, serviceName, tags)
 	if err != nil {
 		lbErr := LBError{err.Error()}
-		lager.Logger.Errorf(lbErr, "Lb err")
+		lager.Component(lager.ComponentLoadBalancer).Errorf(lbErr, "Lb err")
 		return nil, lbErr
 	}
 
@@ -75,10 +96,11 @@ func BuildStrategy(consumerID, serviceName, protocol, sessionID string, fs []str
 			instances = filter(instances, nil)
 		}
 	}
+	instances = FilterStatus(instances, opts.Testing)
 
 	if len(instances) == 0 {
 		lbErr := LBError{fmt.Sprintf("No available instance, key: %s(%v)", serviceName, tags)}
-		lager.Logger.Error(lbErr.Error(), nil)
+		lager.Component(lager.ComponentLoadBalancer).Error(lbErr.Error(), nil)
 		return nil, lbErr
 	}
 
@@ -105,21 +127,28 @@ type Filter func(instances []*registry.MicroServiceInstance, criteria []*Criteri
 
 // Enable function is for to enable load balance strategy
 func Enable() error {
-	lager.Logger.Info("Enable LoadBalancing")
+	lager.Component(lager.ComponentLoadBalancer).Info("Enable LoadBalancing")
 	InstallStrategy(StrategyRandom, newRandomStrategy)
 	InstallStrategy(StrategyRoundRobin, newRoundRobinStrategy)
 	InstallStrategy(StrategySessionStickiness, newSessionStickinessStrategy)
 	InstallStrategy(StrategyLatency, newWeightedResponseStrategy)
+	subscribeInstanceEvents()
+	if err := session.Init(config.GetSessionStore()); err != nil {
+		return err
+	}
+	if err := session.RefreshCookieConfig(); err != nil {
+		return err
+	}
 
 	var strategyName string
 
 	strategyName = config.GetLoadBalancing().Strategy["name"]
 	strategyNameFromArchaius := archaius.GetString("cse.loadbalance.strategy.name", "")
 	if strategyName == "" && archaius.Get("cse.loadbalance.strategy.name") == "" {
-		lager.Logger.Info("Empty strategy configuration, use RoundRobin as default")
+		lager.Component(lager.ComponentLoadBalancer).Info("Empty strategy configuration, use RoundRobin as default")
 		return nil
 	}
-	lager.Logger.Info("Strategy is " + strategyNameFromArchaius)
+	lager.Component(lager.ComponentLoadBalancer).Info("Strategy is " + strategyNameFromArchaius)
 
 	return nil
 }
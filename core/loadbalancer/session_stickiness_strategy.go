@@ -3,6 +3,7 @@ package loadbalancer
 import (
 	"sync"
 
+	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/registry"
 	"github.com/go-chassis/go-chassis/session"
 )
@@ -71,10 +72,21 @@ func (r *SessionStickinessStrategy) ReceiveData(instances []*registry.MicroServi
 	r.sessionID = sessionID
 }
 
-// Pick return instance
+// Pick return instance bound to session of cookie, endpoint signed in cookie is used if session store lost the session.
+// cookie which is not signed by chassis is ignored, cookie of session evicted after successive failures has no endpoint
 func (r *SessionStickinessStrategy) Pick() (*registry.MicroServiceInstance, error) {
-	instanceAddr, ok := session.Get(r.sessionID)
-	if ok {
+	sid, hint, err := session.DecodeCookie(r.sessionID)
+	if err != nil {
+		return r.pick()
+	}
+	instanceAddr, ok, err := session.GetStore().Get(sid)
+	if err != nil {
+		lager.Component(lager.ComponentLoadBalancer).Errorf(err, "get session [%s] failed", sid)
+	}
+	if !ok {
+		instanceAddr = hint
+	}
+	if instanceAddr != "" {
 		if len(r.instances) == 0 {
 			return nil, ErrNoneAvailableInstance
 		}
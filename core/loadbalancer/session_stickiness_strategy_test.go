@@ -52,4 +52,22 @@ func TestSessionStickinessStrategy_Pick(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEqual(t, 1, instance.EndpointsMap["rest"])
 	}
+
+	// signed cookie sticks to its endpoint
+	cookie, err := session.EncodeCookie("signed", "1")
+	assert.NoError(t, err)
+	session.Save("signed", "2", 0)
+	s.ReceiveData(instances, "", "", cookie)
+	for i := 0; i < 10; i++ {
+		instance, err := s.Pick()
+		assert.NoError(t, err)
+		assert.Equal(t, "2", instance.EndpointsMap["rest"])
+	}
+	// endpoint in cookie is used when session is lost
+	session.Delete("signed")
+	for i := 0; i < 10; i++ {
+		instance, err := s.Pick()
+		assert.NoError(t, err)
+		assert.Equal(t, "1", instance.EndpointsMap["rest"])
+	}
 }
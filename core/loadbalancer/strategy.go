@@ -20,7 +20,7 @@ func init() {
 // InstallStrategy install strategy
 func InstallStrategy(name string, s func() Strategy) {
 	strategies[name] = s
-	lager.Logger.Debugf("Installed strategy plugin: %s.", name)
+	lager.Component(lager.ComponentLoadBalancer).Debugf("Installed strategy plugin: %s.", name)
 }
 
 // GetStrategyPlugin get strategy plugin
@@ -19,14 +19,14 @@ var InstanceEndpoints map[string]string
 func RegisterMicroservice() error {
 	service := config.MicroserviceDefinition
 	if e := service.ServiceDescription.Environment; e != "" {
-		lager.Logger.Infof("Microservice environment: [%s]", e)
+		lager.Component(lager.ComponentRegistry).Infof("Microservice environment: [%s]", e)
 	} else {
-		lager.Logger.Debug("No microservice environment defined")
+		lager.Component(lager.ComponentRegistry).Debug("No microservice environment defined")
 	}
 	microServiceDependencies = &MicroServiceDependency{}
 	schemas, err := schema.GetSchemaIDs(service.ServiceDescription.Name)
 	if err != nil {
-		lager.Logger.Warnf("No schemas file for microservice [%s].", service.ServiceDescription.Name)
+		lager.Component(lager.ComponentRegistry).Warnf("No schemas file for microservice [%s].", service.ServiceDescription.Name)
 		schemas = make([]string, 0)
 	}
 	if service.ServiceDescription.Level == "" {
@@ -48,16 +48,16 @@ func RegisterMicroservice() error {
 		},
 		RegisterBy: framework.Register,
 	}
-	lager.Logger.Infof("Framework registered is [ %s:%s ]", framework.Name, framework.Version)
-	lager.Logger.Infof("Micro service registered by [ %s ]", framework.Register)
+	lager.Component(lager.ComponentRegistry).Infof("Framework registered is [ %s:%s ]", framework.Name, framework.Version)
+	lager.Component(lager.ComponentRegistry).Infof("Micro service registered by [ %s ]", framework.Register)
 
 	sid, err := DefaultRegistrator.RegisterService(microservice)
 	runtime.ServiceID = sid
 	if err != nil {
-		lager.Logger.Errorf(err, "Register [%s] failed", microservice.ServiceName)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Register [%s] failed", microservice.ServiceName)
 		return err
 	}
-	lager.Logger.Infof("Register [%s] success", microservice.ServiceName)
+	lager.Component(lager.ComponentRegistry).Infof("Register [%s] success", microservice.ServiceName)
 
 	for _, schemaID := range schemas {
 		schemaInfo := schema.DefaultSchemaIDsMap[schemaID]
@@ -74,10 +74,10 @@ func RegisterMicroservice() error {
 		service.ServiceDescription.Properties["allowCrossApp"] = "false"
 	}
 	if err := DefaultRegistrator.UpdateMicroServiceProperties(sid, service.ServiceDescription.Properties); err != nil {
-		lager.Logger.Errorf(err, "Update micro service properties failed, serviceID = %s.", sid)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Update micro service properties failed, serviceID = %s.", sid)
 		return err
 	}
-	lager.Logger.Debugf("Update micro service properties success, serviceID = %s.", sid)
+	lager.Component(lager.ComponentRegistry).Debugf("Update micro service properties success, serviceID = %s.", sid)
 
 	return refreshDependency(microservice)
 }
@@ -86,7 +86,7 @@ func RegisterMicroservice() error {
 func refreshDependency(service *MicroService) error {
 	providersDependencyMicroService := make([]*MicroService, 0)
 	if len(config.GlobalDefinition.Cse.References) == 0 {
-		lager.Logger.Info("Don't need add dependency")
+		lager.Component(lager.ComponentRegistry).Info("Don't need add dependency")
 		return nil
 	}
 	for k, v := range config.GlobalDefinition.Cse.References {
@@ -108,20 +108,20 @@ func refreshDependency(service *MicroService) error {
 
 // RegisterMicroserviceInstances register micro-service instances
 func RegisterMicroserviceInstances() error {
-	lager.Logger.Info("Start to register instance.", nil)
+	lager.Component(lager.ComponentRegistry).Info("Start to register instance.", nil)
 	service := config.MicroserviceDefinition
 	var err error
 
 	sid, err := DefaultServiceDiscoveryService.GetMicroServiceID(config.GlobalDefinition.AppID, service.ServiceDescription.Name, service.ServiceDescription.Version, service.ServiceDescription.Environment)
 	if err != nil {
-		lager.Logger.Errorf(err, "Get service failed, key: %s:%s:%s",
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get service failed, key: %s:%s:%s",
 			config.GlobalDefinition.AppID,
 			service.ServiceDescription.Name,
 			service.ServiceDescription.Version)
 		return err
 	}
 	eps := MakeEndpointMap(config.GlobalDefinition.Cse.Protocols)
-	lager.Logger.Infof("service support protocols %s", config.GlobalDefinition.Cse.Protocols)
+	lager.Component(lager.ComponentRegistry).Infof("service support protocols %s", config.GlobalDefinition.Cse.Protocols)
 	if InstanceEndpoints != nil {
 		eps = InstanceEndpoints
 	}
@@ -143,7 +143,7 @@ func RegisterMicroserviceInstances() error {
 
 	instanceID, err := DefaultRegistrator.RegisterServiceInstance(sid, microServiceInstance)
 	if err != nil {
-		lager.Logger.Errorf(err, "Register instance failed, serviceID: %s.", sid)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Register instance failed, serviceID: %s.", sid)
 		return err
 	}
 	//Set to runtime
@@ -151,10 +151,10 @@ func RegisterMicroserviceInstances() error {
 	runtime.InstanceStatus = runtime.StatusRunning
 	if service.ServiceDescription.InstanceProperties != nil {
 		if err := DefaultRegistrator.UpdateMicroServiceInstanceProperties(sid, instanceID, service.ServiceDescription.InstanceProperties); err != nil {
-			lager.Logger.Errorf(nil, "UpdateMicroServiceInstanceProperties failed, microServiceID/instanceID = %s/%s.", sid, instanceID)
+			lager.Component(lager.ComponentRegistry).Errorf(nil, "UpdateMicroServiceInstanceProperties failed, microServiceID/instanceID = %s/%s.", sid, instanceID)
 			return err
 		}
-		lager.Logger.Debugf("UpdateMicroServiceInstanceProperties success, microServiceID/instanceID = %s/%s.", sid, instanceID)
+		lager.Component(lager.ComponentRegistry).Debugf("UpdateMicroServiceInstanceProperties success, microServiceID/instanceID = %s/%s.", sid, instanceID)
 	}
 
 	value, _ := SelfInstancesCache.Get(microServiceInstance.ServiceID)
@@ -169,6 +169,6 @@ func RegisterMicroserviceInstances() error {
 		instanceIDs = append(instanceIDs, instanceID)
 	}
 	SelfInstancesCache.Set(sid, instanceIDs, 0)
-	lager.Logger.Infof("Register instance success, serviceID/instanceID: %s/%s.", sid, instanceID)
+	lager.Component(lager.ComponentRegistry).Infof("Register instance success, serviceID/instanceID: %s/%s.", sid, instanceID)
 	return nil
 }
@@ -35,6 +35,20 @@ func enableRegistryCache() {
 	SchemaInterfaceIndexedCache = initCache()
 }
 
+// CachedInstanceCounts returns number of cached instances of each service in MicroserviceInstanceIndex
+func CachedInstanceCounts() map[string]int {
+	kl, ok := MicroserviceInstanceIndex.(keyLister)
+	if !ok {
+		return nil
+	}
+	keys := kl.keys()
+	counts := make(map[string]int, len(keys))
+	for _, service := range keys {
+		counts[service] = len(cachedInstances(service))
+	}
+	return counts
+}
+
 // CacheIndex defines interface for cache and index used by registry
 type CacheIndex interface {
 	GetIndexTags() []string
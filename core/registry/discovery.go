@@ -64,8 +64,9 @@ func enableServiceDiscovery(opts Options) {
 	DefaultServiceDiscoveryService = f(opts)
 
 	DefaultServiceDiscoveryService.AutoSync()
+	enableSnapshot()
 
-	lager.Logger.Infof("Enable %s service discovery.", t)
+	lager.Component(lager.ComponentRegistry).Infof("Enable %s service discovery.", t)
 }
 
 func enableContractDiscovery(opts Options) {
@@ -79,9 +80,9 @@ func enableContractDiscovery(opts Options) {
 	}
 	f := cdFunc[t]
 	if f == nil {
-		lager.Logger.Warn("No contract discovery plugin", nil)
+		lager.Component(lager.ComponentRegistry).Warn("No contract discovery plugin", nil)
 		return
 	}
 	DefaultContractDiscoveryService = f(opts)
-	lager.Logger.Infof("Enable %s contract discovery.", t)
+	lager.Component(lager.ComponentRegistry).Infof("Enable %s contract discovery.", t)
 }
@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// constant values for instance event actions
+const (
+	EventAdd    = "ADD"
+	EventUpdate = "UPDATE"
+	EventDelete = "DELETE"
+	// AllServices is used to subscribe instance events of all services
+	AllServices = "*"
+)
+
+// InstanceEvent is the change of a micro service instance, emitted by service discovery plugins
+type InstanceEvent struct {
+	Action      string
+	ServiceName string
+	Instance    *MicroServiceInstance
+}
+
+// InstanceEventHandler is the callback of instance events,
+// it runs in the goroutine of discovery, so it must not block
+type InstanceEventHandler func(InstanceEvent)
+
+var subscribers = make(map[string][]InstanceEventHandler)
+var subscribersMu sync.RWMutex
+
+// Subscribe register handler for instance events of service,
+// use AllServices to receive events of every service
+func Subscribe(serviceName string, h InstanceEventHandler) {
+	subscribersMu.Lock()
+	subscribers[serviceName] = append(subscribers[serviceName], h)
+	subscribersMu.Unlock()
+}
+
+// Publish notifies subscribers of service and subscribers of all services about event
+func Publish(e InstanceEvent) {
+	subscribersMu.RLock()
+	hs := make([]InstanceEventHandler, 0, len(subscribers[e.ServiceName])+len(subscribers[AllServices]))
+	hs = append(hs, subscribers[e.ServiceName]...)
+	hs = append(hs, subscribers[AllServices]...)
+	subscribersMu.RUnlock()
+	for _, h := range hs {
+		notify(h, e)
+	}
+}
+
+func notify(h InstanceEventHandler, e InstanceEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			lager.Component(lager.ComponentRegistry).Errorf(nil, "instance event handler panics: %v, event: %s %s", r, e.Action, e.ServiceName)
+		}
+	}()
+	h(e)
+}
+
+// PublishDiff compares old and new instances of service and publishes ADD, UPDATE and DELETE events
+func PublishDiff(serviceName string, olds, news []*MicroServiceInstance) {
+	mapOlds := make(map[string]*MicroServiceInstance, len(olds))
+	for _, ins := range olds {
+		mapOlds[ins.InstanceID] = ins
+	}
+	mapNews := make(map[string]struct{}, len(news))
+	for _, ins := range news {
+		mapNews[ins.InstanceID] = struct{}{}
+		old, ok := mapOlds[ins.InstanceID]
+		switch {
+		case !ok:
+			Publish(InstanceEvent{Action: EventAdd, ServiceName: serviceName, Instance: ins})
+		case !equalInstance(old, ins):
+			Publish(InstanceEvent{Action: EventUpdate, ServiceName: serviceName, Instance: ins})
+		}
+	}
+	for _, ins := range olds {
+		if _, ok := mapNews[ins.InstanceID]; !ok {
+			Publish(InstanceEvent{Action: EventDelete, ServiceName: serviceName, Instance: ins})
+		}
+	}
+}
+
+func equalInstance(a, b *MicroServiceInstance) bool {
+	return a.Status == b.Status &&
+		reflect.DeepEqual(a.EndpointsMap, b.EndpointsMap) &&
+		reflect.DeepEqual(a.Metadata, b.Metadata)
+}
+
+// cachedInstances returns instances of service in MicroserviceInstanceIndex
+func cachedInstances(service string) []*MicroServiceInstance {
+	v, ok := MicroserviceInstanceIndex.Get(service, nil)
+	if !ok {
+		return nil
+	}
+	is, _ := v.([]*MicroServiceInstance)
+	return is
+}
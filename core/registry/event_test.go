@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishDiff(t *testing.T) {
+	events := make(map[string]string)
+	Subscribe("EventServer", func(e InstanceEvent) {
+		events[e.Instance.InstanceID] = e.Action
+	})
+	var all int
+	Subscribe(AllServices, func(e InstanceEvent) {
+		if e.ServiceName == "EventServer" {
+			all++
+		}
+	})
+	Subscribe("EventServer", func(e InstanceEvent) {
+		panic("handler panics should not break publishing")
+	})
+
+	olds := []*MicroServiceInstance{
+		{InstanceID: "1", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.1:8080"}},
+		{InstanceID: "2", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.2:8080"}},
+		{InstanceID: "3", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.3:8080"}},
+	}
+	news := []*MicroServiceInstance{
+		{InstanceID: "1", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.1:8080"}},
+		{InstanceID: "2", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.2:9090"}},
+		{InstanceID: "4", Status: "UP", EndpointsMap: map[string]string{"rest": "127.0.0.4:8080"}},
+	}
+	PublishDiff("EventServer", olds, news)
+	assert.Equal(t, map[string]string{"2": EventUpdate, "3": EventDelete, "4": EventAdd}, events)
+	assert.Equal(t, 3, all)
+
+	PublishDiff("OtherServer", nil, news)
+	assert.Equal(t, 3, all)
+}
+
+func TestCachedInstanceCounts(t *testing.T) {
+	MicroserviceInstanceIndex.Set("CountServer", []*MicroServiceInstance{
+		{InstanceID: "1", Status: "UP"},
+		{InstanceID: "2", Status: "UP"},
+	})
+	defer MicroserviceInstanceIndex.Delete("CountServer")
+	assert.Equal(t, 2, CachedInstanceCounts()["CountServer"])
+}
@@ -2,6 +2,7 @@ package file
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/go-chassis/go-chassis/core/registry"
 	"github.com/go-chassis/go-chassis/core/registry/servicecenter"
@@ -81,6 +82,9 @@ type Discovery struct {
 	Name           string
 	registryClient *fileClient
 	opts           Options
+	// instances keeps last found instances of each service to publish instance events
+	instances map[string][]*registry.MicroServiceInstance
+	mu        sync.Mutex
 }
 
 // Close close the file
@@ -137,10 +141,20 @@ func (f *Discovery) FindMicroServiceInstances(consumerID, microServiceName strin
 		return nil, fmt.Errorf("FindMicroServiceInstances failed, err: %s", err)
 	}
 	instances := filterInstances(providerInstances)
+	f.publish(microServiceName, instances)
 
 	return instances, nil
 }
 
+// publish emits instance events if instances in file changed
+func (f *Discovery) publish(microServiceName string, instances []*registry.MicroServiceInstance) {
+	f.mu.Lock()
+	olds := f.instances[microServiceName]
+	f.instances[microServiceName] = instances
+	f.mu.Unlock()
+	registry.PublishDiff(microServiceName, olds, instances)
+}
+
 // filterInstances filter instances
 func filterInstances(providerInstances []*model.MicroServiceInstance) []*registry.MicroServiceInstance {
 	instances := make([]*registry.MicroServiceInstance, 0)
@@ -174,6 +188,7 @@ func newDiscovery(options registry.Options) registry.ServiceDiscovery {
 		Name:           Name,
 		registryClient: f,
 		opts:           fileOption,
+		instances:      make(map[string][]*registry.MicroServiceInstance),
 	}
 }
 
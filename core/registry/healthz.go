@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-chassis/go-chassis/core/config"
@@ -22,6 +23,7 @@ var defaultHealthChecker = &HealthChecker{}
 
 func init() {
 	defaultHealthChecker.Run()
+	Subscribe(AllServices, defaultHealthChecker.onInstanceEvent)
 }
 
 // WrapInstance is the struct defines an instance object with appID/serviceName/version
@@ -52,21 +54,31 @@ type checkResult struct {
 type HealthChecker struct {
 	pendingCh chan *WrapInstance
 	delCh     chan map[string]*WrapInstance
+	// pending records instances being checked, value is true if registry reports the instance alive again during checking
+	pending   map[string]bool
+	pendingMu sync.Mutex
 }
 
 // Run is the method initializes and starts the health check process
 func (hc *HealthChecker) Run() {
 	hc.pendingCh = make(chan *WrapInstance, chanCapacity)
 	hc.delCh = make(chan map[string]*WrapInstance, chanCapacity)
+	hc.pending = make(map[string]bool)
 	go hc.wait()
 	go hc.check()
 }
 
 // Add is the method adds a key of the instance cache into pending chan
 func (hc *HealthChecker) Add(i *WrapInstance) error {
+	hc.pendingMu.Lock()
+	hc.pending[i.Instance.InstanceID] = false
+	hc.pendingMu.Unlock()
 	select {
 	case hc.pendingCh <- i:
 	case <-time.After(timeoutToPending):
+		hc.pendingMu.Lock()
+		delete(hc.pending, i.Instance.InstanceID)
+		hc.pendingMu.Unlock()
 		return errors.New("Health checker is too busy")
 	}
 	return nil
@@ -99,13 +111,13 @@ func (hc *HealthChecker) check() {
 		}
 		for _, r := range rs {
 			cr := <-r
-			if cr.Err != nil {
-				lager.Logger.Debugf("Health check instance %s failed, %s",
+			if cr.Err != nil && !hc.isRevived(cr.Item.Instance.InstanceID) {
+				lager.Component(lager.ComponentRegistry).Debugf("Health check instance %s failed, %s",
 					cr.Item.ServiceKey(), cr.Err)
 				hc.removeFromCache(cr.Item)
 				continue
 			}
-			lager.Logger.Debugf("Health check instance %s %s is still alive, keep it in cache",
+			lager.Component(lager.ComponentRegistry).Debugf("Health check instance %s %s is still alive, keep it in cache",
 				cr.Item.ServiceKey(), cr.Item.Instance.EndpointsMap)
 		}
 	}
@@ -134,6 +146,33 @@ func (hc *HealthChecker) doCheck(i *WrapInstance) <-chan checkResult {
 	return cr
 }
 
+// onInstanceEvent cancels removal of instances being checked which registry reports alive,
+// events of other instances are ignored so that pending only holds instances being checked
+func (hc *HealthChecker) onInstanceEvent(e InstanceEvent) {
+	if e.Instance == nil {
+		return
+	}
+	hc.pendingMu.Lock()
+	defer hc.pendingMu.Unlock()
+	if _, ok := hc.pending[e.Instance.InstanceID]; !ok {
+		return
+	}
+	if e.Action == EventDelete {
+		delete(hc.pending, e.Instance.InstanceID)
+		return
+	}
+	hc.pending[e.Instance.InstanceID] = true
+}
+
+// isRevived returns true if registry reports instance alive during checking, checking of instance is done
+func (hc *HealthChecker) isRevived(instanceID string) bool {
+	hc.pendingMu.Lock()
+	revived := hc.pending[instanceID]
+	delete(hc.pending, instanceID)
+	hc.pendingMu.Unlock()
+	return revived
+}
+
 func (hc *HealthChecker) removeFromCache(i *WrapInstance) {
 	c, ok := MicroserviceInstanceIndex.Get(i.ServiceName, nil)
 	if !ok {
@@ -147,7 +186,8 @@ func (hc *HealthChecker) removeFromCache(i *WrapInstance) {
 		is = append(is, inst)
 	}
 	MicroserviceInstanceIndex.Set(i.ServiceName, is)
-	lager.Logger.Debugf("Health check: cached [%d] Instances of service [%s]", len(is), i.ServiceName)
+	Publish(InstanceEvent{Action: EventDelete, ServiceName: i.ServiceName, Instance: i.Instance})
+	lager.Component(lager.ComponentRegistry).Debugf("Health check: cached [%d] Instances of service [%s]", len(is), i.ServiceName)
 }
 
 // HealthCheck is the function adds the instance to HealthChecker
@@ -166,10 +206,22 @@ func HealthCheck(service, version, appID string, instance *MicroServiceInstance)
 
 // RefreshCache is the function to filter changes between new pulling instances and cache
 func RefreshCache(service string, ups []*MicroServiceInstance, downs map[string]struct{}) {
+	if MarkFresh(service) {
+		olds := cachedInstances(service)
+		// registry is back, stale instances from snapshot must not be kept by health check
+		if len(ups) == 0 {
+			MicroserviceInstanceIndex.Delete(service)
+		} else {
+			MicroserviceInstanceIndex.Set(service, ups)
+		}
+		PublishDiff(service, olds, ups)
+		return
+	}
 	c, ok := MicroserviceInstanceIndex.Get(service, nil)
 	if !ok || c == nil || c.([]*MicroServiceInstance) == nil {
 		// if full new instances or at less one instance, then refresh cache immediately
 		MicroserviceInstanceIndex.Set(service, ups)
+		PublishDiff(service, nil, ups)
 		return
 	}
 
@@ -219,5 +271,6 @@ func RefreshCache(service string, ups []*MicroServiceInstance, downs map[string]
 	} else {
 		MicroserviceInstanceIndex.Set(service, lefts)
 	}
-	lager.Logger.Debugf("Cached [%d] Instances of service [%s]", len(lefts), service)
+	PublishDiff(service, exps, lefts)
+	lager.Component(lager.ComponentRegistry).Debugf("Cached [%d] Instances of service [%s]", len(lefts), service)
 }
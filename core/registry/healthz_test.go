@@ -71,3 +71,25 @@ func TestRefreshCache(t *testing.T) {
 	assert.Equal(t, true, ok)
 	assert.Equal(t, 1, len(is.([]*MicroServiceInstance)))
 }
+
+func TestHealthCheckerOnInstanceEvent(t *testing.T) {
+	hc := &HealthChecker{pending: make(map[string]bool)}
+	checking := &MicroServiceInstance{InstanceID: "1"}
+	other := &MicroServiceInstance{InstanceID: "2"}
+	hc.pending[checking.InstanceID] = false
+
+	// instances not being checked are not recorded
+	hc.onInstanceEvent(InstanceEvent{Action: EventAdd, Instance: other})
+	hc.onInstanceEvent(InstanceEvent{Action: EventUpdate, Instance: other})
+	assert.Equal(t, 1, len(hc.pending))
+	assert.False(t, hc.isRevived(other.InstanceID))
+
+	hc.onInstanceEvent(InstanceEvent{Action: EventUpdate, Instance: checking})
+	assert.True(t, hc.isRevived(checking.InstanceID))
+	assert.Empty(t, hc.pending)
+
+	hc.pending[checking.InstanceID] = true
+	hc.onInstanceEvent(InstanceEvent{Action: EventDelete, Instance: checking})
+	assert.Empty(t, hc.pending)
+	assert.False(t, hc.isRevived(checking.InstanceID))
+}
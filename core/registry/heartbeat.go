@@ -46,7 +46,7 @@ func (s *HeartbeatService) Stop() {
 // AddTask add new micro-service instance to the heartbeat system
 func (s *HeartbeatService) AddTask(microServiceID, microServiceInstanceID string) {
 	key := fmt.Sprintf("%s/%s", microServiceID, microServiceInstanceID)
-	lager.Logger.Infof("Add HB task, task:%s", key)
+	lager.Component(lager.ComponentRegistry).Infof("Add HB task, task:%s", key)
 	s.mux.Lock()
 	if _, ok := s.instances[key]; !ok {
 		s.instances[key] = &HeartbeatTask{
@@ -91,7 +91,7 @@ func (s *HeartbeatService) DoHeartBeat(microServiceID, microServiceInstanceID st
 	s.toggleTask(microServiceID, microServiceInstanceID, true)
 	_, err := DefaultRegistrator.Heartbeat(microServiceID, microServiceInstanceID)
 	if err != nil {
-		lager.Logger.Errorf(err, "Run Heartbeat fail")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Run Heartbeat fail")
 		s.RemoveTask(microServiceID, microServiceInstanceID)
 		s.RetryRegister(microServiceID, microServiceInstanceID)
 	}
@@ -121,10 +121,10 @@ func (s *HeartbeatService) run() {
 func (s *HeartbeatService) RetryRegister(sid, iid string) error {
 	for {
 		time.Sleep(DefaultRetryTime)
-		lager.Logger.Infof("Try to re-register self")
+		lager.Component(lager.ComponentRegistry).Infof("Try to re-register self")
 		_, err := DefaultServiceDiscoveryService.GetAllMicroServices()
 		if err != nil {
-			lager.Logger.Errorf(err, "DefaultRegistrator is not healthy")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "DefaultRegistrator is not healthy")
 			continue
 		}
 		if _, e := DefaultServiceDiscoveryService.GetMicroService(sid); e != nil {
@@ -136,7 +136,7 @@ func (s *HeartbeatService) RetryRegister(sid, iid string) error {
 			break
 		}
 	}
-	lager.Logger.Warn("Re-register self success", nil)
+	lager.Component(lager.ComponentRegistry).Warn("Re-register self success", nil)
 	return nil
 }
 
@@ -144,13 +144,13 @@ func (s *HeartbeatService) RetryRegister(sid, iid string) error {
 func (s *HeartbeatService) ReRegisterSelfMSandMSI() error {
 	err := RegisterMicroservice()
 	if err != nil {
-		lager.Logger.Errorf(err, "The reRegisterSelfMSandMSI() startMicroservice failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "The reRegisterSelfMSandMSI() startMicroservice failed.")
 		return err
 	}
 
 	err = RegisterMicroserviceInstances()
 	if err != nil {
-		lager.Logger.Errorf(err, "The reRegisterSelfMSandMSI() startInstances failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "The reRegisterSelfMSandMSI() startInstances failed.")
 		return err
 	}
 	return nil
@@ -167,21 +167,21 @@ func reRegisterSelfMSI(sid, iid string) error {
 		InstanceID:   iid,
 		EndpointsMap: eps,
 		HostName:     runtime.HostName,
-		Status:       common.DefaultStatus,
+		Status:       InstanceStatus(),
 	}
 	instanceID, err := DefaultRegistrator.RegisterServiceInstance(sid, microServiceInstance)
 	if err != nil {
-		lager.Logger.Errorf(err, "RegisterInstance failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegisterInstance failed.")
 		return err
 	}
 
 	value, ok := SelfInstancesCache.Get(microServiceInstance.ServiceID)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, microServiceID/instanceID: %s/%s", sid, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, microServiceID/instanceID: %s/%s", sid, instanceID)
 	}
 	instanceIDs, ok := value.([]string)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance type asserts failed, microServiceID/instanceID: %s/%s", sid, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance type asserts failed, microServiceID/instanceID: %s/%s", sid, instanceID)
 	}
 	var isRepeat bool
 	for _, va := range instanceIDs {
@@ -193,7 +193,7 @@ func reRegisterSelfMSI(sid, iid string) error {
 		instanceIDs = append(instanceIDs, instanceID)
 	}
 	SelfInstancesCache.Set(microServiceInstance.ServiceID, instanceIDs, 0)
-	lager.Logger.Warnf("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", sid, instanceID)
+	lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", sid, instanceID)
 
 	return nil
 }
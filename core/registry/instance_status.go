@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
+)
+
+// ErrNotRegistered means local instance is not registered to registry
+var ErrNotRegistered = errors.New("instance is not registered")
+
+// IsValidInstanceStatus returns true if local instance can be switched to status at runtime
+func IsValidInstanceStatus(status string) bool {
+	switch status {
+	case runtime.StatusRunning, runtime.StatusOutOfService, runtime.StatusTesting:
+		return true
+	}
+	return false
+}
+
+// IsRoutableStatus returns true if instance in status may receive requests,
+// such instances are kept in instance cache, empty status is treated as UP
+func IsRoutableStatus(status string) bool {
+	return status == "" || status == runtime.StatusRunning || status == runtime.StatusTesting
+}
+
+// UpdateInstanceStatus switches local instance to UP, OUTOFSERVICE or TESTING,
+// so that instance can be taken out of rotation without being stopped
+func UpdateInstanceStatus(status string) error {
+	if !IsValidInstanceStatus(status) {
+		return fmt.Errorf("invalid instance status [%s], must be one of %s, %s, %s",
+			status, runtime.StatusRunning, runtime.StatusOutOfService, runtime.StatusTesting)
+	}
+	if DefaultRegistrator == nil || runtime.InstanceID == "" {
+		return ErrNotRegistered
+	}
+	if err := DefaultRegistrator.UpdateMicroServiceInstanceStatus(runtime.ServiceID, runtime.InstanceID, status); err != nil {
+		lager.Component(lager.ComponentRegistry).Errorf(err, "update instance status to %s failed", status)
+		return err
+	}
+	runtime.InstanceStatus = status
+	lager.Component(lager.ComponentRegistry).Infof("instance status is %s, sid/iid: %s/%s", status, runtime.ServiceID, runtime.InstanceID)
+	return nil
+}
+
+// InstanceStatus returns current status of local instance
+func InstanceStatus() string {
+	if runtime.InstanceStatus == "" {
+		return runtime.StatusRunning
+	}
+	return runtime.InstanceStatus
+}
@@ -0,0 +1,29 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidInstanceStatus(t *testing.T) {
+	assert.True(t, registry.IsValidInstanceStatus("UP"))
+	assert.True(t, registry.IsValidInstanceStatus("OUTOFSERVICE"))
+	assert.True(t, registry.IsValidInstanceStatus("TESTING"))
+	assert.False(t, registry.IsValidInstanceStatus("DOWN"))
+	assert.False(t, registry.IsValidInstanceStatus(""))
+}
+
+func TestIsRoutableStatus(t *testing.T) {
+	assert.True(t, registry.IsRoutableStatus(""))
+	assert.True(t, registry.IsRoutableStatus("UP"))
+	assert.True(t, registry.IsRoutableStatus("TESTING"))
+	assert.False(t, registry.IsRoutableStatus("OUTOFSERVICE"))
+	assert.False(t, registry.IsRoutableStatus("DOWN"))
+}
+
+func TestUpdateInstanceStatus(t *testing.T) {
+	err := registry.UpdateInstanceStatus("DOWN")
+	assert.Error(t, err)
+}
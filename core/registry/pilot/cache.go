@@ -32,7 +32,7 @@ func (c *CacheManager) AutoSync() {
 	} else {
 		timeValue, err := time.ParseDuration(refreshInterval)
 		if err != nil {
-			lager.Logger.Errorf(err, "refeshInterval is invalid. So use Default value")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "refeshInterval is invalid. So use Default value")
 			timeValue = DefaultRefreshInterval
 		}
 		ticker = time.NewTicker(timeValue)
@@ -48,31 +48,31 @@ func (c *CacheManager) AutoSync() {
 func (c *CacheManager) refreshCache() {
 	if archaius.GetBool("cse.service.registry.autodiscovery", false) {
 		// TODO CDS
-		lager.Logger.Errorf(errors.New("not supported"), "SyncPilotEndpoints failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(errors.New("not supported"), "SyncPilotEndpoints failed.")
 	}
 	err := c.pullMicroserviceInstance()
 	if err != nil {
-		lager.Logger.Errorf(err, "AutoUpdateMicroserviceInstance failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "AutoUpdateMicroserviceInstance failed.")
 	}
 
 	if archaius.GetBool("cse.service.registry.autoSchemaIndex", false) {
-		lager.Logger.Errorf(errors.New("Not support operation"), "MakeSchemaIndex failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(errors.New("Not support operation"), "MakeSchemaIndex failed.")
 	}
 
 	if archaius.GetBool("cse.service.registry.autoIPIndex", false) {
 		err = c.MakeIPIndex()
 		if err != nil {
-			lager.Logger.Errorf(err, "Auto Update IP index failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Auto Update IP index failed.")
 		}
 	}
 }
 
 // MakeIPIndex make ip index
 func (c *CacheManager) MakeIPIndex() error {
-	lager.Logger.Debug("Make IP index")
+	lager.Component(lager.ComponentRegistry).Debug("Make IP index")
 	services, err := c.registryClient.GetAllServices()
 	if err != nil {
-		lager.Logger.Errorf(err, "Get instances failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get instances failed")
 		return err
 	}
 	for _, service := range services {
@@ -105,8 +105,14 @@ func (c *CacheManager) pullMicroserviceInstance() error {
 
 // filterRestore filter and restore instances to cache
 func filterRestore(hs []*Host, serviceKey string, tags map[string]string) {
+	registry.MarkFresh(serviceKey)
+	var olds []*registry.MicroServiceInstance
+	if v, ok := registry.MicroserviceInstanceIndex.Get(serviceKey, nil); ok {
+		olds, _ = v.([]*registry.MicroServiceInstance)
+	}
 	if len(hs) == 0 {
 		registry.MicroserviceInstanceIndex.Delete(serviceKey)
+		registry.PublishDiff(serviceKey, olds, nil)
 		return
 	}
 
@@ -116,4 +122,5 @@ func filterRestore(hs []*Host, serviceKey string, tags map[string]string) {
 		store = append(store, msi)
 	}
 	registry.MicroserviceInstanceIndex.Set(serviceKey, store)
+	registry.PublishDiff(serviceKey, olds, store)
 }
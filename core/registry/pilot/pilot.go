@@ -32,10 +32,10 @@ type ServiceDiscovery struct {
 func (r *ServiceDiscovery) GetMicroServiceID(appID, microServiceName, version, env string) (string, error) {
 	_, err := r.registryClient.GetServiceHosts(microServiceName)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceID failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceID failed")
 		return "", err
 	}
-	lager.Logger.Debugf("GetMicroServiceID success")
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServiceID success")
 	return microServiceName, nil
 }
 
@@ -43,7 +43,7 @@ func (r *ServiceDiscovery) GetMicroServiceID(appID, microServiceName, version, e
 func (r *ServiceDiscovery) GetAllMicroServices() ([]*registry.MicroService, error) {
 	svcs, err := r.registryClient.GetAllServices()
 	if err != nil {
-		lager.Logger.Errorf(err, "GetAllMicroServices failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetAllMicroServices failed")
 		return nil, err
 	}
 
@@ -58,10 +58,10 @@ func (r *ServiceDiscovery) GetAllMicroServices() ([]*registry.MicroService, erro
 func (r *ServiceDiscovery) GetMicroService(microServiceID string) (*registry.MicroService, error) {
 	hs, err := r.registryClient.GetServiceHosts(microServiceID)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceID failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceID failed")
 		return nil, err
 	}
-	lager.Logger.Debugf("GetMicroServices success, MicroService: %s", microServiceID)
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServices success, MicroService: %s", microServiceID)
 	return ToMicroService(&Service{
 		ServiceKey: microServiceID,
 		Hosts:      hs.Hosts,
@@ -72,11 +72,11 @@ func (r *ServiceDiscovery) GetMicroService(microServiceID string) (*registry.Mic
 func (r *ServiceDiscovery) GetMicroServiceInstances(consumerID, providerID string) ([]*registry.MicroServiceInstance, error) {
 	hs, err := r.registryClient.GetServiceHosts(providerID)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceInstances failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceInstances failed.")
 		return nil, err
 	}
 	instances := filterInstances(hs.Hosts)
-	lager.Logger.Debugf("GetMicroServiceInstances success, consumerID/providerID: %s/%s", consumerID, providerID)
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServiceInstances success, consumerID/providerID: %s/%s", consumerID, providerID)
 	return instances, nil
 }
 
@@ -85,7 +85,7 @@ func (r *ServiceDiscovery) FindMicroServiceInstances(consumerID, microServiceNam
 	serviceKey := pilotServiceKey(microServiceName)
 	value, boo := registry.MicroserviceInstanceIndex.Get(serviceKey, tags.KV)
 	if !boo || value == nil {
-		lager.Logger.Warnf("%s Get instances from remote, key: %s, %v", consumerID, serviceKey, tags.String())
+		lager.Component(lager.ComponentRegistry).Warnf("%s Get instances from remote, key: %s, %v", consumerID, serviceKey, tags.String())
 		hs, err := r.registryClient.GetHostsByKey(serviceKey, tags.KV)
 		if err != nil {
 			return nil, fmt.Errorf("FindMicroServiceInstances failed, ProviderID: %s, err: %s",
@@ -95,13 +95,13 @@ func (r *ServiceDiscovery) FindMicroServiceInstances(consumerID, microServiceNam
 		filterRestore(hs.Hosts, serviceKey, tags.KV)
 		value, boo = registry.MicroserviceInstanceIndex.Get(serviceKey, tags.KV)
 		if !boo || value == nil {
-			lager.Logger.Debugf("Find no microservice instances for %s from cache", serviceKey)
+			lager.Component(lager.ComponentRegistry).Debugf("Find no microservice instances for %s from cache", serviceKey)
 			return nil, nil
 		}
 	}
 	microServiceInstance, ok := value.([]*registry.MicroServiceInstance)
 	if !ok {
-		lager.Logger.Errorf(nil, "FindMicroServiceInstances failed, Type asserts failed. consumerIDL: %s",
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "FindMicroServiceInstances failed, Type asserts failed. consumerIDL: %s",
 			consumerID)
 	}
 	return microServiceInstance, nil
@@ -20,10 +20,10 @@ const (
 func close(r *EnvoyDSClient) error {
 	err := r.Close()
 	if err != nil {
-		lager.Logger.Errorf(err, "Conn close failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Conn close failed.")
 		return err
 	}
-	lager.Logger.Debugf("Conn close success.")
+	lager.Component(lager.ComponentRegistry).Debugf("Conn close success.")
 	return nil
 }
 
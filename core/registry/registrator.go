@@ -75,12 +75,12 @@ func enableRegistrator(opts Options) {
 	DefaultRegistrator = f(opts)
 
 	if err := RegisterMicroservice(); err != nil {
-		lager.Logger.Errorf(err, "start bacskoff for register microservice")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "start bacskoff for register microservice")
 		startBackOff(RegisterMicroservice)
 	}
 	go HBService.Start()
 
-	lager.Logger.Infof("Enable [%s] registrator.", rt)
+	lager.Component(lager.ComponentRegistry).Infof("Enable [%s] registrator.", rt)
 }
 
 // InstallRegistrator install registrator plugin
@@ -154,7 +154,7 @@ func Enable() (err error) {
 	enableServiceDiscovery(oSD)
 	enableContractDiscovery(oCD)
 
-	lager.Logger.Info("Enabled Registry")
+	lager.Component(lager.ComponentRegistry).Info("Enabled Registry")
 	IsEnabled = true
 	return nil
 }
@@ -175,13 +175,13 @@ func DoRegister() error {
 	default:
 		{
 			tmpErr := fmt.Errorf("parameter incorrect, autoregister: %s", t)
-			lager.Logger.Error(tmpErr.Error(), nil)
+			lager.Component(lager.ComponentRegistry).Error(tmpErr.Error(), nil)
 			return tmpErr
 		}
 	}
 	if isAutoRegister {
 		if err := RegisterMicroserviceInstances(); err != nil {
-			lager.Logger.Errorf(err, "start back off for register microservice instances background")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "start back off for register microservice instances background")
 			go startBackOff(RegisterMicroserviceInstances)
 		}
 	}
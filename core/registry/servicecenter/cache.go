@@ -40,9 +40,9 @@ func (c *CacheManager) AutoSync() {
 	if config.GetServiceDiscoveryWatch() {
 		err := c.registryClient.WatchMicroService(runtime.ServiceID, watch)
 		if err != nil {
-			lager.Logger.Errorf(err, "Watch failed. Self Micro service Id:%s.", runtime.ServiceID)
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Watch failed. Self Micro service Id:%s.", runtime.ServiceID)
 		}
-		lager.Logger.Debugf("Watching Intances change events.")
+		lager.Component(lager.ComponentRegistry).Debugf("Watching Intances change events.")
 	}
 	var ticker *time.Ticker
 	refreshInterval := config.GetServiceDiscoveryRefreshInterval()
@@ -51,7 +51,7 @@ func (c *CacheManager) AutoSync() {
 	} else {
 		timeValue, err := time.ParseDuration(refreshInterval)
 		if err != nil {
-			lager.Logger.Errorf(err, "refeshInterval is invalid. So use Default value")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "refeshInterval is invalid. So use Default value")
 			timeValue = DefaultRefreshInterval
 		}
 		ticker = time.NewTicker(timeValue)
@@ -68,25 +68,25 @@ func (c *CacheManager) refreshCache() {
 	if archaius.GetBool("cse.service.registry.autodiscovery", false) {
 		err := c.registryClient.SyncEndpoints()
 		if err != nil {
-			lager.Logger.Errorf(err, "SyncSCEndpoints failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "SyncSCEndpoints failed.")
 		}
 	}
 	err := c.pullMicroserviceInstance()
 	if err != nil {
-		lager.Logger.Errorf(err, "AutoUpdateMicroserviceInstance failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "AutoUpdateMicroserviceInstance failed.")
 	}
 
 	if archaius.GetBool("cse.service.registry.autoSchemaIndex", false) {
 		err = c.MakeSchemaIndex()
 		if err != nil {
-			lager.Logger.Errorf(err, "MakeSchemaIndex failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "MakeSchemaIndex failed.")
 		}
 	}
 
 	if archaius.GetBool("cse.service.registry.autoIPIndex", false) {
 		err = c.MakeIPIndex()
 		if err != nil {
-			lager.Logger.Errorf(err, "Auto Update IP index failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Auto Update IP index failed.")
 		}
 	}
 
@@ -94,10 +94,10 @@ func (c *CacheManager) refreshCache() {
 
 // MakeIPIndex make ip index
 func (c *CacheManager) MakeIPIndex() error {
-	lager.Logger.Debug("Make IP index")
+	lager.Component(lager.ComponentRegistry).Debug("Make IP index")
 	services, err := c.registryClient.GetAllResources("instances")
 	if err != nil {
-		lager.Logger.Errorf(err, "Get instances failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get instances failed")
 		return err
 	}
 	for _, service := range services {
@@ -105,7 +105,7 @@ func (c *CacheManager) MakeIPIndex() error {
 			for _, uri := range inst.Endpoints {
 				u, err := url.Parse(uri)
 				if err != nil {
-					lager.Logger.Error("Wrong URI", err)
+					lager.Component(lager.ComponentRegistry).Error("Wrong URI", err)
 					continue
 				}
 				u.Host = strings.Split(u.Host, ":")[0]
@@ -121,10 +121,10 @@ func (c *CacheManager) MakeIPIndex() error {
 // MakeSchemaIndex make schema index
 func (c *CacheManager) MakeSchemaIndex() error {
 
-	lager.Logger.Debug("Make Schema index")
+	lager.Component(lager.ComponentRegistry).Debug("Make Schema index")
 	microServiceList, err := c.registryClient.GetAllMicroServices()
 	if err != nil {
-		lager.Logger.Errorf(err, "Get instances failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get instances failed")
 		return err
 	}
 
@@ -153,13 +153,13 @@ func (c *CacheManager) MakeSchemaIndex() error {
 					var allMicroServices []*model.MicroService
 					allMicroServices = append(allMicroServices, ms)
 					registry.SchemaInterfaceIndexedCache.Set(interfaceName, allMicroServices, 0)
-					lager.Logger.Debugf("New Interface added in the Index Cache : %s", interfaceName)
+					lager.Component(lager.ComponentRegistry).Debugf("New Interface added in the Index Cache : %s", interfaceName)
 				} else {
 					val, _ := value.([]*model.MicroService)
 					if !checkIfMicroServiceExistInList(val, ms.ServiceID) {
 						val = append(val, ms)
 						registry.SchemaInterfaceIndexedCache.Set(interfaceName, val, 0)
-						lager.Logger.Debugf("New Interface added in the Index Cache : %s", interfaceName)
+						lager.Component(lager.ComponentRegistry).Debugf("New Interface added in the Index Cache : %s", interfaceName)
 					}
 				}
 
@@ -168,13 +168,13 @@ func (c *CacheManager) MakeSchemaIndex() error {
 					var allMicroServices []*model.MicroService
 					allMicroServices = append(allMicroServices, ms)
 					registry.SchemaServiceIndexedCache.Set(serviceID, allMicroServices, 0)
-					lager.Logger.Debugf("New Service added in the Index Cache : %s", serviceID)
+					lager.Component(lager.ComponentRegistry).Debugf("New Service added in the Index Cache : %s", serviceID)
 				} else {
 					val, _ := svcValue.([]*model.MicroService)
 					if !checkIfMicroServiceExistInList(val, ms.ServiceID) {
 						val = append(val, ms)
 						registry.SchemaServiceIndexedCache.Set(serviceID, val, 0)
-						lager.Logger.Debugf("New Service added in the Index Cache : %s", serviceID)
+						lager.Component(lager.ComponentRegistry).Debugf("New Service added in the Index Cache : %s", serviceID)
 					}
 				}
 			}
@@ -200,7 +200,7 @@ func (c *CacheManager) pullMicroserviceInstance() error {
 	//Get Providers
 	rsp, err := c.registryClient.GetProviders(runtime.ServiceID)
 	if err != nil {
-		lager.Logger.Errorf(err, "get Providers failed, sid = %s", runtime.ServiceID)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "get Providers failed, sid = %s", runtime.ServiceID)
 		return err
 	}
 
@@ -210,7 +210,7 @@ func (c *CacheManager) pullMicroserviceInstance() error {
 	for key := range serviceNameAppIDKeySet {
 		service := strings.Split(key, ":")
 		if len(service) != 2 {
-			lager.Logger.Errorf(err, "Invalid serviceStore %s for providers %s", key, runtime.ServiceID)
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Invalid serviceStore %s for providers %s", key, runtime.ServiceID)
 			continue
 		}
 
@@ -218,10 +218,10 @@ func (c *CacheManager) pullMicroserviceInstance() error {
 			service[0], findVersionRule(service[0]))
 		if err != nil {
 			if err == client.ErrNotModified {
-				lager.Logger.Debug(err.Error())
+				lager.Component(lager.ComponentRegistry).Debug(err.Error())
 				continue
 			}
-			lager.Logger.Error("Refresh local instance cache failed", err)
+			lager.Component(lager.ComponentRegistry).Error("Refresh local instance cache failed", err)
 			continue
 		}
 
@@ -234,7 +234,14 @@ func (c *CacheManager) compareAndDeleteOutdatedProviders(newProviders sets.Strin
 	oldProviders := registry.MicroserviceInstanceIndex.Items()
 	for old := range oldProviders {
 		if !newProviders.Has(old) { //provider is outdated, delete it
+			var olds []*registry.MicroServiceInstance
+			if v, ok := registry.MicroserviceInstanceIndex.Get(old, nil); ok {
+				olds, _ = v.([]*registry.MicroServiceInstance)
+			}
 			registry.MicroserviceInstanceIndex.Delete(old)
+			registry.MarkRemoved(old)
+			// subscribers must release state of instances which are gone
+			registry.PublishDiff(old, olds, nil)
 		}
 	}
 }
@@ -265,11 +272,11 @@ func filterReIndex(providerInstances []*model.MicroServiceInstance, serviceName
 	for _, ins := range providerInstances {
 		switch {
 		case ins.Version == "":
-			lager.Logger.Warn("do not support old service center, plz upgrade")
+			lager.Component(lager.ComponentRegistry).Warn("do not support old service center, plz upgrade")
 			continue
-		case ins.Status != common.DefaultStatus:
+		case !registry.IsRoutableStatus(ins.Status):
 			downs[ins.InstanceID] = struct{}{}
-			lager.Logger.Debugf("do not cache the instance in '%s' status, instanceId = %s/%s",
+			lager.Component(lager.ComponentRegistry).Debugf("do not cache the instance in '%s' status, instanceId = %s/%s",
 				ins.Status, ins.ServiceID, ins.InstanceID)
 			continue
 		default:
@@ -289,7 +296,7 @@ func findVersionRule(microservice string) string {
 
 // watch watching micro-service instance status
 func watch(response *model.MicroServiceInstanceChangedEvent) {
-	if response.Instance.Status != model.MSInstanceUP {
+	if !registry.IsRoutableStatus(response.Instance.Status) {
 		response.Action = common.Delete
 	}
 	switch response.Action {
@@ -303,10 +310,10 @@ func watch(response *model.MicroServiceInstanceChangedEvent) {
 		updateAction(response)
 		break
 	case model.EventError:
-		lager.Logger.Warnf("MicroServiceInstanceChangedEvent action is error, MicroServiceInstanceChangedEvent = %s", response)
+		lager.Component(lager.ComponentRegistry).Warnf("MicroServiceInstanceChangedEvent action is error, MicroServiceInstanceChangedEvent = %s", response)
 		break
 	default:
-		lager.Logger.Warnf("Do not support this Action = %s", response.Action)
+		lager.Component(lager.ComponentRegistry).Warnf("Do not support this Action = %s", response.Action)
 		return
 	}
 }
@@ -316,50 +323,57 @@ func createAction(response *model.MicroServiceInstanceChangedEvent) {
 	key := response.Key.ServiceName
 	value, ok := registry.MicroserviceInstanceIndex.Get(key, nil)
 	if !ok {
-		lager.Logger.Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache,action is EVT_CREATE.key = %s", key)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache,action is EVT_CREATE.key = %s", key)
 		return
 	}
 	microServiceInstances, ok := value.([]*registry.MicroServiceInstance)
 	if !ok {
-		lager.Logger.Errorf(nil, "Type asserts failed.action is EVT_CREATE,sid = %s", response.Instance.ServiceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "Type asserts failed.action is EVT_CREATE,sid = %s", response.Instance.ServiceID)
 		return
 	}
-	if response.Instance.Status != model.MSInstanceUP {
-		lager.Logger.Warnf("createAction failed,MicroServiceInstance status is not MSI_UP,MicroServiceInstanceChangedEvent = %s", response)
+	if !registry.IsRoutableStatus(response.Instance.Status) {
+		lager.Component(lager.ComponentRegistry).Warnf("createAction failed,MicroServiceInstance status is not routable,MicroServiceInstanceChangedEvent = %s", response)
 		return
 	}
 	msi := ToMicroServiceInstance(response.Instance).WithAppID(response.Key.AppID)
 	microServiceInstances = append(microServiceInstances, msi)
 	registry.MicroserviceInstanceIndex.Set(key, microServiceInstances)
-	lager.Logger.Debugf("Cached Instances,action is EVT_CREATE, sid = %s, instances length = %d", response.Instance.ServiceID, len(microServiceInstances))
+	registry.Publish(registry.InstanceEvent{Action: registry.EventAdd, ServiceName: key, Instance: msi})
+	lager.Component(lager.ComponentRegistry).Debugf("Cached Instances,action is EVT_CREATE, sid = %s, instances length = %d", response.Instance.ServiceID, len(microServiceInstances))
 }
 
 // deleteAction delete micro-service instance
 func deleteAction(response *model.MicroServiceInstanceChangedEvent) {
 	key := response.Key.ServiceName
-	lager.Logger.Debugf("Received event EVT_DELETE, sid = %s, endpoints = %s", response.Instance.ServiceID, response.Instance.Endpoints)
+	lager.Component(lager.ComponentRegistry).Debugf("Received event EVT_DELETE, sid = %s, endpoints = %s", response.Instance.ServiceID, response.Instance.Endpoints)
 	if err := registry.HealthCheck(key, response.Key.Version, response.Key.AppID, ToMicroServiceInstance(response.Instance)); err == nil {
 		return
 	}
 	value, ok := registry.MicroserviceInstanceIndex.Get(key, nil)
 	if !ok {
-		lager.Logger.Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache, action is EVT_DELETE, key = %s", key)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache, action is EVT_DELETE, key = %s", key)
 		return
 	}
 	microServiceInstances, ok := value.([]*registry.MicroServiceInstance)
 	if !ok {
-		lager.Logger.Errorf(nil, "Type asserts failed.action is EVT_DELETE, sid = %s", response.Instance.ServiceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "Type asserts failed.action is EVT_DELETE, sid = %s", response.Instance.ServiceID)
 		return
 	}
 	var newInstances = make([]*registry.MicroServiceInstance, 0)
+	var deleted *registry.MicroServiceInstance
 	for _, v := range microServiceInstances {
 		if v.InstanceID != response.Instance.InstanceID {
 			newInstances = append(newInstances, v)
+			continue
 		}
+		deleted = v
 	}
 
 	registry.MicroserviceInstanceIndex.Set(key, newInstances)
-	lager.Logger.Debugf("Cached [%d] Instances of service [%s]", len(newInstances), key)
+	if deleted != nil {
+		registry.Publish(registry.InstanceEvent{Action: registry.EventDelete, ServiceName: key, Instance: deleted})
+	}
+	lager.Component(lager.ComponentRegistry).Debugf("Cached [%d] Instances of service [%s]", len(newInstances), key)
 }
 
 // updateAction update micro-service instance event
@@ -367,16 +381,16 @@ func updateAction(response *model.MicroServiceInstanceChangedEvent) {
 	key := response.Key.ServiceName
 	value, ok := registry.MicroserviceInstanceIndex.Get(key, nil)
 	if !ok {
-		lager.Logger.Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache, action is EVT_UPDATE, sid = %s", key)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "ServiceID does not exist in MicroserviceInstanceCache, action is EVT_UPDATE, sid = %s", key)
 		return
 	}
 	microServiceInstances, ok := value.([]*registry.MicroServiceInstance)
 	if !ok {
-		lager.Logger.Errorf(nil, "Type asserts failed.action is EVT_UPDATE, sid = %s", response.Instance.ServiceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "Type asserts failed.action is EVT_UPDATE, sid = %s", response.Instance.ServiceID)
 		return
 	}
-	if response.Instance.Status != model.MSInstanceUP {
-		lager.Logger.Warnf("updateAction failed, MicroServiceInstance status is not MSI_UP, MicroServiceInstanceChangedEvent = %s", response)
+	if !registry.IsRoutableStatus(response.Instance.Status) {
+		lager.Component(lager.ComponentRegistry).Warnf("updateAction failed, MicroServiceInstance status is not routable, MicroServiceInstanceChangedEvent = %s", response)
 		return
 	}
 	msi := ToMicroServiceInstance(response.Instance).WithAppID(response.Key.AppID)
@@ -388,16 +402,20 @@ func updateAction(response *model.MicroServiceInstanceChangedEvent) {
 			arrayNum = k
 		}
 	}
+	var action string
 	switch iidExist {
 	case InstanceIDIsExist:
 		microServiceInstances[arrayNum] = msi
+		action = registry.EventUpdate
 		break
 	case InstanceIDIsNotExist:
 		microServiceInstances = append(microServiceInstances, msi)
+		action = registry.EventAdd
 		break
 	default:
-		lager.Logger.Warnf("updateAction error, iid:%s", response.Instance.InstanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("updateAction error, iid:%s", response.Instance.InstanceID)
 	}
 	registry.MicroserviceInstanceIndex.Set(key, microServiceInstances)
-	lager.Logger.Debugf("Cached Instances,action is EVT_UPDATE, sid = %s, instances length = %d", response.Instance.ServiceID, len(microServiceInstances))
+	registry.Publish(registry.InstanceEvent{Action: action, ServiceName: key, Instance: msi})
+	lager.Component(lager.ComponentRegistry).Debugf("Cached Instances,action is EVT_UPDATE, sid = %s, instances length = %d", response.Instance.ServiceID, len(microServiceInstances))
 }
@@ -0,0 +1,54 @@
+package servicecenter
+
+import (
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/registry"
+	cache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fakeIndex is an instance index holding services in a map
+type fakeIndex struct {
+	registry.CacheIndex
+	services map[string][]*registry.MicroServiceInstance
+}
+
+func (f *fakeIndex) Items() map[string]*cache.Cache {
+	items := make(map[string]*cache.Cache, len(f.services))
+	for k := range f.services {
+		items[k] = cache.New(0, 0)
+	}
+	return items
+}
+
+func (f *fakeIndex) Get(k string, tags map[string]string) (interface{}, bool) {
+	v, ok := f.services[k]
+	return v, ok
+}
+
+func (f *fakeIndex) Delete(k string) { delete(f.services, k) }
+
+func TestCompareAndDeleteOutdatedProviders(t *testing.T) {
+	old := registry.MicroserviceInstanceIndex
+	defer func() { registry.MicroserviceInstanceIndex = old }()
+	registry.MicroserviceInstanceIndex = &fakeIndex{services: map[string][]*registry.MicroServiceInstance{
+		"OutdatedKept":    {{InstanceID: "1"}},
+		"OutdatedRemoved": {{InstanceID: "2"}, {InstanceID: "3"}},
+	}}
+	deleted := make(map[string]string)
+	registry.Subscribe(registry.AllServices, func(e registry.InstanceEvent) {
+		if e.Action == registry.EventDelete {
+			deleted[e.Instance.InstanceID] = e.ServiceName
+		}
+	})
+
+	c := &CacheManager{}
+	c.compareAndDeleteOutdatedProviders(sets.NewString("OutdatedKept"))
+	_, ok := registry.MicroserviceInstanceIndex.Get("OutdatedRemoved", nil)
+	assert.False(t, ok)
+	_, ok = registry.MicroserviceInstanceIndex.Get("OutdatedKept", nil)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"2": "OutdatedRemoved", "3": "OutdatedRemoved"}, deleted)
+}
@@ -29,18 +29,18 @@ func (r *Registrator) RegisterService(ms *registry.MicroService) (string, error)
 	microservice := ToSCService(ms)
 	sid, err := r.registryClient.GetMicroServiceID(microservice.AppID, microservice.ServiceName, microservice.Version, microservice.Environment)
 	if err != nil {
-		lager.Logger.Errorf(err, "Get service [%s] failed", serviceKey)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get service [%s] failed", serviceKey)
 		return "", err
 	}
 	if sid == "" {
-		lager.Logger.Warnf("service [%s] not exists in registry, register it", serviceKey, err)
+		lager.Component(lager.ComponentRegistry).Warnf("service [%s] not exists in registry, register it", serviceKey, err)
 		sid, err = r.registryClient.RegisterService(microservice)
 		if err != nil {
-			lager.Logger.Errorf(err, "Register service [%s] failed", serviceKey)
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Register service [%s] failed", serviceKey)
 			return "", err
 		}
 	} else {
-		lager.Logger.Infof("[%s] exists in registry", serviceKey)
+		lager.Component(lager.ComponentRegistry).Infof("[%s] exists in registry", serviceKey)
 	}
 
 	return sid, nil
@@ -52,16 +52,16 @@ func (r *Registrator) RegisterServiceInstance(sid string, cIns *registry.MicroSe
 	instance.ServiceID = sid
 	instanceID, err := r.registryClient.RegisterMicroServiceInstance(instance)
 	if err != nil {
-		lager.Logger.Errorf(err, "RegisterMicroServiceInstance failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegisterMicroServiceInstance failed.")
 		return "", err
 	}
 	value, ok := registry.SelfInstancesCache.Get(instance.ServiceID)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", instance.ServiceID, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", instance.ServiceID, instanceID)
 	}
 	instanceIDs, ok := value.([]string)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance type asserts failed,  Mid/Sid: %s/%s", instance.ServiceID, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance type asserts failed,  Mid/Sid: %s/%s", instance.ServiceID, instanceID)
 	}
 	var isRepeat bool
 	for _, va := range instanceIDs {
@@ -73,13 +73,13 @@ func (r *Registrator) RegisterServiceInstance(sid string, cIns *registry.MicroSe
 		instanceIDs = append(instanceIDs, instanceID)
 	}
 	registry.SelfInstancesCache.Set(instance.ServiceID, instanceIDs, 0)
-	lager.Logger.Infof("RegisterMicroServiceInstance success, MicroServiceID: %s", instance.ServiceID)
+	lager.Component(lager.ComponentRegistry).Infof("RegisterMicroServiceInstance success, MicroServiceID: %s", instance.ServiceID)
 
 	if instance.HealthCheck == nil ||
 		instance.HealthCheck.Mode == model.CheckByHeartbeat {
 		registry.HBService.AddTask(sid, instanceID)
 	}
-	lager.Logger.Infof("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", sid, instanceID)
+	lager.Component(lager.ComponentRegistry).Infof("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", sid, instanceID)
 	return instanceID, nil
 }
 
@@ -91,25 +91,25 @@ func (r *Registrator) RegisterServiceAndInstance(cMicroService *registry.MicroSe
 	if microServiceID == "" {
 		microServiceID, err = r.registryClient.RegisterService(microService)
 		if err != nil {
-			lager.Logger.Errorf(err, "RegisterMicroService failed")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "RegisterMicroService failed")
 			return "", "", err
 		}
-		lager.Logger.Debugf("RegisterMicroService success, microServiceID: %s", microServiceID)
+		lager.Component(lager.ComponentRegistry).Debugf("RegisterMicroService success, microServiceID: %s", microServiceID)
 	}
 	instance.ServiceID = microServiceID
 	instanceID, err := r.registryClient.RegisterMicroServiceInstance(instance)
 	if err != nil {
-		lager.Logger.Errorf(err, "RegisterMicroServiceInstance failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegisterMicroServiceInstance failed.")
 		return microServiceID, "", err
 	}
 
 	value, ok := registry.SelfInstancesCache.Get(instance.ServiceID)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", instance.ServiceID, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", instance.ServiceID, instanceID)
 	}
 	instanceIDs, ok := value.([]string)
 	if !ok {
-		lager.Logger.Warnf("RegisterMicroServiceInstance type asserts failed,  Mid/Sid: %s/%s", instance.ServiceID, instanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("RegisterMicroServiceInstance type asserts failed,  Mid/Sid: %s/%s", instance.ServiceID, instanceID)
 	}
 	var isRepeat bool
 	for _, va := range instanceIDs {
@@ -121,13 +121,13 @@ func (r *Registrator) RegisterServiceAndInstance(cMicroService *registry.MicroSe
 		instanceIDs = append(instanceIDs, instanceID)
 	}
 	registry.SelfInstancesCache.Set(instance.ServiceID, instanceIDs, 0)
-	lager.Logger.Infof("RegisterMicroServiceInstance success, MicroServiceID: %s", instance.ServiceID)
+	lager.Component(lager.ComponentRegistry).Infof("RegisterMicroServiceInstance success, MicroServiceID: %s", instance.ServiceID)
 
 	if instance.HealthCheck == nil ||
 		instance.HealthCheck.Mode == model.CheckByHeartbeat {
 		registry.HBService.AddTask(microServiceID, instanceID)
 	}
-	lager.Logger.Infof("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", microServiceID, instanceID)
+	lager.Component(lager.ComponentRegistry).Infof("RegisterMicroServiceInstance success, microServiceID/instanceID: %s/%s.", microServiceID, instanceID)
 	return microServiceID, instanceID, nil
 }
 
@@ -135,17 +135,17 @@ func (r *Registrator) RegisterServiceAndInstance(cMicroService *registry.MicroSe
 func (r *Registrator) UnRegisterMicroServiceInstance(microServiceID, microServiceInstanceID string) error {
 	isSuccess, err := r.registryClient.UnregisterMicroServiceInstance(microServiceID, microServiceInstanceID)
 	if !isSuccess || err != nil {
-		lager.Logger.Errorf(nil, "unregisterMicroServiceInstance failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "unregisterMicroServiceInstance failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 		return err
 	}
 
 	value, ok := registry.SelfInstancesCache.Get(microServiceID)
 	if !ok {
-		lager.Logger.Warnf("UnregisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("UnregisterMicroServiceInstance get SelfInstancesCache failed, Mid/Sid: %s/%s", microServiceID, microServiceInstanceID)
 	}
 	instanceIDs, ok := value.([]string)
 	if !ok {
-		lager.Logger.Warnf("UnregisterMicroServiceInstance type asserts failed, Mid/Sid: %s/%s", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Warnf("UnregisterMicroServiceInstance type asserts failed, Mid/Sid: %s/%s", microServiceID, microServiceInstanceID)
 	}
 	var newInstanceIDs = make([]string, 0)
 	for _, v := range instanceIDs {
@@ -155,7 +155,7 @@ func (r *Registrator) UnRegisterMicroServiceInstance(microServiceID, microServic
 	}
 	registry.SelfInstancesCache.Set(microServiceID, newInstanceIDs, 0)
 
-	lager.Logger.Debugf("unregisterMicroServiceInstance success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+	lager.Component(lager.ComponentRegistry).Debugf("unregisterMicroServiceInstance success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 	return nil
 }
 
@@ -163,14 +163,14 @@ func (r *Registrator) UnRegisterMicroServiceInstance(microServiceID, microServic
 func (r *Registrator) Heartbeat(microServiceID, microServiceInstanceID string) (bool, error) {
 	bo, err := r.registryClient.Heartbeat(microServiceID, microServiceInstanceID)
 	if err != nil {
-		lager.Logger.Errorf(err, "Heartbeat failed, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Heartbeat failed, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
 		return false, err
 	}
 	if bo == false {
-		lager.Logger.Errorf(err, "Heartbeat failed, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Heartbeat failed, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
 		return bo, err
 	}
-	lager.Logger.Debugf("Heartbeat success, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
+	lager.Component(lager.ComponentRegistry).Debugf("Heartbeat success, microServiceID/instanceID: %s/%s.", microServiceID, microServiceInstanceID)
 	return bo, nil
 }
 
@@ -179,20 +179,20 @@ func (r *Registrator) AddDependencies(cDep *registry.MicroServiceDependency) err
 	request := ToSCDependency(cDep)
 	err := r.registryClient.AddDependencies(request)
 	if err != nil {
-		lager.Logger.Errorf(err, "AddDependencies failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "AddDependencies failed.")
 		return err
 	}
-	lager.Logger.Debugf("AddDependencies success.")
+	lager.Component(lager.ComponentRegistry).Debugf("AddDependencies success.")
 	return nil
 }
 
 // AddSchemas to service center
 func (r *Registrator) AddSchemas(microServiceID, schemaName, schemaInfo string) error {
 	if err := r.registryClient.AddSchemas(microServiceID, schemaName, schemaInfo); err != nil {
-		lager.Logger.Errorf(err, "AddSchemas failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "AddSchemas failed.")
 		return err
 	}
-	lager.Logger.Debugf("AddSchemas success.")
+	lager.Component(lager.ComponentRegistry).Debugf("AddSchemas success.")
 	return nil
 }
 
@@ -200,10 +200,10 @@ func (r *Registrator) AddSchemas(microServiceID, schemaName, schemaInfo string)
 func (r *Registrator) UpdateMicroServiceInstanceStatus(microServiceID, microServiceInstanceID, status string) error {
 	isSuccess, err := r.registryClient.UpdateMicroServiceInstanceStatus(microServiceID, microServiceInstanceID, status)
 	if !isSuccess {
-		lager.Logger.Errorf(nil, "UpdateMicroServiceInstanceStatus failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "UpdateMicroServiceInstanceStatus failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 		return err
 	}
-	lager.Logger.Debugf("UpdateMicroServiceInstanceStatus success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+	lager.Component(lager.ComponentRegistry).Debugf("UpdateMicroServiceInstanceStatus success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 	return nil
 }
 
@@ -214,10 +214,10 @@ func (r *Registrator) UpdateMicroServiceProperties(microServiceID string, proper
 	}
 	isSuccess, err := r.registryClient.UpdateMicroServiceProperties(microServiceID, microService)
 	if !isSuccess {
-		lager.Logger.Errorf(nil, "UpdateMicroService Properties failed, microServiceID/instanceID = %s.", microServiceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "UpdateMicroService Properties failed, microServiceID/instanceID = %s.", microServiceID)
 		return err
 	}
-	lager.Logger.Debugf("UpdateMicroService Properties success, microServiceID/instanceID = %s.", microServiceID)
+	lager.Component(lager.ComponentRegistry).Debugf("UpdateMicroService Properties success, microServiceID/instanceID = %s.", microServiceID)
 	return nil
 }
 
@@ -228,10 +228,10 @@ func (r *Registrator) UpdateMicroServiceInstanceProperties(microServiceID, micro
 	}
 	isSuccess, err := r.registryClient.UpdateMicroServiceInstanceProperties(microServiceID, microServiceInstanceID, microServiceInstance)
 	if !isSuccess {
-		lager.Logger.Errorf(nil, "UpdateMicroServiceInstanceProperties failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "UpdateMicroServiceInstanceProperties failed, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 		return err
 	}
-	lager.Logger.Debugf("UpdateMicroServiceInstanceProperties success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
+	lager.Component(lager.ComponentRegistry).Debugf("UpdateMicroServiceInstanceProperties success, microServiceID/instanceID = %s/%s.", microServiceID, microServiceInstanceID)
 	return nil
 }
 
@@ -251,10 +251,10 @@ type ServiceDiscovery struct {
 func (r *ServiceDiscovery) GetMicroServiceID(appID, microServiceName, version, env string) (string, error) {
 	microServiceID, err := r.registryClient.GetMicroServiceID(appID, microServiceName, version, env)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceID failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceID failed")
 		return "", err
 	}
-	lager.Logger.Debugf("GetMicroServiceID success")
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServiceID success")
 	return microServiceID, nil
 }
 
@@ -262,14 +262,14 @@ func (r *ServiceDiscovery) GetMicroServiceID(appID, microServiceName, version, e
 func (r *ServiceDiscovery) GetAllMicroServices() ([]*registry.MicroService, error) {
 	microServices, err := r.registryClient.GetAllMicroServices()
 	if err != nil {
-		lager.Logger.Errorf(err, "GetAllMicroServices failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetAllMicroServices failed")
 		return nil, err
 	}
 	mss := []*registry.MicroService{}
 	for _, s := range microServices {
 		mss = append(mss, ToMicroService(s))
 	}
-	lager.Logger.Debugf("GetAllMicroServices success, MicroService: %s", microServices)
+	lager.Component(lager.ComponentRegistry).Debugf("GetAllMicroServices success, MicroService: %s", microServices)
 	return mss, nil
 }
 
@@ -277,14 +277,14 @@ func (r *ServiceDiscovery) GetAllMicroServices() ([]*registry.MicroService, erro
 func (r *ServiceDiscovery) GetAllApplications() ([]string, error) {
 	apps, err := r.registryClient.GetAllApplications()
 	if err != nil {
-		lager.Logger.Errorf(err, "GetAllApplications failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetAllApplications failed")
 		return nil, err
 	}
 	appArray := []string{}
 	for _, s := range apps {
 		appArray = append(appArray, s)
 	}
-	lager.Logger.Debugf("GetAllApplications success, Applications: %s", apps)
+	lager.Component(lager.ComponentRegistry).Debugf("GetAllApplications success, Applications: %s", apps)
 	return appArray, nil
 }
 
@@ -292,10 +292,10 @@ func (r *ServiceDiscovery) GetAllApplications() ([]string, error) {
 func (r *ServiceDiscovery) GetMicroService(microServiceID string) (*registry.MicroService, error) {
 	microService, err := r.registryClient.GetMicroService(microServiceID)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroService failed")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroService failed")
 		return nil, err
 	}
-	lager.Logger.Debugf("GetMicroServices success, MicroService: %s", microService)
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServices success, MicroService: %s", microService)
 	return ToMicroService(microService), nil
 }
 
@@ -303,11 +303,11 @@ func (r *ServiceDiscovery) GetMicroService(microServiceID string) (*registry.Mic
 func (r *ServiceDiscovery) GetMicroServiceInstances(consumerID, providerID string) ([]*registry.MicroServiceInstance, error) {
 	providerInstances, err := r.registryClient.GetMicroServiceInstances(consumerID, providerID)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceInstances failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceInstances failed.")
 		return nil, err
 	}
 	instances := filterInstances(providerInstances)
-	lager.Logger.Debugf("GetMicroServiceInstances success, consumerID/providerID: %s/%s", consumerID, providerID)
+	lager.Component(lager.ComponentRegistry).Debugf("GetMicroServiceInstances success, consumerID/providerID: %s/%s", consumerID, providerID)
 	return instances, nil
 }
 
@@ -319,7 +319,7 @@ func (r *ServiceDiscovery) FindMicroServiceInstances(consumerID, microServiceNam
 	tags = wrapTagsForServiceCenter(tags)
 	value, boo := registry.MicroserviceInstanceIndex.Get(microServiceName, tags.KV)
 	if !boo || value == nil {
-		lager.Logger.Warnf("%s Get instances from remote, key: %s %s", consumerID, appID, microServiceName)
+		lager.Component(lager.ComponentRegistry).Warnf("%s Get instances from remote, key: %s %s", consumerID, appID, microServiceName)
 		providerInstances, err := r.registryClient.FindMicroServiceInstances(consumerID, appID, microServiceName,
 			findVersionRule(microServiceName))
 		if err != nil {
@@ -329,13 +329,13 @@ func (r *ServiceDiscovery) FindMicroServiceInstances(consumerID, microServiceNam
 		filterReIndex(providerInstances, microServiceName, appID)
 		value, boo = registry.MicroserviceInstanceIndex.Get(microServiceName, tags.KV)
 		if !boo || value == nil {
-			lager.Logger.Debugf("Find no microservice instances for %s from cache", microServiceName)
+			lager.Component(lager.ComponentRegistry).Debugf("Find no microservice instances for %s from cache", microServiceName)
 			return nil, nil
 		}
 	}
 	microServiceInstance, ok := value.([]*registry.MicroServiceInstance)
 	if !ok {
-		lager.Logger.Errorf(nil, "FindMicroServiceInstances failed, Type asserts failed.consumerIDL: %s", consumerID)
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "FindMicroServiceInstances failed, Type asserts failed.consumerIDL: %s", consumerID)
 	}
 	return microServiceInstance, nil
 }
@@ -345,30 +345,30 @@ func (r *ServiceDiscovery) GetDependentMicroServiceInstances(appID, consumerMicr
 	var instancesAll []*model.MicroServiceInstance
 	microServiceConsumerID, err := r.GetMicroServiceID(appID, consumerMicroServiceName, version, env)
 	if err != nil {
-		lager.Logger.Errorf(err, "GetMicroServiceID failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceID failed.")
 		return nil, err
 	}
 	providers, err := r.registryClient.GetProviders(microServiceConsumerID)
 	if err != nil {
-		lager.Logger.Errorf(err, "Get Provider failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Get Provider failed.")
 		return nil, err
 	}
 	for _, provider := range providers.Services {
 		microServiceProviderID, err := r.GetMicroServiceID(provider.AppID, provider.ServiceName, provider.Version, env)
 		if err != nil {
-			lager.Logger.Errorf(err, "GetMicroServiceID failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceID failed.")
 			return nil, err
 		}
 		instances, err := r.GetMicroServiceInstances(microServiceConsumerID, microServiceProviderID)
 		if err != nil {
-			lager.Logger.Errorf(err, "GetMicroServiceInstances failed.")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "GetMicroServiceInstances failed.")
 			return nil, err
 		}
 		for _, value := range instances {
 			instancesAll = append(instancesAll, ToSCInstance(value))
 		}
 	}
-	lager.Logger.Debugf("GetDependentMicroServiceInstances success, appID/microServiceName/version: %s/%s/%s", appID, consumerMicroServiceName, version)
+	lager.Component(lager.ComponentRegistry).Debugf("GetDependentMicroServiceInstances success, appID/microServiceName/version: %s/%s/%s", appID, consumerMicroServiceName, version)
 	return instancesAll, nil
 }
 
@@ -408,7 +408,7 @@ func (r *ContractDiscovery) GetMicroServicesByInterface(interfaceName string) (m
 	microServiceModel, ok := value.([]*model.MicroService)
 
 	if !ok {
-		lager.Logger.Errorf(nil, "GetMicroServicesByInterface failed, Type asserts failed")
+		lager.Component(lager.ComponentRegistry).Errorf(nil, "GetMicroServicesByInterface failed, Type asserts failed")
 	}
 
 	for _, v := range microServiceModel {
@@ -461,7 +461,7 @@ func (r *ContractDiscovery) fillSchemaServiceIndexCache(ms []*model.MicroService
 	if ms == nil {
 		microServiceList, err := r.registryClient.GetAllMicroServices()
 		if err != nil {
-			lager.Logger.Errorf(err, "Get instances failed")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Get instances failed")
 			return content
 		}
 
@@ -507,7 +507,7 @@ func (r *ContractDiscovery) fillSchemaInterfaceIndexCache(ms []*model.MicroServi
 	if ms == nil {
 		microServiceList, err := r.registryClient.GetAllMicroServices()
 		if err != nil {
-			lager.Logger.Errorf(err, "Get instances failed")
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Get instances failed")
 			return content
 		}
 
@@ -568,10 +568,10 @@ func (r *ContractDiscovery) GetSchema(microServiceID, schemaName string) ([]byte
 	var schemaContent []byte
 	var err error
 	if schemaContent, err = r.registryClient.GetSchema(microServiceID, schemaName); err != nil {
-		lager.Logger.Errorf(err, "GetSchema failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "GetSchema failed.")
 		return []byte(""), err
 	}
-	lager.Logger.Debugf("GetSchema success.")
+	lager.Component(lager.ComponentRegistry).Debugf("GetSchema success.")
 	return schemaContent, nil
 
 }
@@ -584,7 +584,7 @@ func newRegistrator(options registry.Options) registry.Registrator {
 	sco := ToSCOptions(options)
 	r := &client.RegistryClient{}
 	if err := r.Initialize(sco); err != nil {
-		lager.Logger.Errorf(err, "RegistryClient initialization failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegistryClient initialization failed.")
 	}
 
 	return &Registrator{
@@ -597,7 +597,7 @@ func newServiceDiscovery(options registry.Options) registry.ServiceDiscovery {
 	sco := ToSCOptions(options)
 	r := &client.RegistryClient{}
 	if err := r.Initialize(sco); err != nil {
-		lager.Logger.Errorf(err, "RegistryClient initialization failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegistryClient initialization failed.")
 	}
 
 	return &ServiceDiscovery{
@@ -610,7 +610,7 @@ func newContractDiscovery(options registry.Options) registry.ContractDiscovery {
 	sco := ToSCOptions(options)
 	r := &client.RegistryClient{}
 	if err := r.Initialize(sco); err != nil {
-		lager.Logger.Errorf(err, "RegistryClient initialization failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "RegistryClient initialization failed.")
 	}
 
 	return &ContractDiscovery{
@@ -53,7 +53,7 @@ func unmarshalSchemaContent(content []byte) (*registry.SchemaContent, error) {
 func filterInstances(providerInstances []*model.MicroServiceInstance) []*registry.MicroServiceInstance {
 	instances := make([]*registry.MicroServiceInstance, 0)
 	for _, ins := range providerInstances {
-		if ins.Status != model.MSInstanceUP {
+		if !registry.IsRoutableStatus(ins.Status) {
 			continue
 		}
 		msi := ToMicroServiceInstance(ins)
@@ -65,10 +65,10 @@ func filterInstances(providerInstances []*model.MicroServiceInstance) []*registr
 func closeClient(r *client.RegistryClient) error {
 	err := r.Close()
 	if err != nil {
-		lager.Logger.Errorf(err, "Conn close failed.")
+		lager.Component(lager.ComponentRegistry).Errorf(err, "Conn close failed.")
 		return err
 	}
-	lager.Logger.Debugf("Conn close success.")
+	lager.Component(lager.ComponentRegistry).Debugf("Conn close success.")
 	return nil
 }
 
@@ -0,0 +1,284 @@
+package registry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/util/fileutil"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// constant values for instance cache snapshot
+const (
+	DefaultSnapshotFile         = "registry_snapshot.json"
+	DefaultSnapshotInterval     = 30 * time.Second
+	DefaultSnapshotMaxStaleness = 24 * time.Hour
+
+	// MetricSnapshotStale is 1 when any service is served from a stale snapshot
+	MetricSnapshotStale = "registry_snapshot_stale"
+	// MetricSnapshotStaleServices is the number of services served from a stale snapshot
+	MetricSnapshotStaleServices = "registry_snapshot_stale_services"
+)
+
+// Snapshot is the content of instance cache persisted to disk
+type Snapshot struct {
+	Timestamp time.Time                          `json:"timestamp"`
+	Instances map[string][]*MicroServiceInstance `json:"instances"`
+}
+
+// snapshotManager periodically persists MicroserviceInstanceIndex to disk,
+// and tracks services whose instances were restored from a snapshot
+type snapshotManager struct {
+	path         string
+	interval     time.Duration
+	maxStaleness time.Duration
+
+	mu    sync.RWMutex
+	stale map[string]time.Time
+}
+
+var defaultSnapshotManager *snapshotManager
+
+// keyLister is implemented by cache index which can list cached service names
+type keyLister interface {
+	keys() []string
+}
+
+func (n *noIndexCache) keys() []string {
+	items := n.cache.Items()
+	ks := make([]string, 0, len(items))
+	for k := range items {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func (b *indexCache) keys() []string { return b.cache.keys() }
+
+func newSnapshotManager() *snapshotManager {
+	s := &snapshotManager{
+		path:         config.GetServiceDiscoverySnapshotPath(),
+		interval:     parseDuration(config.GetServiceDiscoverySnapshotInterval(), DefaultSnapshotInterval),
+		maxStaleness: parseDuration(config.GetServiceDiscoverySnapshotMaxStaleness(), DefaultSnapshotMaxStaleness),
+		stale:        make(map[string]time.Time),
+	}
+	if s.path == "" {
+		s.path = filepath.Join(fileutil.ChassisHomeDir(), DefaultSnapshotFile)
+	}
+	return s
+}
+
+func parseDuration(s string, d time.Duration) time.Duration {
+	if s == "" {
+		return d
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		lager.Component(lager.ComponentRegistry).Warnf("invalid duration [%s], use default value %s", s, d)
+		return d
+	}
+	return v
+}
+
+// enableSnapshot restores services missing in cache from snapshot and starts to persist cache
+func enableSnapshot() {
+	if !config.GetServiceDiscoverySnapshotEnabled() {
+		return
+	}
+	defaultSnapshotManager = newSnapshotManager()
+	if err := defaultSnapshotManager.load(); err != nil {
+		lager.Component(lager.ComponentRegistry).Warnf("load instance cache snapshot failed: %s", err)
+	}
+	go defaultSnapshotManager.run()
+	lager.Component(lager.ComponentRegistry).Infof("Enable instance cache snapshot, path: %s", defaultSnapshotManager.path)
+}
+
+func (s *snapshotManager) run() {
+	ticker := time.NewTicker(s.interval)
+	for range ticker.C {
+		s.expire()
+		if err := s.save(); err != nil {
+			lager.Component(lager.ComponentRegistry).Errorf(err, "save instance cache snapshot failed")
+		}
+		s.report()
+	}
+}
+
+// load puts instances from snapshot into cache, only for services which registry did not supply
+func (s *snapshotManager) load() error {
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	snap := &Snapshot{}
+	if err := json.Unmarshal(b, snap); err != nil {
+		return err
+	}
+	if time.Since(snap.Timestamp) > s.maxStaleness {
+		lager.Component(lager.ComponentRegistry).Warnf("instance cache snapshot is older than %s, ignore it", s.maxStaleness)
+		return nil
+	}
+	loaded := make(map[string][]*MicroServiceInstance)
+	s.mu.Lock()
+	for service, instances := range snap.Instances {
+		if len(instances) == 0 {
+			continue
+		}
+		if _, ok := MicroserviceInstanceIndex.Get(service, nil); ok {
+			continue
+		}
+		MicroserviceInstanceIndex.Set(service, instances)
+		s.stale[service] = snap.Timestamp
+		loaded[service] = instances
+		lager.Component(lager.ComponentRegistry).Warnf("Registry is unavailable, use [%d] stale instances of service [%s] from snapshot",
+			len(instances), service)
+	}
+	s.mu.Unlock()
+	// publish events without holding lock, subscribers may query staleness
+	for service, instances := range loaded {
+		PublishDiff(service, nil, instances)
+	}
+	s.report()
+	return nil
+}
+
+// save writes all fresh services in cache to snapshot file
+func (s *snapshotManager) save() error {
+	kl, ok := MicroserviceInstanceIndex.(keyLister)
+	if !ok {
+		return nil
+	}
+	snap := &Snapshot{
+		Timestamp: time.Now(),
+		Instances: make(map[string][]*MicroServiceInstance),
+	}
+	for _, service := range kl.keys() {
+		// do not refresh timestamp of stale instances
+		if s.isStale(service) {
+			continue
+		}
+		v, ok := MicroserviceInstanceIndex.Get(service, nil)
+		if !ok {
+			continue
+		}
+		instances, ok := v.([]*MicroServiceInstance)
+		if !ok || len(instances) == 0 {
+			continue
+		}
+		snap.Instances[service] = instances
+	}
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	// write to temp file first, so that a crash never leaves a broken snapshot
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// expire removes stale services which exceed max staleness from cache
+func (s *snapshotManager) expire() {
+	removed := make(map[string][]*MicroServiceInstance)
+	s.mu.Lock()
+	for service, t := range s.stale {
+		if time.Since(t) > s.maxStaleness {
+			removed[service] = cachedInstances(service)
+			MicroserviceInstanceIndex.Delete(service)
+			delete(s.stale, service)
+			lager.Component(lager.ComponentRegistry).Warnf("stale instances of service [%s] exceed max staleness, removed", service)
+		}
+	}
+	s.mu.Unlock()
+	for service, olds := range removed {
+		PublishDiff(service, olds, nil)
+	}
+}
+
+func (s *snapshotManager) isStale(service string) bool {
+	s.mu.RLock()
+	_, ok := s.stale[service]
+	s.mu.RUnlock()
+	return ok
+}
+
+// fresh marks service as supplied by registry, returns true if it was stale
+func (s *snapshotManager) fresh(service string) bool {
+	s.mu.Lock()
+	_, ok := s.stale[service]
+	delete(s.stale, service)
+	s.mu.Unlock()
+	if ok {
+		lager.Component(lager.ComponentRegistry).Infof("Registry is available, replace stale instances of service [%s]", service)
+		s.report()
+	}
+	return ok
+}
+
+// remove forgets service which is deleted from cache
+func (s *snapshotManager) remove(service string) {
+	s.mu.Lock()
+	_, ok := s.stale[service]
+	delete(s.stale, service)
+	s.mu.Unlock()
+	if ok {
+		s.report()
+	}
+}
+
+func (s *snapshotManager) report() {
+	s.mu.RLock()
+	n := len(s.stale)
+	s.mu.RUnlock()
+	var stale int64
+	if n > 0 {
+		stale = 1
+	}
+	// metrics system registry is go-metrics default registry,
+	// registry package can not import metrics package
+	gometrics.GetOrRegisterGauge(MetricSnapshotStale, gometrics.DefaultRegistry).Update(stale)
+	gometrics.GetOrRegisterGauge(MetricSnapshotStaleServices, gometrics.DefaultRegistry).Update(int64(n))
+}
+
+// IsStale returns true if instances of service are restored from snapshot
+// and have not been refreshed by registry yet
+func IsStale(service string) bool {
+	if defaultSnapshotManager == nil {
+		return false
+	}
+	return defaultSnapshotManager.isStale(service)
+}
+
+// MarkFresh is called when registry supplies instances of service,
+// it returns true if instances of service were restored from snapshot.
+// discovery plugins which set MicroserviceInstanceIndex directly should call it
+func MarkFresh(service string) bool {
+	if defaultSnapshotManager == nil {
+		return false
+	}
+	return defaultSnapshotManager.fresh(service)
+}
+
+// MarkRemoved is called when service is deleted from cache,
+// so that instances restored from snapshot are no longer reported as stale.
+// discovery plugins which delete services from MicroserviceInstanceIndex directly should call it
+func MarkRemoved(service string) {
+	if defaultSnapshotManager == nil {
+		return
+	}
+	defaultSnapshotManager.remove(service)
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	enableRegistryCache()
+	s := &snapshotManager{
+		path:         filepath.Join(dir, DefaultSnapshotFile),
+		interval:     time.Second,
+		maxStaleness: time.Hour,
+		stale:        make(map[string]time.Time),
+	}
+	defaultSnapshotManager = s
+	defer func() { defaultSnapshotManager = nil }()
+
+	MicroserviceInstanceIndex.Set("Server", []*MicroServiceInstance{
+		{InstanceID: "1", EndpointsMap: map[string]string{"rest": "127.0.0.1:8080"}, Metadata: map[string]string{}},
+	})
+	assert.NoError(t, s.save())
+
+	// case: registry is down at boot, instances come from snapshot
+	enableRegistryCache()
+	assert.NoError(t, s.load())
+	v, ok := MicroserviceInstanceIndex.Get("Server", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "1", v.([]*MicroServiceInstance)[0].InstanceID)
+	assert.True(t, IsStale("Server"))
+
+	// case: registry is back, stale instances are replaced
+	RefreshCache("Server", []*MicroServiceInstance{
+		{InstanceID: "2", Metadata: map[string]string{}},
+	}, nil)
+	assert.False(t, IsStale("Server"))
+	v, ok = MicroserviceInstanceIndex.Get("Server", nil)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(v.([]*MicroServiceInstance)))
+	assert.Equal(t, "2", v.([]*MicroServiceInstance)[0].InstanceID)
+
+	// case: stale instances exceed max staleness
+	s.stale["Server"] = time.Now().Add(-2 * time.Hour)
+	s.expire()
+	assert.False(t, IsStale("Server"))
+	_, ok = MicroserviceInstanceIndex.Get("Server", nil)
+	assert.False(t, ok)
+}
+
+func TestMarkRemoved(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	s := &snapshotManager{maxStaleness: time.Hour, stale: map[string]time.Time{"Removed": time.Now(), "Kept": time.Now()}}
+	defaultSnapshotManager = s
+	defer func() { defaultSnapshotManager = nil }()
+
+	MarkRemoved("Removed")
+	assert.False(t, IsStale("Removed"))
+	assert.True(t, IsStale("Kept"))
+	assert.Equal(t, int64(1), gometrics.GetOrRegisterGauge(MetricSnapshotStaleServices, gometrics.DefaultRegistry).Value())
+	MarkRemoved("Kept")
+	assert.Equal(t, int64(0), gometrics.GetOrRegisterGauge(MetricSnapshotStale, gometrics.DefaultRegistry).Value())
+}
@@ -90,6 +90,13 @@ type Parameter struct {
 	Items     Item        `yaml:"items"`
 	ColFormat string      `yaml:"collectionFormat"`
 	Schema    SchemaValue `yaml:"schema"`
+	// constraints of path, query, header and formData parameters, nil means no limit
+	Pattern   string        `yaml:"pattern"`
+	Maximum   *float64      `yaml:"maximum"`
+	Minimum   *float64      `yaml:"minimum"`
+	MaxLength *int          `yaml:"maxLength"`
+	MinLength *int          `yaml:"minLength"`
+	Enum      []interface{} `yaml:"enum"`
 }
 
 // SchemaValue represents additional info of schema
@@ -105,7 +112,7 @@ type SchemaValue struct {
 	ExclusiveMinimum     int                    `yaml:"exclusiveMinimum"`
 	MaxLength            int                    `yaml:"maxLength"`
 	MinLength            int                    `yaml:"minLength"`
-	Pattern              int                    `yaml:"pattern"`
+	Pattern              string                 `yaml:"pattern"`
 	MaxItems             int                    `yaml:"maxItems"`
 	MinItems             int                    `yaml:"minItems"`
 	UniqueItems          bool                   `yaml:"uniqueItems"`
@@ -136,4 +143,5 @@ type Definition struct {
 	Types      string                 `yaml:"type"`
 	XJavaClass string                 `yaml:"x-java-class"`
 	Properties map[string]interface{} `yaml:"properties"`
+	Required   []string               `yaml:"required"`
 }
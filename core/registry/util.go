@@ -25,7 +25,7 @@ func GetProtocolMap(eps []string) (map[string]string, string) {
 	for _, ep := range eps {
 		u, err := url.Parse(ep)
 		if err != nil {
-			lager.Logger.Error("Can not parse "+ep, err)
+			lager.Component(lager.ComponentRegistry).Error("Can not parse "+ep, err)
 			continue
 		}
 		proto := u.Scheme
@@ -76,7 +76,7 @@ func MakeEndpointMap(m map[string]model.Protocol) map[string]string {
 		if len(protocol.Advertise) == 0 {
 			host, port, err := net.SplitHostPort(protocol.Listen)
 			if err != nil {
-				lager.Logger.Warnf("get port from listen addr failed.", err)
+				lager.Component(lager.ComponentRegistry).Warnf("get port from listen addr failed.", err)
 				port = iputil.DefaultPort4Protocol(name)
 				host = iputil.Localhost()
 			}
@@ -105,7 +105,7 @@ func MakeEndpointMap(m map[string]model.Protocol) map[string]string {
 			}
 
 			if err != nil {
-				lager.Logger.Errorf(err, "failed to parse ip address")
+				lager.Component(lager.ComponentRegistry).Errorf(err, "failed to parse ip address")
 			} else {
 				if ip != nil && ip.To4() != nil {
 					eps[name] = ip.String() + ":" + ipWithoutPort[1]
@@ -135,7 +135,7 @@ func startBackOff(operation func() error) {
 		Clock:               backoff.SystemClock,
 	}
 	for {
-		lager.Logger.Infof("start backoff with initial interval %v", initialInterval)
+		lager.Component(lager.ComponentRegistry).Infof("start backoff with initial interval %v", initialInterval)
 		err := backoff.Retry(operation, backOff)
 		if err == nil {
 			return
@@ -175,13 +175,13 @@ func getTLSConfig(scheme, t string) (*tls.Config, error) {
 		if err != nil {
 			if chassisTLS.IsSSLConfigNotExist(err) {
 				tmpErr := fmt.Errorf("%s tls mode, but no ssl config", sslTag)
-				lager.Logger.Error(tmpErr.Error(), err)
+				lager.Component(lager.ComponentRegistry).Error(tmpErr.Error(), err)
 				return nil, tmpErr
 			}
-			lager.Logger.Errorf(err, "Load %s TLS config failed.", sslTag)
+			lager.Component(lager.ComponentRegistry).Errorf(err, "Load %s TLS config failed.", sslTag)
 			return nil, err
 		}
-		lager.Logger.Warnf("%s TLS mode, verify peer: %t, cipher plugin: %s.",
+		lager.Component(lager.ComponentRegistry).Warnf("%s TLS mode, verify peer: %t, cipher plugin: %s.",
 			sslTag, sslConfig.VerifyPeer, sslConfig.CipherPlugin)
 		tlsConfig = tmpTLSConfig
 	}
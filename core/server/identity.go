@@ -0,0 +1,48 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/security/common"
+)
+
+// errors of source identity
+var (
+	// ErrSourceIdentityMismatch is returned when source service claimed in request does not match identity of peer certificate
+	ErrSourceIdentityMismatch = errors.New("source service does not match certificate identity")
+	// ErrNoSourceIdentity is returned when verified peer certificate has neither URI SAN nor DNS SAN naming a service
+	ErrNoSourceIdentity = errors.New("certificate gives no source identity")
+)
+
+// SetSourceIdentity sets identity of verified peer certificate in cs to inv.SourceIdentity,
+// if caller does not claim source service, it is taken from identity.
+// if strict is true, it returns ErrSourceIdentityMismatch when claimed source service does not match identity,
+// and ErrNoSourceIdentity when peer certificate gives no service name, so that claimed source service is never trusted
+func SetSourceIdentity(inv *invocation.Invocation, cs *tls.ConnectionState, strict bool) error {
+	identity := common.ConnectionIdentity(cs)
+	name := common.IdentityServiceName(identity)
+	if name == "" {
+		if strict && cs != nil && len(cs.PeerCertificates) != 0 {
+			lager.FromContext(inv.Ctx).Warnf("peer certificate of source service [%s] gives no service identity",
+				inv.SourceMicroService)
+			return ErrNoSourceIdentity
+		}
+		if identity == "" {
+			return nil
+		}
+	}
+	inv.SourceIdentity = identity
+	if inv.SourceMicroService == "" {
+		inv.SourceMicroService = name
+		return nil
+	}
+	if strict && inv.SourceMicroService != name {
+		lager.FromContext(inv.Ctx).Warnf("source service [%s] does not match certificate identity [%s]",
+			inv.SourceMicroService, identity)
+		return ErrSourceIdentityMismatch
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/invocation"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSourceIdentity(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	u, err := url.Parse("spiffe://cluster.local/ns/default/sa/Client")
+	assert.NoError(t, err)
+	cs := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}}
+
+	// no peer certificate
+	inv := &invocation.Invocation{SourceMicroService: "Other", Ctx: context.Background()}
+	assert.NoError(t, server.SetSourceIdentity(inv, &tls.ConnectionState{}, true))
+	assert.Empty(t, inv.SourceIdentity)
+	assert.Equal(t, "Other", inv.SourceMicroService)
+
+	// source service is taken from identity
+	inv = &invocation.Invocation{Ctx: context.Background()}
+	assert.NoError(t, server.SetSourceIdentity(inv, cs, true))
+	assert.Equal(t, u.String(), inv.SourceIdentity)
+	assert.Equal(t, "Client", inv.SourceMicroService)
+
+	inv = &invocation.Invocation{SourceMicroService: "Client", Ctx: context.Background()}
+	assert.NoError(t, server.SetSourceIdentity(inv, cs, true))
+	assert.Equal(t, u.String(), inv.SourceIdentity)
+
+	// mismatch is rejected only in strict mode
+	inv = &invocation.Invocation{SourceMicroService: "Other", Ctx: context.Background()}
+	assert.NoError(t, server.SetSourceIdentity(inv, cs, false))
+	assert.Equal(t, u.String(), inv.SourceIdentity)
+	assert.Equal(t, "Other", inv.SourceMicroService)
+	assert.Equal(t, server.ErrSourceIdentityMismatch, server.SetSourceIdentity(inv, cs, true))
+}
+
+func TestSetSourceIdentityWithoutSAN(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	noSAN := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "Client"}}}}
+	u, err := url.Parse("spiffe://cluster.local")
+	assert.NoError(t, err)
+	trustDomain := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}}
+
+	for _, cs := range []*tls.ConnectionState{noSAN, trustDomain} {
+		// claimed source service is kept if not strict
+		inv := &invocation.Invocation{SourceMicroService: "Other", Ctx: context.Background()}
+		assert.NoError(t, server.SetSourceIdentity(inv, cs, false))
+		assert.Equal(t, "Other", inv.SourceMicroService)
+
+		inv = &invocation.Invocation{SourceMicroService: "Other", Ctx: context.Background()}
+		assert.Equal(t, server.ErrNoSourceIdentity, server.SetSourceIdentity(inv, cs, true))
+		inv = &invocation.Invocation{Ctx: context.Background()}
+		assert.Equal(t, server.ErrNoSourceIdentity, server.SetSourceIdentity(inv, cs, true))
+	}
+}
@@ -1,6 +1,8 @@
 //Package server is a package for protocol of a micro service
 package server
 
+import "context"
+
 // ProtocolServer interface for the protocol server, a server should implement init, register, start, and stop
 type ProtocolServer interface {
 	//Register a schema of microservice,return unique schema id,you can specify schema id and microservice name of this schema
@@ -9,3 +11,9 @@ type ProtocolServer interface {
 	Stop() error
 	String() string
 }
+
+// GracefulServer is implemented by protocol server which can drain in-flight requests,
+// Shutdown stops accepting connections and waits for in-flight requests until ctx is done
+type GracefulServer interface {
+	Shutdown(ctx context.Context) error
+}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/config"
@@ -69,6 +71,34 @@ func StartServer() error {
 	return nil
 }
 
+//StopServers stops all servers, servers implement GracefulServer drain in-flight requests until ctx is done
+func StopServers(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(servers))
+	for name, s := range servers {
+		wg.Add(1)
+		go func(name string, s ProtocolServer) {
+			defer wg.Done()
+			lager.Logger.Info("stopping server " + name + "...")
+			var err error
+			if gs, ok := s.(GracefulServer); ok {
+				err = gs.Shutdown(ctx)
+			} else {
+				err = s.Stop()
+			}
+			if err != nil {
+				lager.Logger.Errorf(err, "server [%s] failed to stop", name)
+				errs <- fmt.Errorf("can not stop [%s] server, %s", name, err.Error())
+				return
+			}
+			lager.Logger.Info(name + " server stop success")
+		}(name, s)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
 //UnRegistrySelfInstances this function removes the self instance
 func UnRegistrySelfInstances() error {
 	if err := registry.DefaultRegistrator.UnRegisterMicroServiceInstance(runtime.ServiceID, runtime.InstanceID); err != nil {
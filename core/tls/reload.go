@@ -0,0 +1,119 @@
+package tls
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	secCommon "github.com/go-chassis/go-chassis/security/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// constants for certificate metric names
+const (
+	CertExpiryMetric        = "tls_certificate_expiry_timestamp_seconds"
+	CertReloadMetric        = "tls_certificate_reloads_total"
+	CertReloadFailureMetric = "tls_certificate_reload_failures_total"
+	certLabel               = "cert"
+	warnBeforeExpiring      = 7 * 24 * time.Hour
+)
+
+// reloaders is shared by listeners and clients with the same ssl config, key is role and ssl config
+var (
+	reloaders   = make(map[string]*secCommon.CertReloader)
+	reloadersMu sync.Mutex
+	watchOnce   sync.Once
+)
+
+// certificate metrics, they are registered to system prometheus registry by chassis
+var (
+	certExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: CertExpiryMetric,
+		Help: "Expiry time of certificate in unix seconds",
+	}, []string{certLabel})
+	certReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: CertReloadMetric,
+		Help: "Number of certificate reloads",
+	}, []string{certLabel})
+	certReloadFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: CertReloadFailureMetric,
+		Help: "Number of failed certificate reloads",
+	}, []string{certLabel})
+)
+
+// Collectors returns prometheus collectors of certificate expiry time and reloads
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{certExpiry, certReloads, certReloadFailures}
+}
+
+// getReloader returns reloader of ssl config, it starts watching files of the reloader
+func getReloader(sslConfig *secCommon.SSLConfig, role string) (*secCommon.CertReloader, error) {
+	key := fmt.Sprintf("%s|%+v", role, *sslConfig)
+	reloadersMu.Lock()
+	defer reloadersMu.Unlock()
+	if r, ok := reloaders[key]; ok {
+		return r, nil
+	}
+	r, err := secCommon.NewCertReloader(sslConfig, role)
+	if err != nil {
+		return nil, err
+	}
+	reloaders[key] = r
+	observeExpiry(r)
+	warnExpiring(r)
+	watchOnce.Do(watch)
+	return r, nil
+}
+
+func watch() {
+	interval := config.GetSSLReloadInterval()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		for range time.Tick(interval) {
+			ReloadCertificates()
+		}
+	}()
+}
+
+// ReloadCertificates checks files of all certificates in use, and reloads changed ones,
+// a certificate failed to reload is kept until files are fixed
+func ReloadCertificates() {
+	reloadersMu.Lock()
+	rs := make([]*secCommon.CertReloader, 0, len(reloaders))
+	for _, r := range reloaders {
+		rs = append(rs, r)
+	}
+	reloadersMu.Unlock()
+	for _, r := range rs {
+		changed, err := r.Reload()
+		if err != nil {
+			certReloadFailures.WithLabelValues(r.Name()).Inc()
+			lager.Logger.Errorf(err, "reload certificate %v failed", r.Files())
+			continue
+		}
+		if changed {
+			certReloads.WithLabelValues(r.Name()).Inc()
+			lager.Logger.Infof("certificate %v is reloaded, expires at %s", r.Files(), r.NotAfter())
+			warnExpiring(r)
+		}
+		observeExpiry(r)
+	}
+}
+
+func observeExpiry(r *secCommon.CertReloader) {
+	notAfter := r.NotAfter()
+	if notAfter.IsZero() {
+		return
+	}
+	certExpiry.WithLabelValues(r.Name()).Set(float64(notAfter.Unix()))
+}
+
+func warnExpiring(r *secCommon.CertReloader) {
+	if notAfter := r.NotAfter(); !notAfter.IsZero() && time.Until(notAfter) < warnBeforeExpiring {
+		lager.Logger.Warnf("certificate %s expires at %s", r.Name(), notAfter)
+	}
+}
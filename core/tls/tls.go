@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/config"
@@ -37,12 +38,7 @@ func hasDefaultSslTag(tag string) bool {
 }
 
 func getDefaultSslConfigMap() map[string]string {
-	cipherSuits := []string{}
-	for k := range secCommon.TLSCipherSuiteMap {
-		cipherSuits = append(cipherSuits, k)
-	}
-
-	cipherSuitesKey := strings.Join(cipherSuits, ",")
+	cipherSuitesKey := strings.Join(secCommon.DefaultCipherSuites, ",")
 	defaultSslConfigMap := map[string]string{
 		common.SslCipherPluginKey: "default",
 		common.SslVerifyPeerKey:   "false",
@@ -52,6 +48,13 @@ func getDefaultSslConfigMap() map[string]string {
 		common.SslCertFileKey:     "",
 		common.SslKeyFileKey:      "",
 		common.SslCertPwdFileKey:  "",
+		// empty max protocol means TLSv1.2, or protocol if it is higher
+		common.SslMaxProtocolKey:              "",
+		common.SslCurvePreferencesKey:         "",
+		common.SslALPNProtocolsKey:            "",
+		common.SslSessionTicketKeyFileKey:     "",
+		common.SslSessionTicketKeyRotationKey: "",
+		common.SslOCSPStapleFileKey:           "",
 	}
 	return defaultSslConfigMap
 }
@@ -113,11 +116,34 @@ func parseSSLConfig(sslConfigMap map[string]string) (*secCommon.SSLConfig, error
 		return nil, err
 	}
 	sslConfig.MaxVersion = secCommon.TLSVersionMap["TLSv1.2"]
+	if p := sslConfigMap[common.SslMaxProtocolKey]; p != "" {
+		if sslConfig.MaxVersion, err = secCommon.ParseSSLProtocol(p); err != nil {
+			return nil, err
+		}
+	} else if sslConfig.MinVersion > sslConfig.MaxVersion {
+		sslConfig.MaxVersion = sslConfig.MinVersion
+	}
+	if err = secCommon.CheckSSLCipherSuites(sslConfig.CipherSuites, sslConfig.MinVersion, sslConfig.MaxVersion); err != nil {
+		return nil, err
+	}
 	sslConfig.CAFile = sslConfigMap[common.SslCaFileKey]
 	sslConfig.CertFile = sslConfigMap[common.SslCertFileKey]
 	sslConfig.KeyFile = sslConfigMap[common.SslKeyFileKey]
 	sslConfig.CertPWDFile = sslConfigMap[common.SslCertPwdFileKey]
 
+	sslConfig.CurvePreferences, err = secCommon.ParseSSLCurvePreferences(sslConfigMap[common.SslCurvePreferencesKey])
+	if err != nil {
+		return nil, err
+	}
+	sslConfig.ALPNProtocols = secCommon.ParseSSLALPNProtocols(sslConfigMap[common.SslALPNProtocolsKey])
+	sslConfig.SessionTicketKeyFile = sslConfigMap[common.SslSessionTicketKeyFileKey]
+	if d := sslConfigMap[common.SslSessionTicketKeyRotationKey]; d != "" {
+		if sslConfig.SessionTicketKeyRotation, err = time.ParseDuration(d); err != nil {
+			return nil, fmt.Errorf("invalid %s %s, it should be a duration like 24h", common.SslSessionTicketKeyRotationKey, d)
+		}
+	}
+	sslConfig.OCSPStapleFile = sslConfigMap[common.SslOCSPStapleFileKey]
+
 	return sslConfig, nil
 }
 
@@ -178,12 +204,18 @@ func GetTLSConfigByService(svcName, protocol, svcType string) (*tls.Config, *sec
 		return nil, nil, err
 	}
 
+	// certificate and ca are reloaded when files change
 	var tlsConfig *tls.Config
+	var r *secCommon.CertReloader
 	switch svcType {
 	case common.Provider:
-		tlsConfig, err = secCommon.GetServerTLSConfig(sslConfig)
+		if r, err = getReloader(sslConfig, "server"); err == nil {
+			tlsConfig = secCommon.GetReloadableServerTLSConfig(r)
+		}
 	case common.Consumer:
-		tlsConfig, err = secCommon.GetClientTLSConfig(sslConfig)
+		if r, err = getReloader(sslConfig, common.Client); err == nil {
+			tlsConfig = secCommon.GetReloadableClientTLSConfig(r)
+		}
 	default:
 		err = fmt.Errorf("service type not support: %s, must be: %s|%s",
 			svcType, common.Provider, common.Consumer)
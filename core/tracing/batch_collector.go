@@ -0,0 +1,229 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	zipkin "github.com/openzipkin/zipkin-go-opentracing"
+	"github.com/openzipkin/zipkin-go-opentracing/thrift/gen-go/zipkincore"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// constant for drop policies of batch collector
+const (
+	// DropNewest discards incoming span if buffer is full
+	DropNewest = "dropNewest"
+	// DropOldest overwrites the oldest span in buffer if buffer is full
+	DropOldest = "dropOldest"
+)
+
+// SpanExporter writes a batch of spans to backend, it is only called by collector goroutine
+type SpanExporter interface {
+	Export(spans []*zipkincore.Span) error
+	Close() error
+}
+
+// collectorExporter adapts zipkin.Collector to SpanExporter
+type collectorExporter struct {
+	c zipkin.Collector
+}
+
+// Export collects spans one by one
+func (e *collectorExporter) Export(spans []*zipkincore.Span) error {
+	var err error
+	for _, s := range spans {
+		if cErr := e.c.Collect(s); cErr != nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+// Close closes collector
+func (e *collectorExporter) Close() error {
+	return e.c.Close()
+}
+
+// BatchStats is counters of batch collector
+type BatchStats struct {
+	Collected int64
+	Dropped   int64
+	Exported  int64
+	Failed    int64
+}
+
+// BatchCollector buffers spans in a bounded ring buffer and exports them in background,
+// Collect never blocks on I/O, spans are dropped according to drop policy if buffer is full
+type BatchCollector struct {
+	exporter SpanExporter
+	c        config.TracingBatchConfig
+
+	mu    sync.Mutex
+	buf   []*zipkincore.Span
+	head  int
+	size  int
+	ready chan struct{}
+
+	// exportMu serializes exporting of loop and flush
+	exportMu sync.Mutex
+	stats    BatchStats
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewBatchCollector wraps collector with a batch collector,
+// collector which implements SpanExporter exports whole batch at once
+func NewBatchCollector(collector zipkin.Collector, c config.TracingBatchConfig) *BatchCollector {
+	exporter, ok := collector.(SpanExporter)
+	if !ok {
+		exporter = &collectorExporter{c: collector}
+	}
+	b := &BatchCollector{
+		exporter: exporter,
+		c:        c,
+		buf:      make([]*zipkincore.Span, c.QueueSize),
+		ready:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Collect puts span into buffer
+func (b *BatchCollector) Collect(s *zipkincore.Span) error {
+	atomic.AddInt64(&b.stats.Collected, 1)
+	b.mu.Lock()
+	if b.size == len(b.buf) {
+		if b.c.DropPolicy != DropOldest {
+			b.mu.Unlock()
+			b.drop(1)
+			return nil
+		}
+		b.head = (b.head + 1) % len(b.buf)
+		b.size--
+		b.drop(1)
+	}
+	b.buf[(b.head+b.size)%len(b.buf)] = s
+	b.size++
+	full := b.size >= b.c.BatchSize
+	b.mu.Unlock()
+	if full {
+		select {
+		case b.ready <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush exports all buffered spans, it returns when buffer is empty or ctx is done
+func (b *BatchCollector) Flush(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if b.exportBatch() == 0 {
+			return nil
+		}
+	}
+}
+
+// Close stops background goroutine, exports buffered spans and closes exporter
+func (b *BatchCollector) Close() error {
+	return b.Shutdown(context.Background())
+}
+
+// Shutdown is Close with a deadline, it returns error of ctx if exporter does not finish before ctx is done,
+// exporting continues in background then
+func (b *BatchCollector) Shutdown(ctx context.Context) error {
+	b.once.Do(func() {
+		close(b.stop)
+	})
+	errc := make(chan error, 1)
+	go func() {
+		<-b.done
+		if err := b.Flush(ctx); err != nil {
+			errc <- err
+			return
+		}
+		errc <- b.exporter.Close()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns counters of collector
+func (b *BatchCollector) Stats() BatchStats {
+	return BatchStats{
+		Collected: atomic.LoadInt64(&b.stats.Collected),
+		Dropped:   atomic.LoadInt64(&b.stats.Dropped),
+		Exported:  atomic.LoadInt64(&b.stats.Exported),
+		Failed:    atomic.LoadInt64(&b.stats.Failed),
+	}
+}
+
+func (b *BatchCollector) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.c.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			// a tick exports everything, so that no span waits longer than flush interval
+			for b.exportBatch() > 0 {
+				continue
+			}
+		case <-b.ready:
+			b.exportBatch()
+		}
+	}
+}
+
+// exportBatch exports at most one batch, it returns number of spans taken from buffer
+func (b *BatchCollector) exportBatch() int {
+	b.exportMu.Lock()
+	defer b.exportMu.Unlock()
+	b.mu.Lock()
+	n := b.size
+	if n > b.c.BatchSize {
+		n = b.c.BatchSize
+	}
+	batch := make([]*zipkincore.Span, n)
+	for i := 0; i < n; i++ {
+		idx := (b.head + i) % len(b.buf)
+		batch[i] = b.buf[idx]
+		b.buf[idx] = nil
+	}
+	b.head = (b.head + n) % len(b.buf)
+	b.size -= n
+	b.mu.Unlock()
+	if n == 0 {
+		return 0
+	}
+	if err := b.exporter.Export(batch); err != nil {
+		atomic.AddInt64(&b.stats.Failed, int64(n))
+		gometrics.GetOrRegisterCounter("tracing_spans_export_failed", gometrics.DefaultRegistry).Inc(int64(n))
+		lager.Logger.Warnf("export %d spans failed: %s", n, err)
+		return n
+	}
+	atomic.AddInt64(&b.stats.Exported, int64(n))
+	gometrics.GetOrRegisterCounter("tracing_spans_exported", gometrics.DefaultRegistry).Inc(int64(n))
+	return n
+}
+
+func (b *BatchCollector) drop(n int64) {
+	atomic.AddInt64(&b.stats.Dropped, n)
+	gometrics.GetOrRegisterCounter("tracing_spans_dropped", gometrics.DefaultRegistry).Inc(n)
+}
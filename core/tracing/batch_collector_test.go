@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/openzipkin/zipkin-go-opentracing/thrift/gen-go/zipkincore"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExporter struct {
+	mu      sync.Mutex
+	batches [][]*zipkincore.Span
+	err     error
+	closed  bool
+}
+
+func (e *fakeExporter) Collect(s *zipkincore.Span) error {
+	return e.Export([]*zipkincore.Span{s})
+}
+
+func (e *fakeExporter) Export(spans []*zipkincore.Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.batches = append(e.batches, spans)
+	return e.err
+}
+
+func (e *fakeExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	return nil
+}
+
+func (e *fakeExporter) spans() []*zipkincore.Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var s []*zipkincore.Span
+	for _, b := range e.batches {
+		s = append(s, b...)
+	}
+	return s
+}
+
+func newSpan(name string) *zipkincore.Span {
+	return &zipkincore.Span{Name: name}
+}
+
+func TestBatchCollector_Batch(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	e := &fakeExporter{}
+	b := NewBatchCollector(e, config.TracingBatchConfig{
+		QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		b.Collect(newSpan("span"))
+	}
+	assert.NoError(t, b.Flush(context.Background()))
+	e.mu.Lock()
+	for _, batch := range e.batches {
+		assert.True(t, len(batch) <= 2)
+	}
+	e.mu.Unlock()
+	assert.Equal(t, 5, len(e.spans()))
+	assert.Equal(t, int64(5), b.Stats().Exported)
+
+	b.Collect(newSpan("last"))
+	assert.NoError(t, b.Close())
+	assert.Equal(t, 6, len(e.spans()))
+	assert.True(t, e.closed)
+}
+
+func TestBatchCollector_Drop(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	c := config.TracingBatchConfig{QueueSize: 2, BatchSize: 2, FlushInterval: time.Hour}
+
+	// collectors without background goroutine, so that buffer is only drained by Flush
+	c.DropPolicy = DropNewest
+	e := &fakeExporter{}
+	b := &BatchCollector{exporter: e, c: c, buf: make([]*zipkincore.Span, c.QueueSize), ready: make(chan struct{}, 1)}
+	for _, n := range []string{"1", "2", "3"} {
+		b.Collect(newSpan(n))
+	}
+	b.Flush(context.Background())
+	assert.Equal(t, int64(1), b.Stats().Dropped)
+	assert.Equal(t, []string{"1", "2"}, names(e.spans()))
+
+	c.DropPolicy = DropOldest
+	e = &fakeExporter{}
+	b = &BatchCollector{exporter: e, c: c, buf: make([]*zipkincore.Span, c.QueueSize), ready: make(chan struct{}, 1)}
+	for _, n := range []string{"1", "2", "3"} {
+		b.Collect(newSpan(n))
+	}
+	b.Flush(context.Background())
+	assert.Equal(t, int64(1), b.Stats().Dropped)
+	assert.Equal(t, []string{"2", "3"}, names(e.spans()))
+}
+
+func TestBatchCollector_ExportFailed(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	e := &fakeExporter{err: errors.New("unavailable")}
+	b := NewBatchCollector(e, config.TracingBatchConfig{
+		QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+	b.Collect(newSpan("span"))
+	b.Close()
+	assert.Equal(t, int64(1), b.Stats().Failed)
+	assert.Equal(t, int64(0), b.Stats().Exported)
+}
+
+// blockingExporter blocks exporting until release is closed
+type blockingExporter struct {
+	fakeExporter
+	release chan struct{}
+}
+
+func (e *blockingExporter) Export(spans []*zipkincore.Span) error {
+	<-e.release
+	return e.fakeExporter.Export(spans)
+}
+
+func TestBatchCollector_Shutdown(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	e := &blockingExporter{release: make(chan struct{})}
+	defer close(e.release)
+	b := NewBatchCollector(e, config.TracingBatchConfig{
+		QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+	b.Collect(newSpan("span"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Equal(t, context.DeadlineExceeded, b.Shutdown(ctx))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func names(spans []*zipkincore.Span) []string {
+	s := make([]string, 0, len(spans))
+	for _, span := range spans {
+		s = append(s, span.Name)
+	}
+	return s
+}
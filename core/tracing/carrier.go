@@ -1,6 +1,8 @@
 package tracing
 
 import (
+	"strings"
+
 	"github.com/go-chassis/go-chassis/client/rest"
 )
 
@@ -11,8 +13,26 @@ const (
 	zipkinParentSpanID = prefixTracerState + "parentspanid"
 	zipkinSampled      = prefixTracerState + "sampled"
 	zipkinFlags        = prefixTracerState + "flags"
+	b3Single           = "b3"
+
+	// W3C trace context and baggage headers
+	w3cTraceParent = "traceparent"
+	w3cTraceState  = "tracestate"
+	w3cBaggage     = "baggage"
 )
 
+var tracingHeaders = map[string]struct{}{
+	zipkinTraceID:      {},
+	zipkinSpanID:       {},
+	zipkinParentSpanID: {},
+	zipkinSampled:      {},
+	zipkinFlags:        {},
+	b3Single:           {},
+	w3cTraceParent:     {},
+	w3cTraceState:      {},
+	w3cBaggage:         {},
+}
+
 // RestClientHeaderWriter rest client header writer
 type RestClientHeaderWriter rest.Request
 
@@ -39,9 +59,11 @@ func (f *HeaderCarrier) ForeachKey(handler func(key, val string) error) error {
 
 func (f *HeaderCarrier) header2TextMap() map[string]string {
 	m := make(map[string]string)
-	for _, s := range []string{zipkinTraceID, zipkinSpanID, zipkinParentSpanID, zipkinSampled, zipkinFlags} {
-		if _, ok := f.Header[s]; ok {
-			m[s] = f.Header[s]
+	// header names are case insensitive, propagators look up lower case names
+	for k, v := range f.Header {
+		lk := strings.ToLower(k)
+		if _, ok := tracingHeaders[lk]; ok {
+			m[lk] = v
 		}
 	}
 	return m
@@ -41,3 +41,24 @@ func TestHeaderCarrier(t *testing.T) {
 	carrier.ForeachKey(handlerFunc)
 	assert.Equal(t, true, containsZipkinHeader)
 }
+
+func TestHeaderCarrierW3C(t *testing.T) {
+	carrier := &tracing.HeaderCarrier{Header: map[string]string{
+		"Traceparent":  "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"tracestate":   "congo=t61rcWkgMzE",
+		"Baggage":      "userId=alice",
+		"X-B3-TraceId": "abc",
+		"Content-Type": "application/json",
+	}}
+	m := make(map[string]string)
+	carrier.ForeachKey(func(k, v string) error {
+		m[k] = v
+		return nil
+	})
+	assert.Equal(t, map[string]string{
+		"traceparent":  "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"tracestate":   "congo=t61rcWkgMzE",
+		"baggage":      "userId=alice",
+		"x-b3-traceid": "abc",
+	}, m)
+}
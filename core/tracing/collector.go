@@ -28,6 +28,13 @@ func (f *FileCollector) Collect(s *zipkincore.Span) error {
 	return err
 }
 
+// Export serializes spans once and writes them into the file collector
+func (f *FileCollector) Export(spans []*zipkincore.Span) error {
+	buf := Serialize(spans)
+	_, err := f.Fd.Write(buf.Bytes())
+	return err
+}
+
 // Close close file collector
 func (f *FileCollector) Close() error {
 	return f.Fd.Close()
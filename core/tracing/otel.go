@@ -0,0 +1,239 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/attribute"
+	otelbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// constant for OpenTelemetry exporters
+const (
+	TracingOTLPHTTPCollector = "otlpHttp"
+	TracingOTLPGRPCCollector = "otlpGrpc"
+	TracingFileCollector     = "file"
+	TracingStdoutCollector   = "stdout"
+)
+
+// constant for propagation formats
+const (
+	PropagationTraceContext = "tracecontext"
+	PropagationBaggage      = "baggage"
+	PropagationB3           = "b3"
+	// DefaultPropagation sends both W3C and B3 headers, so that zipkin tracers keep working during migration
+	DefaultPropagation = PropagationTraceContext + "," + PropagationBaggage + "," + PropagationB3
+)
+
+// IsOTelCollector returns true if collector type is an OpenTelemetry exporter
+func IsOTelCollector(collectorType string) bool {
+	switch collectorType {
+	case TracingOTLPHTTPCollector, TracingOTLPGRPCCollector, TracingFileCollector, TracingStdoutCollector:
+		return true
+	}
+	return false
+}
+
+// NewExporter returns OpenTelemetry span exporter based on collector type
+func NewExporter(collectorType, target string) (sdktrace.SpanExporter, error) {
+	switch collectorType {
+	case TracingOTLPHTTPCollector:
+		opts, err := otlpHTTPOptions(target)
+		if err != nil {
+			return nil, err
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	case TracingOTLPGRPCCollector:
+		endpoint, insecure, err := parseOTLPTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(context.Background(), opts...)
+	case TracingFileCollector:
+		f, err := os.OpenFile(target, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+		if err != nil {
+			return nil, err
+		}
+		e, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &fileExporter{SpanExporter: e, f: f}, nil
+	case TracingStdoutCollector:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	}
+	return nil, errors.New("Not support exporter type: " + collectorType)
+}
+
+// fileExporter closes file after exporter shutdown
+type fileExporter struct {
+	sdktrace.SpanExporter
+	f *os.File
+}
+
+// Shutdown flushes spans and closes the file
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cErr := e.f.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// parseOTLPTarget returns host:port of target, target without https scheme is treated as insecure
+func parseOTLPTarget(target string) (string, bool, error) {
+	if target == "" {
+		return "", false, errors.New("empty OTLP collector target")
+	}
+	if !strings.Contains(target, "://") {
+		return target, true, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", false, err
+	}
+	return u.Host, u.Scheme != "https", nil
+}
+
+func otlpHTTPOptions(target string) ([]otlptracehttp.Option, error) {
+	endpoint, insecure, err := parseOTLPTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if u, err := url.Parse(target); err == nil && u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	return opts, nil
+}
+
+// NewPropagator returns composite propagator of formats separated by comma,
+// when extracting, the later format overrides the former one
+func NewPropagator(formats string) (propagation.TextMapPropagator, error) {
+	if formats == "" {
+		formats = DefaultPropagation
+	}
+	ps := make([]propagation.TextMapPropagator, 0)
+	// b3 goes first, so that W3C trace context is preferred if request carries both of them
+	for _, f := range strings.Split(formats, ",") {
+		switch strings.TrimSpace(f) {
+		case PropagationB3:
+			ps = append([]propagation.TextMapPropagator{b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))}, ps...)
+		case PropagationTraceContext:
+			ps = append(ps, propagation.TraceContext{})
+		case PropagationBaggage:
+			ps = append(ps, propagation.Baggage{})
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown propagation format [%s]", f)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(ps...), nil
+}
+
+// otelTracers holds tracer providers sharing one exporter
+type otelTracers struct {
+	exporter  sdktrace.SpanExporter
+	providers []*sdktrace.TracerProvider
+}
+
+// newOTelTracers creates one bridged opentracing tracer for each caller,
+// so that handlers work with both zipkin and OpenTelemetry
+func newOTelTracers(exporter sdktrace.SpanExporter, propagator propagation.TextMapPropagator,
+	c config.TracingBatchConfig, callers []string) (*otelTracers, map[string]opentracing.Tracer) {
+	o := &otelTracers{exporter: exporter}
+	tracers := make(map[string]opentracing.Tracer, len(callers))
+	shared := &sharedExporter{SpanExporter: exporter}
+	for _, caller := range callers {
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(shared,
+				sdktrace.WithMaxQueueSize(c.QueueSize),
+				sdktrace.WithMaxExportBatchSize(c.BatchSize),
+				sdktrace.WithBatchTimeout(c.FlushInterval),
+			),
+			sdktrace.WithSampler(otelSampler{}),
+			sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", caller))),
+		)
+		o.providers = append(o.providers, tp)
+		bridge, _ := otelbridge.NewTracerPair(tp.Tracer("go-chassis"))
+		bridge.SetTextMapPropagator(propagator)
+		bridge.SetWarningHandler(func(msg string) {
+			lager.Logger.Debug(msg)
+		})
+		tracers[caller] = bridge
+	}
+	return o, tracers
+}
+
+// Close exports buffered spans then shuts down exporter
+func (o *otelTracers) Close(ctx context.Context) error {
+	var err error
+	for _, tp := range o.providers {
+		if e := tp.Shutdown(ctx); e != nil && err == nil {
+			err = e
+		}
+	}
+	if e := o.exporter.Shutdown(ctx); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// otelSampler applies sampler to OpenTelemetry spans
+type otelSampler struct{}
+
+// ShouldSample implements sdktrace.Sampler
+func (otelSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+	sp := SamplingParams{
+		TraceID:       binary.BigEndian.Uint64(p.TraceID[8:]),
+		HasParent:     parent.IsValid(),
+		ParentSampled: parent.IsSampled(),
+	}
+	sp.Service, sp.Operation = parseOperationName(p.Name)
+	decision := sdktrace.Drop
+	if ShouldSample(sp) {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{Decision: decision, Tracestate: parent.TraceState()}
+}
+
+// Description implements sdktrace.Sampler
+func (otelSampler) Description() string {
+	return "ChassisSampler"
+}
+
+// sharedExporter is shared by tracer providers,
+// shutting down one provider must not shut down the exporter
+type sharedExporter struct {
+	sdktrace.SpanExporter
+}
+
+// Shutdown does nothing, exporter is shut down by otelTracers
+func (e *sharedExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
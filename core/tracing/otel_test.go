@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestIsOTelCollector(t *testing.T) {
+	assert.True(t, IsOTelCollector(TracingOTLPHTTPCollector))
+	assert.True(t, IsOTelCollector(TracingStdoutCollector))
+	assert.False(t, IsOTelCollector(TracingZipkinCollector))
+	assert.False(t, IsOTelCollector(""))
+}
+
+func TestNewExporter(t *testing.T) {
+	f := filepath.Join(os.TempDir(), "chassis_otel_trace.log")
+	defer os.Remove(f)
+	e, err := NewExporter(TracingFileCollector, f)
+	assert.NoError(t, err)
+	assert.NoError(t, e.Shutdown(context.Background()))
+
+	_, err = NewExporter(TracingOTLPHTTPCollector, "")
+	assert.Error(t, err)
+	_, err = NewExporter("no-support", "")
+	assert.Error(t, err)
+}
+
+func TestParseOTLPTarget(t *testing.T) {
+	ep, insecure, err := parseOTLPTarget("127.0.0.1:4317")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:4317", ep)
+	assert.True(t, insecure)
+
+	ep, insecure, err = parseOTLPTarget("https://collector:4318/v1/traces")
+	assert.NoError(t, err)
+	assert.Equal(t, "collector:4318", ep)
+	assert.False(t, insecure)
+}
+
+func TestNewPropagator(t *testing.T) {
+	_, err := NewPropagator("tracecontext,unknown")
+	assert.Error(t, err)
+	p, err := NewPropagator("")
+	assert.NoError(t, err)
+	assert.Contains(t, p.Fields(), "traceparent")
+	assert.Contains(t, p.Fields(), "x-b3-traceid")
+}
+
+func TestOTelTracerPropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	p, err := NewPropagator("")
+	assert.NoError(t, err)
+	o, tracers := newOTelTracers(exporter, p, config.TracingBatchConfig{
+		QueueSize: 10, BatchSize: 10, FlushInterval: time.Second}, []string{"default"})
+	tracer := tracers["default"]
+
+	span := tracer.StartSpan("client")
+	carrier := opentracing.TextMapCarrier{}
+	err = tracer.Inject(span.Context(), opentracing.TextMap, carrier)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, carrier[w3cTraceParent])
+	assert.NotEmpty(t, carrier[zipkinTraceID])
+
+	// provider which only understands b3 still joins the trace
+	b3Only := opentracing.TextMapCarrier{
+		zipkinTraceID: carrier[zipkinTraceID],
+		zipkinSpanID:  carrier[zipkinSpanID],
+		zipkinSampled: carrier[zipkinSampled],
+	}
+	wire, err := tracer.Extract(opentracing.TextMap, b3Only)
+	assert.NoError(t, err)
+	server := tracer.StartSpan("server", opentracing.ChildOf(wire))
+	assert.Equal(t, carrier[zipkinTraceID], TraceID(opentracing.ContextWithSpan(context.Background(), server)))
+	server.Finish()
+	span.Finish()
+
+	for _, tp := range o.providers {
+		assert.NoError(t, tp.ForceFlush(context.Background()))
+	}
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.NoError(t, o.Close(context.Background()))
+}
@@ -0,0 +1,217 @@
+package tracing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"golang.org/x/time/rate"
+)
+
+// constant for sampler types
+const (
+	SamplerProbabilistic = "probabilistic"
+	SamplerRateLimiting  = "rateLimiting"
+	SamplerConst         = "const"
+)
+
+// SamplingParams is what sampler knows about a new span
+type SamplingParams struct {
+	// Service is the target micro service of span
+	Service string
+	// Operation is the interface name of span, it is rest path or operation id
+	Operation string
+	// TraceID is the lower 64 bits of trace id
+	TraceID       uint64
+	HasParent     bool
+	ParentSampled bool
+}
+
+// Sampler decides whether a span is recorded and sent to collector
+type Sampler interface {
+	ShouldSample(p SamplingParams) bool
+}
+
+// NewSampler creates a sampler with param, param meaning depends on sampler type
+type NewSampler func(param float64) (Sampler, error)
+
+var samplerPlugins = map[string]NewSampler{
+	SamplerProbabilistic: newProbabilisticSampler,
+	SamplerRateLimiting:  newRateLimitingSampler,
+	SamplerConst:         newConstSampler,
+}
+
+// InstallSamplerPlugin install a sampler type
+func InstallSamplerPlugin(name string, f NewSampler) {
+	samplerPlugins[name] = f
+}
+
+// samplerHolder makes sampler replaceable at runtime
+type samplerHolder struct {
+	s Sampler
+}
+
+var defaultSampler atomic.Value
+
+func init() {
+	defaultSampler.Store(samplerHolder{s: &constSampler{decision: true}})
+}
+
+// ShouldSample asks current sampler whether span should be sampled
+func ShouldSample(p SamplingParams) bool {
+	return defaultSampler.Load().(samplerHolder).s.ShouldSample(p)
+}
+
+// SetSampler replaces current sampler
+func SetSampler(s Sampler) {
+	defaultSampler.Store(samplerHolder{s: s})
+}
+
+// RefreshSampler builds sampler from config and replaces current sampler,
+// current sampler is kept if config is invalid
+func RefreshSampler() error {
+	s, err := BuildSampler(config.GetSamplerSettings())
+	if err != nil {
+		lager.Logger.Errorf(err, "invalid tracing sampler config, keep current sampler")
+		return err
+	}
+	SetSampler(s)
+	lager.Logger.Debugf("tracing sampler refreshed")
+	return nil
+}
+
+// BuildSampler builds sampler of services and operations,
+// operation config overrides service config, service config overrides default config
+func BuildSampler(c config.SamplerSettings) (Sampler, error) {
+	d, err := newSamplerFromConfig(c.Default)
+	if err != nil {
+		return nil, err
+	}
+	s := &PerOperationSampler{
+		Default:    d,
+		Services:   make(map[string]Sampler, len(c.Services)),
+		Operations: make(map[string]Sampler, len(c.Operations)),
+	}
+	for name, sc := range c.Services {
+		if s.Services[name], err = newSamplerFromConfig(sc); err != nil {
+			return nil, fmt.Errorf("service [%s]: %s", name, err)
+		}
+	}
+	for name, sc := range c.Operations {
+		if s.Operations[name], err = newSamplerFromConfig(sc); err != nil {
+			return nil, fmt.Errorf("operation [%s]: %s", name, err)
+		}
+	}
+	if c.ParentBased {
+		return &ParentBasedSampler{Root: s}, nil
+	}
+	return s, nil
+}
+
+func newSamplerFromConfig(c config.SamplerConfig) (Sampler, error) {
+	f, ok := samplerPlugins[c.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown sampler type [%s]", c.Type)
+	}
+	return f(c.Param)
+}
+
+// PerOperationSampler picks sampler by operation, then by service
+type PerOperationSampler struct {
+	Default    Sampler
+	Services   map[string]Sampler
+	Operations map[string]Sampler
+}
+
+// ShouldSample implements Sampler
+func (s *PerOperationSampler) ShouldSample(p SamplingParams) bool {
+	if o, ok := s.Operations[p.Operation]; ok {
+		return o.ShouldSample(p)
+	}
+	if o, ok := s.Services[p.Service]; ok {
+		return o.ShouldSample(p)
+	}
+	return s.Default.ShouldSample(p)
+}
+
+// ParentBasedSampler follows the decision of parent span, Root decides for spans without parent
+type ParentBasedSampler struct {
+	Root Sampler
+}
+
+// ShouldSample implements Sampler
+func (s *ParentBasedSampler) ShouldSample(p SamplingParams) bool {
+	if p.HasParent {
+		return p.ParentSampled
+	}
+	return s.Root.ShouldSample(p)
+}
+
+// probabilisticSampler samples traces by trace id, so that all spans of a trace get the same decision
+type probabilisticSampler struct {
+	boundary uint64
+	always   bool
+}
+
+func newProbabilisticSampler(param float64) (Sampler, error) {
+	if param < 0 || param > 1 {
+		return nil, fmt.Errorf("sampling rate must be in [0, 1], got %v", param)
+	}
+	return &probabilisticSampler{
+		boundary: uint64(param * math.MaxUint64),
+		always:   param == 1,
+	}, nil
+}
+
+func (s *probabilisticSampler) ShouldSample(p SamplingParams) bool {
+	return s.always || p.TraceID < s.boundary
+}
+
+// rateLimitingSampler samples at most param traces per second
+type rateLimitingSampler struct {
+	limiter *rate.Limiter
+}
+
+func newRateLimitingSampler(param float64) (Sampler, error) {
+	if param < 0 {
+		return nil, fmt.Errorf("traces per second must not be negative, got %v", param)
+	}
+	if param == 0 {
+		return &constSampler{decision: false}, nil
+	}
+	burst := int(math.Ceil(param))
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitingSampler{limiter: rate.NewLimiter(rate.Limit(param), burst)}, nil
+}
+
+func (s *rateLimitingSampler) ShouldSample(p SamplingParams) bool {
+	return s.limiter.Allow()
+}
+
+// constSampler samples all traces if param is not 0
+type constSampler struct {
+	decision bool
+}
+
+func newConstSampler(param float64) (Sampler, error) {
+	return &constSampler{decision: param != 0}, nil
+}
+
+func (s *constSampler) ShouldSample(p SamplingParams) bool {
+	return s.decision
+}
+
+// parseOperationName splits span name in format of [service]:[interface]
+func parseOperationName(name string) (service, operation string) {
+	if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+		if s := strings.SplitN(name[1:len(name)-1], "]:[", 2); len(s) == 2 {
+			return s[0], s[1]
+		}
+	}
+	return "", name
+}
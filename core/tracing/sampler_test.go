@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbabilisticSampler(t *testing.T) {
+	s, err := newProbabilisticSampler(0.5)
+	assert.NoError(t, err)
+	assert.True(t, s.ShouldSample(SamplingParams{TraceID: 1}))
+	assert.False(t, s.ShouldSample(SamplingParams{TraceID: math.MaxUint64 - 1}))
+
+	s, err = newProbabilisticSampler(0)
+	assert.NoError(t, err)
+	assert.False(t, s.ShouldSample(SamplingParams{TraceID: 0}))
+
+	s, err = newProbabilisticSampler(1)
+	assert.NoError(t, err)
+	assert.True(t, s.ShouldSample(SamplingParams{TraceID: math.MaxUint64}))
+
+	_, err = newProbabilisticSampler(2)
+	assert.Error(t, err)
+}
+
+func TestRateLimitingSampler(t *testing.T) {
+	s, err := newRateLimitingSampler(2)
+	assert.NoError(t, err)
+	assert.True(t, s.ShouldSample(SamplingParams{}))
+	assert.True(t, s.ShouldSample(SamplingParams{}))
+	assert.False(t, s.ShouldSample(SamplingParams{}))
+
+	s, err = newRateLimitingSampler(0)
+	assert.NoError(t, err)
+	assert.False(t, s.ShouldSample(SamplingParams{}))
+}
+
+func TestBuildSampler(t *testing.T) {
+	s, err := BuildSampler(config.SamplerSettings{
+		Default:     config.SamplerConfig{Type: SamplerConst, Param: 0},
+		ParentBased: true,
+		Services:    map[string]config.SamplerConfig{"Server": {Type: SamplerProbabilistic, Param: 1}},
+		Operations:  map[string]config.SamplerConfig{"/debug": {Type: SamplerConst, Param: 1}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, s.ShouldSample(SamplingParams{Service: "Other", Operation: "/hello"}))
+	assert.True(t, s.ShouldSample(SamplingParams{Service: "Server", Operation: "/hello"}))
+	assert.True(t, s.ShouldSample(SamplingParams{Service: "Other", Operation: "/debug"}))
+	// parent decision wins
+	assert.False(t, s.ShouldSample(SamplingParams{Service: "Server", HasParent: true, ParentSampled: false}))
+	assert.True(t, s.ShouldSample(SamplingParams{Service: "Other", HasParent: true, ParentSampled: true}))
+
+	_, err = BuildSampler(config.SamplerSettings{Default: config.SamplerConfig{Type: "unknown"}})
+	assert.Error(t, err)
+	_, err = BuildSampler(config.SamplerSettings{
+		Default:    config.SamplerConfig{Type: SamplerConst},
+		Operations: map[string]config.SamplerConfig{"/debug": {Type: SamplerProbabilistic, Param: 3}},
+	})
+	assert.Error(t, err)
+}
+
+func TestParseOperationName(t *testing.T) {
+	s, o := parseOperationName("[Server]:[/sayhello/{userid}]")
+	assert.Equal(t, "Server", s)
+	assert.Equal(t, "/sayhello/{userid}", o)
+	s, o = parseOperationName("custom")
+	assert.Equal(t, "", s)
+	assert.Equal(t, "custom", o)
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	zipkin "github.com/openzipkin/zipkin-go-opentracing"
+)
+
+// samplingTracer applies sampler to zipkin spans,
+// zipkin sampler only knows trace id, so decision is made after span starts
+type samplingTracer struct {
+	opentracing.Tracer
+}
+
+// StartSpan starts span and sets its sampling priority
+func (t *samplingTracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	sso := opentracing.StartSpanOptions{}
+	for _, o := range opts {
+		o.Apply(&sso)
+	}
+	p := SamplingParams{}
+	p.Service, p.Operation = parseOperationName(operationName)
+	for _, ref := range sso.References {
+		if sc, ok := ref.ReferencedContext.(zipkin.SpanContext); ok {
+			p.HasParent = true
+			p.ParentSampled = sc.Sampled
+			break
+		}
+	}
+	span := t.Tracer.StartSpan(operationName, opts...)
+	if sc, ok := span.Context().(zipkin.SpanContext); ok {
+		p.TraceID = sc.TraceID.Low
+	}
+	var priority uint16
+	if ShouldSample(p) {
+		priority = 1
+	}
+	ext.SamplingPriority.Set(span, priority)
+	return span
+}
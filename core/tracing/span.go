@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/opentracing/opentracing-go"
+)
+
+// constant for span event names recorded by handlers
+const (
+	EventLBPick     = "lb.pick"
+	EventLBRetry    = "lb.retry"
+	EventFallback   = "fallback"
+	EventFaultDelay = "fault.delay"
+	EventFaultAbort = "fault.abort"
+	EventConnect    = "transport.connect"
+	EventTransport  = "transport.request"
+)
+
+// constant for span tag keys set by handlers
+const (
+	TagLBStrategy   = "lb.strategy"
+	TagLBInstance   = "lb.instance"
+	TagCircuitState = "circuit.state"
+)
+
+const eventKey = "event"
+
+// SpanFromContext returns the active span in ctx, it returns nil if there is no active span
+func SpanFromContext(ctx context.Context) opentracing.Span {
+	if ctx == nil {
+		return nil
+	}
+	return opentracing.SpanFromContext(ctx)
+}
+
+// AddEvent records event with key value pairs to the active span in ctx,
+// it does nothing if tracing is not enabled for the call,
+// handlers use it to explain what happened inside a call, for example:
+//
+//	tracing.AddEvent(i.Ctx, "cache.miss", "key", key)
+func AddEvent(ctx context.Context, event string, keyValues ...interface{}) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	span.LogKV(append([]interface{}{eventKey, event}, keyValues...)...)
+}
+
+// SetTag sets tag to the active span in ctx, it does nothing if there is no active span
+func SetTag(ctx context.Context, key string, value interface{}) {
+	if span := SpanFromContext(ctx); span != nil {
+		span.SetTag(key, value)
+	}
+}
+
+// StartChildSpan starts a child span of the active span in ctx and returns context holding it,
+// if there is no active span, a noop span and the original ctx are returned,
+// caller must finish the returned span
+func StartChildSpan(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return opentracing.NoopTracer{}.StartSpan(operationName), ctx
+	}
+	span := parent.Tracer().StartSpan(operationName, opentracing.ChildOf(parent.Context()))
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+// TraceID returns hex trace id of the active span in ctx,
+// it returns empty string if there is no active span or the trace is not sampled.
+// span context is injected into a text map, so that it works with both zipkin and OpenTelemetry tracer
+func TraceID(ctx context.Context) string {
+	traceID, _ := spanIDs(ctx)
+	return traceID
+}
+
+// SpanID returns hex span id of the active span in ctx, it is empty if TraceID is empty
+func SpanID(ctx context.Context) string {
+	_, spanID := spanIDs(ctx)
+	return spanID
+}
+
+func spanIDs(ctx context.Context) (traceID, spanID string) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return "", ""
+	}
+	carrier := opentracing.TextMapCarrier{}
+	if err := span.Tracer().Inject(span.Context(), opentracing.TextMap, carrier); err != nil {
+		return "", ""
+	}
+	sampled := false
+	for k, v := range carrier {
+		switch strings.ToLower(k) {
+		case w3cTraceParent:
+			// version-traceid-spanid-flags
+			if parts := strings.Split(v, "-"); len(parts) == 4 {
+				flags, err := strconv.ParseUint(parts[3], 16, 8)
+				return idsIfSampled(parts[1], parts[2], err == nil && flags&1 == 1)
+			}
+		case zipkinTraceID:
+			traceID = v
+		case zipkinSpanID:
+			spanID = v
+		case zipkinSampled:
+			sampled = v == "1" || v == "true"
+		}
+	}
+	return idsIfSampled(traceID, spanID, sampled)
+}
+
+func idsIfSampled(traceID, spanID string, sampled bool) (string, string) {
+	if !sampled {
+		return "", ""
+	}
+	return traceID, spanID
+}
+
+// logFields attaches trace id and span id to logs of lager.FromContext
+func logFields(ctx context.Context) lager.Fields {
+	traceID, spanID := spanIDs(ctx)
+	if traceID == "" {
+		return nil
+	}
+	return lager.Fields{lager.FieldTraceID: traceID, lager.FieldSpanID: spanID}
+}
+
+func init() {
+	lager.RegisterContextFields(logFields)
+}
@@ -0,0 +1,75 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/tracing"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	zipkin "github.com/openzipkin/zipkin-go-opentracing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEvent(t *testing.T) {
+	// no active span
+	tracing.AddEvent(nil, tracing.EventLBPick)
+	tracing.AddEvent(context.Background(), tracing.EventLBPick, "instance", "1")
+	tracing.SetTag(context.Background(), tracing.TagLBInstance, "1")
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("call")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+	tracing.AddEvent(ctx, tracing.EventLBPick, "instance", "1")
+	tracing.SetTag(ctx, tracing.TagLBInstance, "1")
+	span.Finish()
+
+	s := tracer.FinishedSpans()[0]
+	assert.Equal(t, "1", s.Tag(tracing.TagLBInstance))
+	assert.Len(t, s.Logs(), 1)
+	assert.Equal(t, "event", s.Logs()[0].Fields[0].Key)
+	assert.Equal(t, tracing.EventLBPick, s.Logs()[0].Fields[0].ValueString)
+	assert.Equal(t, "instance", s.Logs()[0].Fields[1].Key)
+}
+
+func TestStartChildSpan(t *testing.T) {
+	span, ctx := tracing.StartChildSpan(context.Background(), "child")
+	assert.NotNil(t, span)
+	assert.Nil(t, tracing.SpanFromContext(ctx))
+	span.Finish()
+
+	tracer := mocktracer.New()
+	parent := tracer.StartSpan("parent")
+	span, ctx = tracing.StartChildSpan(opentracing.ContextWithSpan(context.Background(), parent), "child")
+	assert.Equal(t, span, tracing.SpanFromContext(ctx))
+	span.Finish()
+	parent.Finish()
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentID)
+}
+
+func TestTraceID(t *testing.T) {
+	assert.Empty(t, tracing.TraceID(context.Background()))
+
+	tracer, err := zipkin.NewTracer(zipkin.NewInMemoryRecorder())
+	assert.NoError(t, err)
+	span := tracer.StartSpan("call")
+	traceID := span.Context().(zipkin.SpanContext).TraceID.ToHex()
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+	assert.Equal(t, traceID, tracing.TraceID(ctx))
+	assert.NotEmpty(t, tracing.SpanID(ctx))
+
+	fields := lager.FieldsFromContext(ctx)
+	assert.Equal(t, traceID, fields[lager.FieldTraceID])
+	assert.Equal(t, tracing.SpanID(ctx), fields[lager.FieldSpanID])
+
+	tracer, err = zipkin.NewTracer(zipkin.NewInMemoryRecorder(), zipkin.WithSampler(func(uint64) bool { return false }))
+	assert.NoError(t, err)
+	span = tracer.StartSpan("call")
+	ctx = opentracing.ContextWithSpan(context.Background(), span)
+	assert.Empty(t, tracing.TraceID(ctx))
+	assert.Empty(t, tracing.SpanID(ctx))
+	assert.NotContains(t, lager.FieldsFromContext(ctx), lager.FieldTraceID)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chassis/go-chassis/core/config/schema"
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/pkg/runtime"
+	"github.com/go-chassis/go-chassis/pkg/shutdown"
 	"github.com/opentracing/opentracing-go"
 	zipkin "github.com/openzipkin/zipkin-go-opentracing"
 )
@@ -32,26 +33,29 @@ func init() {
 // Init initialize the tracer
 func Init() error {
 	lager.Logger.Info("Tracing enabled. Start to init tracer map.", nil)
-	collector, err := NewCollector(config.GlobalDefinition.Tracing.CollectorType, config.GlobalDefinition.Tracing.CollectorTarget)
+	if err := RefreshSampler(); err != nil {
+		return fmt.Errorf("unable to create tracing sampler: %+v", err)
+	}
+	if IsOTelCollector(config.GlobalDefinition.Tracing.CollectorType) {
+		return initOTel()
+	}
+	c, err := NewCollector(config.GlobalDefinition.Tracing.CollectorType, config.GlobalDefinition.Tracing.CollectorTarget)
 	if err != nil {
 		lager.Logger.Error(err.Error(), nil)
 		return fmt.Errorf("unable to create tracing collector: %+v", err)
 	}
+	// spans are exported in background, so that tracing adds no I/O to requests
+	collector := NewBatchCollector(c, config.GetTracingBatchConfig())
+	shutdown.RegisterFlusher("tracing", collector.Shutdown)
 
-	microserviceNames := schema.GetMicroserviceNames()
 	// key: caller name, val: recorder
-	recorderMap := make(map[string]zipkin.SpanRecorder, len(microserviceNames)+1)
-
-	// set default recorder
-	defaultCaller := common.DefaultKey
-	defaultRecorder := zipkin.NewRecorder(collector, false, "0.0.0.0:0", runtime.HostName)
-	recorderMap[defaultCaller] = defaultRecorder
-
-	// set recorder map
-	for _, msName := range microserviceNames {
-		caller := msName + ":" + runtime.HostName
-		r := zipkin.NewRecorder(collector, false, "0.0.0.0:0", caller)
-		recorderMap[caller] = r
+	recorderMap := make(map[string]zipkin.SpanRecorder)
+	for _, caller := range callers() {
+		serviceName := caller
+		if caller == common.DefaultKey {
+			serviceName = runtime.HostName
+		}
+		recorderMap[caller] = zipkin.NewRecorder(collector, false, "0.0.0.0:0", serviceName)
 	}
 
 	// set tracer map
@@ -66,8 +70,41 @@ func Init() error {
 			lager.Logger.Error(err.Error(), nil)
 			return fmt.Errorf("unable to create global tracer: %+v", err)
 		}
-		TracerMap[caller] = tracer
+		TracerMap[caller] = &samplingTracer{Tracer: tracer}
 	}
 
 	return nil
 }
+
+// initOTel sets OpenTelemetry tracers bridged to opentracing API
+func initOTel() error {
+	t := config.GlobalDefinition.Tracing
+	propagator, err := NewPropagator(t.Propagation)
+	if err != nil {
+		lager.Logger.Error(err.Error(), nil)
+		return fmt.Errorf("unable to create tracing propagator: %+v", err)
+	}
+	exporter, err := NewExporter(t.CollectorType, t.CollectorTarget)
+	if err != nil {
+		lager.Logger.Error(err.Error(), nil)
+		return fmt.Errorf("unable to create tracing exporter: %+v", err)
+	}
+	o, tracers := newOTelTracers(exporter, propagator, config.GetTracingBatchConfig(), callers())
+	shutdown.RegisterFlusher("tracing", o.Close)
+	for caller, tracer := range tracers {
+		TracerMap[caller] = tracer
+	}
+	lager.Logger.Infof("Use OpenTelemetry exporter [%s]", t.CollectorType)
+	return nil
+}
+
+// callers returns default caller and callers of each micro service
+func callers() []string {
+	microserviceNames := schema.GetMicroserviceNames()
+	cs := make([]string, 0, len(microserviceNames)+1)
+	cs = append(cs, common.DefaultKey)
+	for _, msName := range microserviceNames {
+		cs = append(cs, msName+":"+runtime.HostName)
+	}
+	return cs
+}
@@ -0,0 +1,229 @@
+package validator
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-chassis/go-chassis/core/config/schema"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"gopkg.in/yaml.v2"
+)
+
+// Operation is an operation of swagger contract, it validates requests and responses of the operation
+type Operation struct {
+	// ID is operationId, or method and path if operationId is empty
+	ID     string
+	Method string
+	// Path is path template including basePath, like /users/{id}
+	Path string
+
+	segments    []string
+	literals    int
+	params      []*parameter
+	body        *parameter
+	responses   map[string]node
+	definitions map[string]node
+}
+
+// parameter is a swagger parameter with compiled pattern and body schema
+type parameter struct {
+	registry.Parameter
+	pattern *regexp.Regexp
+	// schema is schema of body parameter
+	schema node
+}
+
+var (
+	operations = make(map[string][]*Operation)
+	loaded     = make(map[string]bool)
+	mu         sync.RWMutex
+)
+
+// Register adds operations of contract to service, contracts of service in schema files are still loaded
+func Register(service string, content *registry.SchemaContent) error {
+	ops, err := compile(content)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	add(service, ops)
+	return nil
+}
+
+// add must be called with mu locked
+func add(service string, ops []*Operation) {
+	all := append(operations[service], ops...)
+	// literal segments win over path parameters, so /users/me is matched before /users/{id}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].literals > all[j].literals
+	})
+	operations[service] = all
+}
+
+// Operations returns operations of service, schema files of service under conf are loaded at first call
+func Operations(service string) []*Operation {
+	mu.RLock()
+	ops, ok := operations[service], loaded[service]
+	mu.RUnlock()
+	if ok {
+		return ops
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !loaded[service] {
+		loaded[service] = true
+		add(service, loadSchemaFiles(service))
+	}
+	return operations[service]
+}
+
+// loadSchemaFiles compiles contracts loaded by schema.LoadSchema from conf/{service}/schema
+func loadSchemaFiles(service string) []*Operation {
+	ids, err := schema.GetSchemaIDs(service)
+	if err != nil {
+		return nil
+	}
+	var ops []*Operation
+	for _, id := range ids {
+		content := &registry.SchemaContent{}
+		if err := yaml.Unmarshal([]byte(schema.DefaultSchemaIDsMap[id]), content); err != nil {
+			lager.Logger.Errorf(err, "unmarshal schema [%s] of [%s] failed", id, service)
+			continue
+		}
+		compiled, err := compile(content)
+		if err != nil {
+			lager.Logger.Errorf(err, "compile schema [%s] of [%s] failed", id, service)
+			continue
+		}
+		ops = append(ops, compiled...)
+	}
+	return ops
+}
+
+// Lookup returns operation of service matching method and path, and values of path parameters
+func Lookup(service, method, p string) (*Operation, map[string]string, bool) {
+	segments := split(p)
+	for _, op := range Operations(service) {
+		if !strings.EqualFold(op.Method, method) {
+			continue
+		}
+		if values, ok := op.match(segments); ok {
+			return op, values, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Validate validates r against contract of service and returns operation of r,
+// r matching no operation is rejected if strict is true, otherwise it is not checked
+func Validate(service string, r *Request, strict bool) (*Operation, error) {
+	op, values, ok := Lookup(service, r.Method, r.Path)
+	if !ok {
+		if strict {
+			return nil, newError(r.Method+" "+r.Path, []FieldError{{In: "path", Message: "matches no operation of contract"}})
+		}
+		return nil, nil
+	}
+	return op, op.ValidateRequest(r, values)
+}
+
+func (op *Operation) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(op.segments) {
+		return nil, false
+	}
+	values := make(map[string]string)
+	for i, s := range op.segments {
+		if name, ok := pathParam(s); ok {
+			v, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return nil, false
+			}
+			values[name] = v
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func split(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// compile builds operations of contract
+func compile(content *registry.SchemaContent) ([]*Operation, error) {
+	definitions := make(map[string]node, len(content.Definition))
+	for name, d := range content.Definition {
+		definitions[name] = definitionNode(d)
+	}
+	var ops []*Operation
+	for p, methods := range content.Paths {
+		full := path.Join("/", content.BasePath, p)
+		for method, info := range methods {
+			op := &Operation{
+				ID:          info.OperationID,
+				Method:      strings.ToUpper(method),
+				Path:        full,
+				segments:    split(full),
+				responses:   make(map[string]node, len(info.Response)),
+				definitions: definitions,
+			}
+			if op.ID == "" {
+				op.ID = op.Method + " " + full
+			}
+			for _, s := range op.segments {
+				if _, ok := pathParam(s); !ok {
+					op.literals++
+				}
+			}
+			for _, param := range info.Parameters {
+				compiled := &parameter{Parameter: param}
+				if param.Pattern != "" {
+					r, err := compilePattern(param.Pattern)
+					if err != nil {
+						return nil, fmt.Errorf("invalid pattern of parameter [%s] in [%s], %v", param.Name, op.ID, err)
+					}
+					compiled.pattern = r
+				}
+				if param.In == "body" {
+					compiled.schema = schemaNode(param.Schema)
+					op.body = compiled
+					continue
+				}
+				op.params = append(op.params, compiled)
+			}
+			for status, r := range info.Response {
+				if len(r.Schema) != 0 {
+					n := make(node, len(r.Schema))
+					for k, v := range r.Schema {
+						n[k] = v
+					}
+					op.responses[status] = n
+				}
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
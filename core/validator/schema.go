@@ -0,0 +1,298 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-chassis/go-chassis/core/registry"
+)
+
+// maxDepth limits nesting of body, it stops recursive definitions
+const maxDepth = 32
+
+const definitionRefPrefix = "#/definitions/"
+
+// node is a json schema object of swagger, keys are type, properties, items, $ref and constraints
+type node map[string]interface{}
+
+// definitionNode converts definition to node
+func definitionNode(d registry.Definition) node {
+	n := node{"type": d.Types}
+	if d.Types == "" && len(d.Properties) != 0 {
+		n["type"] = "object"
+	}
+	if len(d.Properties) != 0 {
+		n["properties"] = normalize(d.Properties)
+	}
+	if len(d.Required) != 0 {
+		required := make([]interface{}, len(d.Required))
+		for i, r := range d.Required {
+			required[i] = r
+		}
+		n["required"] = required
+	}
+	return n
+}
+
+// schemaNode converts schema of body parameter to node
+func schemaNode(s registry.SchemaValue) node {
+	n := node{}
+	if s.Reference != "" {
+		n["$ref"] = s.Reference
+		return n
+	}
+	if s.Type != "" {
+		n["type"] = s.Type
+	}
+	if len(s.Properties) != 0 {
+		n["properties"] = normalize(s.Properties)
+	}
+	if s.Items.Type != "" {
+		n["items"] = map[string]interface{}{"type": s.Items.Type}
+	}
+	if len(s.Enum) != 0 {
+		n["enum"] = s.Enum
+	}
+	if s.Pattern != "" {
+		n["pattern"] = s.Pattern
+	}
+	if s.MaxLength != 0 {
+		n["maxLength"] = s.MaxLength
+	}
+	if s.MinLength != 0 {
+		n["minLength"] = s.MinLength
+	}
+	return n
+}
+
+// normalize converts maps decoded by yaml to map[string]interface{}
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, e := range value {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, e := range value {
+			m[k] = normalize(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(value))
+		for i, e := range value {
+			l[i] = normalize(e)
+		}
+		return l
+	}
+	return v
+}
+
+func asNode(v interface{}) node {
+	switch m := v.(type) {
+	case node:
+		return m
+	case map[string]interface{}:
+		return node(m)
+	}
+	return nil
+}
+
+// resolve follows $ref of n to definitions
+func (op *Operation) resolve(n node) node {
+	for i := 0; i < maxDepth; i++ {
+		ref, ok := n["$ref"].(string)
+		if !ok {
+			return n
+		}
+		d, ok := op.definitions[strings.TrimPrefix(ref, definitionRefPrefix)]
+		if !ok {
+			// unknown definition is not validated
+			return nil
+		}
+		n = d
+	}
+	return nil
+}
+
+// validateValue validates json value v against n, errors are appended with name of field
+func (op *Operation) validateValue(n node, v interface{}, in, name string, depth int, errs *[]FieldError) {
+	if depth > maxDepth {
+		return
+	}
+	if n = op.resolve(n); n == nil {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{In: in, Name: name, Message: fmt.Sprintf(format, args...)})
+	}
+	t, _ := n["type"].(string)
+	if t != "" && !isType(t, v) {
+		fail("must be %s", t)
+		return
+	}
+	if enum, ok := n["enum"].([]interface{}); ok && len(enum) != 0 && !inEnum(enum, v) {
+		fail("must be one of %v", enum)
+		return
+	}
+	switch value := v.(type) {
+	case string:
+		checkString(n, value, fail)
+	case json.Number:
+		f, _ := value.Float64()
+		checkNumber(n, f, fail)
+	case []interface{}:
+		if min, ok := toInt(n["minItems"]); ok && len(value) < min {
+			fail("must have at least %d items", min)
+		}
+		if max, ok := toInt(n["maxItems"]); ok && len(value) > max {
+			fail("must have at most %d items", max)
+		}
+		if items := asNode(n["items"]); items != nil {
+			for i, e := range value {
+				op.validateValue(items, e, in, fmt.Sprintf("%s[%d]", name, i), depth+1, errs)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := n["required"].([]interface{}); ok {
+			for _, r := range required {
+				key := fmt.Sprint(r)
+				if _, ok := value[key]; !ok {
+					*errs = append(*errs, FieldError{In: in, Name: join(name, key), Message: "is required"})
+				}
+			}
+		}
+		properties, _ := n["properties"].(map[string]interface{})
+		for key, p := range properties {
+			e, ok := value[key]
+			if !ok {
+				continue
+			}
+			if pn := asNode(p); pn != nil {
+				op.validateValue(pn, e, in, join(name, key), depth+1, errs)
+			}
+		}
+	}
+}
+
+func join(name, key string) string {
+	if name == "" {
+		return key
+	}
+	return name + "." + key
+}
+
+// isType reports whether json value v is swagger type t, unknown types are not checked
+func isType(t string, v interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return true
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	s := fmt.Sprint(v)
+	for _, e := range enum {
+		if fmt.Sprint(e) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func checkString(n node, s string, fail func(string, ...interface{})) {
+	length := utf8.RuneCountInString(s)
+	if min, ok := toInt(n["minLength"]); ok && length < min {
+		fail("length must be >= %d", min)
+	}
+	if max, ok := toInt(n["maxLength"]); ok && length > max {
+		fail("length must be <= %d", max)
+	}
+	if p, ok := n["pattern"].(string); ok && p != "" {
+		// invalid patterns of body are not checked
+		if r, err := compilePattern(p); err == nil && !r.MatchString(s) {
+			fail("must match pattern %s", p)
+		}
+	}
+}
+
+// patterns caches compiled patterns of body schemas
+var patterns sync.Map
+
+func compilePattern(p string) (*regexp.Regexp, error) {
+	if r, ok := patterns.Load(p); ok {
+		return r.(*regexp.Regexp), nil
+	}
+	r, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(p, r)
+	return r, nil
+}
+
+func checkNumber(n node, f float64, fail func(string, ...interface{})) {
+	if min, ok := toFloat(n["minimum"]); ok && f < min {
+		fail("must be >= %v", min)
+	}
+	if max, ok := toFloat(n["maximum"]); ok && f > max {
+		fail("must be <= %v", max)
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toInt(v interface{}) (int, bool) {
+	f, ok := toFloat(v)
+	return int(f), ok
+}
@@ -0,0 +1,248 @@
+// Package validator validates rest requests and responses against swagger contracts of micro services
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Request is part of a rest request checked by contract
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// FieldError is a parameter or body field which violates contract
+type FieldError struct {
+	// In is path, query, header, formData or body
+	In      string `json:"in"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// Error is returned when request or response violates contract, provider responds it as json with status 400
+type Error struct {
+	Operation string       `json:"operation,omitempty"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// Error returns all field errors in one line
+func (e *Error) Error() string {
+	s := make([]string, 0, len(e.Errors))
+	for _, f := range e.Errors {
+		if f.Name == "" {
+			s = append(s, f.In+" "+f.Message)
+			continue
+		}
+		s = append(s, fmt.Sprintf("%s parameter [%s] %s", f.In, f.Name, f.Message))
+	}
+	return fmt.Sprintf("[%s] violates contract: %s", e.Operation, strings.Join(s, "; "))
+}
+
+// MarshalJSON adds message to field errors
+func (e *Error) MarshalJSON() ([]byte, error) {
+	type plain Error
+	return json.Marshal(struct {
+		Message string `json:"message"`
+		*plain
+	}{Message: "violates contract", plain: (*plain)(e)})
+}
+
+func newError(operation string, errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &Error{Operation: operation, Errors: errs}
+}
+
+// ValidateRequest validates parameters and body of r, pathParams are values of path parameters returned by Lookup
+func (op *Operation) ValidateRequest(r *Request, pathParams map[string]string) error {
+	var errs []FieldError
+	var form url.Values
+	for _, p := range op.params {
+		var values []string
+		switch p.In {
+		case "path":
+			if v, ok := pathParams[p.Name]; ok {
+				values = []string{v}
+			}
+		case "query":
+			values = r.Query[p.Name]
+		case "header":
+			values = r.Header[http.CanonicalHeaderKey(p.Name)]
+		case "formData":
+			if form == nil {
+				form = parseForm(r)
+			}
+			values = form[p.Name]
+		default:
+			continue
+		}
+		errs = append(errs, p.validate(values)...)
+	}
+	if op.body != nil {
+		errs = append(errs, op.validateBody(op.body, r)...)
+	}
+	return newError(op.ID, errs)
+}
+
+// ValidateResponse validates json body of response with status, status without schema in contract is not checked
+func (op *Operation) ValidateResponse(status int, contentType string, body []byte) error {
+	n, ok := op.responses[strconv.Itoa(status)]
+	if !ok {
+		if n, ok = op.responses["default"]; !ok {
+			return nil
+		}
+	}
+	if !isJSON(contentType) {
+		return nil
+	}
+	v, err := decode(body)
+	if err != nil {
+		return newError(op.ID, []FieldError{{In: "body", Message: "must be json, " + err.Error()}})
+	}
+	var errs []FieldError
+	op.validateValue(n, v, "body", "", 0, &errs)
+	return newError(op.ID, errs)
+}
+
+func (op *Operation) validateBody(p *parameter, r *Request) []FieldError {
+	if len(bytes.TrimSpace(r.Body)) == 0 {
+		if p.Required {
+			return []FieldError{{In: "body", Name: p.Name, Message: "is required"}}
+		}
+		return nil
+	}
+	// only json body is checked
+	if !isJSON(r.Header.Get("Content-Type")) {
+		return nil
+	}
+	v, err := decode(r.Body)
+	if err != nil {
+		return []FieldError{{In: "body", Name: p.Name, Message: "must be json, " + err.Error()}}
+	}
+	var errs []FieldError
+	op.validateValue(p.schema, v, "body", p.Name, 0, &errs)
+	return errs
+}
+
+func decode(body []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// isJSON reports whether body of content type is json, empty content type is treated as json
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	t, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (t == "application/json" || strings.HasSuffix(t, "+json"))
+}
+
+func parseForm(r *Request) url.Values {
+	t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if t != "application/x-www-form-urlencoded" {
+		return url.Values{}
+	}
+	form, err := url.ParseQuery(string(r.Body))
+	if err != nil {
+		return url.Values{}
+	}
+	return form
+}
+
+// validate validates values of parameter, values of array are split by collection format
+func (p *parameter) validate(values []string) []FieldError {
+	if len(values) == 0 {
+		if p.Required {
+			return []FieldError{{In: p.In, Name: p.Name, Message: "is required"}}
+		}
+		return nil
+	}
+	if p.Type != "array" {
+		if msg := p.check(p.Type, values[0]); msg != "" {
+			return []FieldError{{In: p.In, Name: p.Name, Message: msg}}
+		}
+		return nil
+	}
+	var errs []FieldError
+	for i, v := range p.split(values) {
+		if msg := p.check(p.Items.Type, v); msg != "" {
+			errs = append(errs, FieldError{In: p.In, Name: fmt.Sprintf("%s[%d]", p.Name, i), Message: msg})
+		}
+	}
+	return errs
+}
+
+func (p *parameter) split(values []string) []string {
+	sep := ","
+	switch p.ColFormat {
+	case "multi":
+		return values
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+	return strings.Split(values[0], sep)
+}
+
+// check returns reason if v is not a valid value of type t, constraints of p are applied to v
+func (p *parameter) check(t, v string) string {
+	switch t {
+	case "integer":
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return "must be integer"
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "must be number"
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(v); err != nil {
+			return "must be boolean"
+		}
+	}
+	if len(p.Enum) != 0 && !inEnum(p.Enum, v) {
+		return fmt.Sprintf("must be one of %v", p.Enum)
+	}
+	if t == "integer" || t == "number" {
+		f, _ := strconv.ParseFloat(v, 64)
+		if p.Minimum != nil && f < *p.Minimum {
+			return fmt.Sprintf("must be >= %v", *p.Minimum)
+		}
+		if p.Maximum != nil && f > *p.Maximum {
+			return fmt.Sprintf("must be <= %v", *p.Maximum)
+		}
+		return ""
+	}
+	length := utf8.RuneCountInString(v)
+	if p.MinLength != nil && length < *p.MinLength {
+		return fmt.Sprintf("length must be >= %d", *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		return fmt.Sprintf("length must be <= %d", *p.MaxLength)
+	}
+	if p.pattern != nil && !p.pattern.MatchString(v) {
+		return fmt.Sprintf("must match pattern %s", p.Pattern)
+	}
+	return ""
+}
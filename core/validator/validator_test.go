@@ -0,0 +1,204 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/core/validator"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const contract = `
+swagger: "2.0"
+basePath: /v1
+paths:
+  /users/{id}:
+    get:
+      operationId: GetUser
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: integer
+        minimum: 1
+      - name: fields
+        in: query
+        type: array
+        items:
+          type: string
+      - name: X-Tenant
+        in: header
+        required: true
+        type: string
+        pattern: "^[a-z]+$"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/User"
+  /users/me:
+    get:
+      operationId: GetMe
+      responses:
+        200:
+          description: ok
+  /users:
+    post:
+      operationId: CreateUser
+      parameters:
+      - name: user
+        in: body
+        required: true
+        schema:
+          $ref: "#/definitions/User"
+      - name: mode
+        in: query
+        type: string
+        enum: [fast, safe]
+      responses:
+        200:
+          description: ok
+definitions:
+  User:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+        minLength: 2
+      age:
+        type: integer
+        minimum: 0
+        maximum: 150
+      tags:
+        type: array
+        items:
+          type: string
+      address:
+        $ref: "#/definitions/Address"
+  Address:
+    type: object
+    required: [city]
+    properties:
+      city:
+        type: string
+`
+
+func register(t *testing.T, service string) {
+	content := &registry.SchemaContent{}
+	assert.NoError(t, yaml.Unmarshal([]byte(contract), content))
+	assert.NoError(t, validator.Register(service, content))
+}
+
+func fieldErrors(err error) []validator.FieldError {
+	if e, ok := err.(*validator.Error); ok {
+		return e.Errors
+	}
+	return nil
+}
+
+func TestLookup(t *testing.T) {
+	register(t, "lookup")
+	op, params, ok := validator.Lookup("lookup", http.MethodGet, "/v1/users/me")
+	assert.True(t, ok)
+	assert.Equal(t, "GetMe", op.ID)
+	assert.Empty(t, params)
+
+	op, params, ok = validator.Lookup("lookup", http.MethodGet, "/v1/users/12/")
+	assert.True(t, ok)
+	assert.Equal(t, "GetUser", op.ID)
+	assert.Equal(t, "12", params["id"])
+
+	_, _, ok = validator.Lookup("lookup", http.MethodDelete, "/v1/users/12")
+	assert.False(t, ok)
+	_, _, ok = validator.Lookup("unknown", http.MethodGet, "/v1/users/12")
+	assert.False(t, ok)
+
+	_, err := validator.Validate("lookup", &validator.Request{Method: http.MethodGet, Path: "/v2/users"}, false)
+	assert.NoError(t, err)
+	_, err = validator.Validate("lookup", &validator.Request{Method: http.MethodGet, Path: "/v2/users"}, true)
+	assert.Error(t, err)
+}
+
+func TestValidateParameters(t *testing.T) {
+	register(t, "params")
+	get := func(path, query string, header http.Header) error {
+		q, _ := url.ParseQuery(query)
+		_, err := validator.Validate("params", &validator.Request{
+			Method: http.MethodGet,
+			Path:   path,
+			Query:  q,
+			Header: header,
+		}, false)
+		return err
+	}
+	tenant := http.Header{"X-Tenant": []string{"acme"}}
+	assert.NoError(t, get("/v1/users/1", "fields=a,b", tenant))
+
+	errs := fieldErrors(get("/v1/users/abc", "", http.Header{}))
+	assert.Equal(t, []validator.FieldError{
+		{In: "path", Name: "id", Message: "must be integer"},
+		{In: "header", Name: "X-Tenant", Message: "is required"},
+	}, errs)
+
+	errs = fieldErrors(get("/v1/users/0", "", http.Header{"X-Tenant": []string{"ACME"}}))
+	assert.Equal(t, []validator.FieldError{
+		{In: "path", Name: "id", Message: "must be >= 1"},
+		{In: "header", Name: "X-Tenant", Message: "must match pattern ^[a-z]+$"},
+	}, errs)
+}
+
+func TestValidateBody(t *testing.T) {
+	register(t, "body")
+	post := func(query, contentType, body string) error {
+		q, _ := url.ParseQuery(query)
+		_, err := validator.Validate("body", &validator.Request{
+			Method: http.MethodPost,
+			Path:   "/v1/users",
+			Query:  q,
+			Header: http.Header{"Content-Type": []string{contentType}},
+			Body:   []byte(body),
+		}, false)
+		return err
+	}
+	assert.NoError(t, post("mode=fast", "application/json", `{"name":"tom","age":20,"tags":["a"],"address":{"city":"x"}}`))
+	// body which is not json is not checked
+	assert.NoError(t, post("", "application/xml", `<user/>`))
+
+	errs := fieldErrors(post("mode=slow", "application/json", ""))
+	assert.Equal(t, []validator.FieldError{
+		{In: "query", Name: "mode", Message: "must be one of [fast safe]"},
+		{In: "body", Name: "user", Message: "is required"},
+	}, errs)
+
+	errs = fieldErrors(post("", "application/json", `{"name":"t","age":1.5,"tags":[1],"address":{}}`))
+	assert.Equal(t, 4, len(errs))
+	assert.Contains(t, errs, validator.FieldError{In: "body", Name: "user.name", Message: "length must be >= 2"})
+	assert.Contains(t, errs, validator.FieldError{In: "body", Name: "user.age", Message: "must be integer"})
+	assert.Contains(t, errs, validator.FieldError{In: "body", Name: "user.tags[0]", Message: "must be string"})
+	assert.Contains(t, errs, validator.FieldError{In: "body", Name: "user.address.city", Message: "is required"})
+
+	errs = fieldErrors(post("", "", `[]`))
+	assert.Equal(t, []validator.FieldError{{In: "body", Name: "user", Message: "must be object"}}, errs)
+	assert.Equal(t, 1, len(fieldErrors(post("", "", `{"name":`))))
+
+	err := post("", "", `{"age":200}`)
+	b, e := json.Marshal(err)
+	assert.NoError(t, e)
+	assert.JSONEq(t, `{"message":"violates contract","operation":"CreateUser","errors":[
+		{"in":"body","name":"user.name","message":"is required"},
+		{"in":"body","name":"user.age","message":"must be <= 150"}]}`, string(b))
+}
+
+func TestValidateResponse(t *testing.T) {
+	register(t, "response")
+	op, _, ok := validator.Lookup("response", http.MethodGet, "/v1/users/1")
+	assert.True(t, ok)
+	assert.NoError(t, op.ValidateResponse(http.StatusOK, "application/json", []byte(`{"name":"tom"}`)))
+	assert.Error(t, op.ValidateResponse(http.StatusOK, "application/json", []byte(`{"age":1}`)))
+	// status without schema is not checked
+	assert.NoError(t, op.ValidateResponse(http.StatusNotFound, "application/json", []byte(`{"age":1}`)))
+}
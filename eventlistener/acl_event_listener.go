@@ -0,0 +1,21 @@
+package eventlistener
+
+import (
+	"github.com/go-chassis/go-archaius/core"
+	"github.com/go-chassis/go-chassis/auth/acl"
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// ACLKey is variable of type string that matches acl rule events
+const ACLKey = "^cse\\.auth\\.acl\\."
+
+//ACLEventListener reloads acl rules when auth.yaml changes
+type ACLEventListener struct {
+	Key string
+}
+
+//Event is a method used to handle an acl rule event
+func (e *ACLEventListener) Event(event *core.Event) {
+	lager.Logger.Debugf("ACL event, key: %s, type: %s", event.Key, event.EventType)
+	acl.Refresh()
+}
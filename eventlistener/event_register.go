@@ -21,5 +21,9 @@ func Init() {
 	RegisterKeys(circuitBreakerEventListener, ConsumerFallbackKey, ConsumerFallbackPolicyKey, ConsumerIsolationKey, ConsumerCircuitbreakerKey)
 	RegisterKeys(lbEventListener, LoadBalanceKey)
 	RegisterKeys(&DarkLaunchEventListener{}, DarkLaunchKey)
+	RegisterKeys(&TracingSamplerEventListener{}, TracingSamplerKey)
+	RegisterKeys(&LoggerLevelEventListener{}, LoggerLevelKey)
+	RegisterKeys(&ACLEventListener{}, ACLKey)
+	ApplyLoggerLevels()
 
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/go-chassis/go-archaius/core"
 	"github.com/go-chassis/go-chassis/control/archaius"
 	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/session"
 )
 
 // constants for loadbalancer strategy name, and timeout
@@ -26,4 +27,7 @@ func (e *LoadbalanceEventListener) Event(event *core.Event) {
 		lager.Logger.Error("can not unmarshal new lb config", err)
 	}
 	archaius.SaveToLBCache(config.GetLoadBalancing())
+	if err := session.RefreshCookieConfig(); err != nil {
+		lager.Logger.Errorf(err, "can not refresh session cookie config")
+	}
 }
@@ -0,0 +1,65 @@
+package eventlistener
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-chassis/go-archaius/core"
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// LoggerLevelKey is variable of type string that matches log level events
+const LoggerLevelKey = "^cse\\.logger\\.level"
+
+//LoggerLevelEventListener changes log level when cse.logger.level or cse.logger.levels.<component> changes
+type LoggerLevelEventListener struct {
+	Key string
+}
+
+//Event is a method used to handle a log level event
+func (e *LoggerLevelEventListener) Event(event *core.Event) {
+	lager.Logger.Debugf("Log level event, key: %s, type: %s", event.Key, event.EventType)
+	var component string
+	switch {
+	case event.Key == config.LoggerLevelKey:
+	case strings.HasPrefix(event.Key, config.LoggerLevelPrefix):
+		component = strings.TrimPrefix(event.Key, config.LoggerLevelPrefix)
+	default:
+		return
+	}
+	switch event.EventType {
+	case common.Create, common.Update:
+		setLoggerLevel(component, fmt.Sprint(event.Value))
+	case common.Delete:
+		if err := lager.ResetLevel(component); err != nil {
+			lager.Logger.Errorf(err, "reset log level of [%s] failed", componentName(component))
+		}
+	}
+}
+
+// ApplyLoggerLevels applies log levels set in config center
+func ApplyLoggerLevels() {
+	if level := config.GetLoggerLevel(); level != "" {
+		setLoggerLevel("", level)
+	}
+	for component, level := range config.GetLoggerComponentLevels() {
+		setLoggerLevel(component, level)
+	}
+}
+
+func setLoggerLevel(component, level string) {
+	if err := lager.SetLevel(component, level, config.GetLoggerRevertAfter()); err != nil {
+		lager.Logger.Errorf(err, "set log level of [%s] to [%s] failed", componentName(component), level)
+		return
+	}
+	lager.Logger.Infof("log level of [%s] is changed to [%s]", componentName(component), level)
+}
+
+func componentName(component string) string {
+	if component == "" {
+		return "global"
+	}
+	return component
+}
@@ -0,0 +1,21 @@
+package eventlistener
+
+import (
+	"github.com/go-chassis/go-archaius/core"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/tracing"
+)
+
+// TracingSamplerKey is variable of type string that matches tracing sampler events
+const TracingSamplerKey = "^tracing\\.(sampler\\.|samplingRate)"
+
+//TracingSamplerEventListener rebuilds tracing sampler when sampler config changes
+type TracingSamplerEventListener struct {
+	Key string
+}
+
+//Event is a method used to handle a tracing sampler event
+func (e *TracingSamplerEventListener) Event(event *core.Event) {
+	lager.Logger.Debugf("Tracing sampler event, key: %s, type: %s", event.Key, event.EventType)
+	tracing.RefreshSampler()
+}
@@ -0,0 +1,290 @@
+// Package checker actively probes discovered provider instances,
+// load balancer skips instances which checker marks unhealthy
+package checker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-archaius/core"
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+)
+
+// constant values for instance health status
+const (
+	StatusUnknown   = "UNKNOWN"
+	StatusHealthy   = "HEALTHY"
+	StatusUnhealthy = "UNHEALTHY"
+)
+
+// healthCheckKey matches health check config, workers are started or stopped when it changes
+const healthCheckKey = "^cse\\.healthCheck\\."
+
+// jitterFactor spreads probes of one interval so that consumers do not probe in lockstep
+const jitterFactor = 0.2
+
+var defaultChecker = newChecker()
+var once sync.Once
+
+// Checker runs one probe loop for each discovered instance of services whose health check is enabled
+type Checker struct {
+	mu sync.RWMutex
+	// instances are all discovered instances, so that workers can be started when health check is enabled at runtime
+	instances map[string]discovered
+	workers   map[string]*worker
+}
+
+type discovered struct {
+	service  string
+	instance *registry.MicroServiceInstance
+}
+
+type worker struct {
+	service string
+	stop    chan struct{}
+
+	mu        sync.RWMutex
+	instance  *registry.MicroServiceInstance
+	status    string
+	successes int
+	failures  int
+}
+
+func newChecker() *Checker {
+	return &Checker{
+		instances: make(map[string]discovered),
+		workers:   make(map[string]*worker),
+	}
+}
+
+// configListener syncs workers with health check config
+type configListener struct{}
+
+// Event starts or stops workers when health check config changes
+func (configListener) Event(e *core.Event) {
+	defaultChecker.sync()
+}
+
+// Init subscribes instance events, it must be called before registry is enabled,
+// so that instances found by first discovery are checked
+func Init() {
+	once.Do(func() {
+		registry.Subscribe(registry.AllServices, defaultChecker.onInstanceEvent)
+		if err := archaius.RegisterListener(configListener{}, healthCheckKey); err != nil {
+			lager.Logger.Errorf(err, "watch health check config failed")
+		}
+		lager.Logger.Info("Enable active health check")
+	})
+}
+
+// Status returns health status of instance
+func Status(instanceID string) string {
+	return defaultChecker.status(instanceID)
+}
+
+// IsHealthy returns false only if instance is checked and marked unhealthy
+func IsHealthy(instanceID string) bool {
+	return defaultChecker.status(instanceID) != StatusUnhealthy
+}
+
+func (c *Checker) onInstanceEvent(e registry.InstanceEvent) {
+	if e.Instance == nil {
+		return
+	}
+	switch e.Action {
+	case registry.EventAdd, registry.EventUpdate:
+		c.update(e.ServiceName, e.Instance)
+	case registry.EventDelete:
+		c.mu.Lock()
+		delete(c.instances, e.Instance.InstanceID)
+		c.mu.Unlock()
+		c.remove(e.Instance.InstanceID)
+	}
+}
+
+// update records instance, a running worker probes the new instance and keeps its status,
+// otherwise worker is started if health check of service is enabled
+func (c *Checker) update(service string, ins *registry.MicroServiceInstance) {
+	c.mu.Lock()
+	c.instances[ins.InstanceID] = discovered{service: service, instance: ins}
+	w, ok := c.workers[ins.InstanceID]
+	c.mu.Unlock()
+	if ok {
+		w.setInstance(ins)
+		return
+	}
+	if config.GetHealthCheck(service).Enabled {
+		c.add(service, ins)
+	}
+}
+
+// sync starts workers of instances whose health check is enabled, and stops the others
+func (c *Checker) sync() {
+	c.mu.RLock()
+	all := make([]discovered, 0, len(c.instances))
+	for _, d := range c.instances {
+		all = append(all, d)
+	}
+	c.mu.RUnlock()
+	for _, d := range all {
+		if config.GetHealthCheck(d.service).Enabled {
+			c.add(d.service, d.instance)
+		} else {
+			c.remove(d.instance.InstanceID)
+		}
+	}
+}
+
+// add starts worker of discovered instance if it is not running
+func (c *Checker) add(service string, ins *registry.MicroServiceInstance) {
+	w := &worker{
+		service:  service,
+		instance: ins,
+		stop:     make(chan struct{}),
+		status:   StatusUnknown,
+	}
+	c.mu.Lock()
+	_, running := c.workers[ins.InstanceID]
+	// instance may be deleted after sync listed it
+	_, ok := c.instances[ins.InstanceID]
+	if running || !ok {
+		c.mu.Unlock()
+		return
+	}
+	c.workers[ins.InstanceID] = w
+	c.mu.Unlock()
+	go w.run()
+}
+
+func (c *Checker) remove(instanceID string) {
+	c.mu.Lock()
+	w, ok := c.workers[instanceID]
+	delete(c.workers, instanceID)
+	c.mu.Unlock()
+	if ok {
+		close(w.stop)
+	}
+}
+
+func (c *Checker) status(instanceID string) string {
+	c.mu.RLock()
+	w, ok := c.workers[instanceID]
+	c.mu.RUnlock()
+	if !ok {
+		return StatusUnknown
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.status
+}
+
+// jitter returns a random duration in [0, d), it never panics on non-positive d
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+// nextInterval returns interval randomly shifted by at most jitterFactor
+func nextInterval(interval time.Duration) time.Duration {
+	spread := time.Duration(float64(interval) * jitterFactor)
+	return interval - spread/2 + jitter(spread)
+}
+
+func (w *worker) setInstance(ins *registry.MicroServiceInstance) {
+	w.mu.Lock()
+	w.instance = ins
+	w.mu.Unlock()
+}
+
+func (w *worker) getInstance() *registry.MicroServiceInstance {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.instance
+}
+
+func (w *worker) run() {
+	// first probe is delayed randomly within one interval
+	timer := time.NewTimer(jitter(config.GetHealthCheck(w.service).Interval))
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-timer.C:
+		}
+		// config is read every round, so that changes take effect at runtime
+		c := config.GetHealthCheck(w.service)
+		if c.Enabled {
+			w.record(c, w.probe(c))
+		} else {
+			w.reset()
+		}
+		timer.Reset(nextInterval(c.Interval))
+	}
+}
+
+func (w *worker) probe(c config.HealthCheckConfig) (err error) {
+	ins := w.getInstance()
+	defer func() {
+		if r := recover(); r != nil {
+			// a panic counts as a failure, otherwise a crashing probe would keep instance healthy
+			err = fmt.Errorf("health check probe panics: %v", r)
+			lager.Logger.Errorf(err, "probe instance [%s] of service [%s] failed", ins.InstanceID, w.service)
+		}
+	}()
+	p, err := GetProbe(c.Probe)
+	if err != nil {
+		lager.Logger.Warnf("%s, skip health check of service [%s]", err, w.service)
+		return nil
+	}
+	protocol := c.Protocol
+	if protocol == "" && c.Probe == ProbeHighway {
+		protocol = ProbeHighway
+	}
+	t, ok := newTarget(w.service, ins, protocol)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return p(ctx, t, c)
+}
+
+func (w *worker) record(c config.HealthCheckConfig, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.failures++
+		w.successes = 0
+		if w.failures >= c.UnhealthyThreshold && w.status != StatusUnhealthy {
+			w.status = StatusUnhealthy
+			lager.Logger.Warnf("Instance [%s] of service [%s] is unhealthy: %s",
+				w.instance.InstanceID, w.service, err)
+		}
+		return
+	}
+	w.successes++
+	w.failures = 0
+	if w.successes >= c.HealthyThreshold && w.status != StatusHealthy {
+		if w.status == StatusUnhealthy {
+			lager.Logger.Infof("Instance [%s] of service [%s] is healthy again", w.instance.InstanceID, w.service)
+		}
+		w.status = StatusHealthy
+	}
+}
+
+func (w *worker) reset() {
+	w.mu.Lock()
+	w.status = StatusUnknown
+	w.successes = 0
+	w.failures = 0
+	w.mu.Unlock()
+}
@@ -0,0 +1,153 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+}
+
+func TestTCPProbe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := l.Addr().String()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, tcpProbe(ctx, &Target{Address: addr}, config.HealthCheckConfig{}))
+
+	l.Close()
+	assert.Error(t, tcpProbe(ctx, &Target{Address: addr}, config.HealthCheckConfig{}))
+}
+
+func TestHTTPProbe(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+	addr := strings.TrimPrefix(s.URL, "http://")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	c := config.HealthCheckConfig{Path: "health", ExpectedStatus: http.StatusOK}
+	assert.NoError(t, httpProbe(ctx, &Target{Address: addr}, c))
+	c.Path = "/healthz"
+	assert.Error(t, httpProbe(ctx, &Target{Address: addr}, c))
+}
+
+func TestNewTarget(t *testing.T) {
+	ins := &registry.MicroServiceInstance{EndpointsMap: map[string]string{
+		"rest": "127.0.0.1:8080?sslEnabled=true",
+	}}
+	target, ok := newTarget("Server", ins, "")
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1:8080", target.Address)
+	assert.True(t, target.SSLEnabled)
+	_, ok = newTarget("Server", ins, "highway")
+	assert.False(t, ok)
+}
+
+func TestWorkerRecord(t *testing.T) {
+	c := config.HealthCheckConfig{HealthyThreshold: 2, UnhealthyThreshold: 2}
+	w := &worker{service: "Server", instance: &registry.MicroServiceInstance{InstanceID: "1"},
+		stop: make(chan struct{}), status: StatusUnknown}
+	defaultChecker.mu.Lock()
+	defaultChecker.workers["1"] = w
+	defaultChecker.mu.Unlock()
+	defer defaultChecker.remove("1")
+
+	w.record(c, errors.New("refused"))
+	assert.True(t, IsHealthy("1"))
+	w.record(c, errors.New("refused"))
+	assert.False(t, IsHealthy("1"))
+	assert.Equal(t, StatusUnhealthy, Status("1"))
+	w.record(c, nil)
+	assert.False(t, IsHealthy("1"))
+	w.record(c, nil)
+	assert.Equal(t, StatusHealthy, Status("1"))
+	assert.Equal(t, StatusUnknown, Status("2"))
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), jitter(0))
+	assert.Equal(t, time.Duration(0), jitter(-time.Second))
+	for i := 0; i < 100; i++ {
+		d := nextInterval(10 * time.Second)
+		assert.True(t, d >= 9*time.Second && d < 11*time.Second)
+	}
+}
+
+func TestProbePanic(t *testing.T) {
+	InstallProbe("panic", func(ctx context.Context, t *Target, c config.HealthCheckConfig) error {
+		panic("crash")
+	})
+	w := &worker{service: "Server", instance: &registry.MicroServiceInstance{InstanceID: "1",
+		EndpointsMap: map[string]string{"rest": "127.0.0.1:8080"}}}
+	err := w.probe(config.HealthCheckConfig{Probe: "panic", Timeout: time.Second})
+	assert.Error(t, err)
+	w.record(config.HealthCheckConfig{HealthyThreshold: 1, UnhealthyThreshold: 1}, err)
+	assert.Equal(t, StatusUnhealthy, w.status)
+}
+
+func TestCheckerUpdate(t *testing.T) {
+	c := newChecker()
+	old := &registry.MicroServiceInstance{InstanceID: "1", Metadata: map[string]string{"v": "1"}}
+	w := &worker{service: "Server", instance: old, stop: make(chan struct{}), status: StatusUnhealthy}
+	c.instances["1"] = discovered{service: "Server", instance: old}
+	c.workers["1"] = w
+	defer c.remove("1")
+
+	// update must not readmit an unhealthy instance
+	ins := &registry.MicroServiceInstance{InstanceID: "1", Metadata: map[string]string{"v": "2"}}
+	c.onInstanceEvent(registry.InstanceEvent{Action: registry.EventUpdate, ServiceName: "Server", Instance: ins})
+	assert.Equal(t, StatusUnhealthy, c.status("1"))
+	assert.Equal(t, ins, w.getInstance())
+
+	c.onInstanceEvent(registry.InstanceEvent{Action: registry.EventDelete, ServiceName: "Server", Instance: ins})
+	assert.Equal(t, StatusUnknown, c.status("1"))
+	assert.Empty(t, c.instances)
+}
+
+func TestCheckerSync(t *testing.T) {
+	p := os.Getenv("GOPATH")
+	os.Setenv("CHASSIS_HOME", filepath.Join(p, "src", "github.com", "go-chassis", "go-chassis", "examples", "discovery", "server"))
+	assert.NoError(t, config.Init())
+	c := newChecker()
+	running := func(id string) bool {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		_, ok := c.workers[id]
+		return ok
+	}
+
+	// no worker is started while health check is disabled
+	ins := &registry.MicroServiceInstance{InstanceID: "1"}
+	c.onInstanceEvent(registry.InstanceEvent{Action: registry.EventAdd, ServiceName: "SyncServer", Instance: ins})
+	assert.False(t, running("1"))
+
+	archaius.AddKeyValue("cse.healthCheck.SyncServer.enabled", true)
+	c.sync()
+	assert.True(t, running("1"))
+
+	archaius.AddKeyValue("cse.healthCheck.SyncServer.enabled", false)
+	c.sync()
+	assert.False(t, running("1"))
+	archaius.DeleteKeyValue("cse.healthCheck.SyncServer.enabled", false)
+}
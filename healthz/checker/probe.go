@@ -0,0 +1,122 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/healthz/client"
+)
+
+// constant values for probe types
+const (
+	ProbeTCP     = "tcp"
+	ProbeHTTP    = "http"
+	ProbeHighway = "highway"
+)
+
+// Target is the instance endpoint to probe
+type Target struct {
+	ServiceName string
+	Protocol    string
+	// Address is host:port of endpoint
+	Address    string
+	SSLEnabled bool
+	Instance   *registry.MicroServiceInstance
+}
+
+// Probe checks whether target is healthy, it must return before ctx is done
+type Probe func(ctx context.Context, t *Target, c config.HealthCheckConfig) error
+
+var probes = map[string]Probe{
+	ProbeTCP:     tcpProbe,
+	ProbeHTTP:    httpProbe,
+	ProbeHighway: highwayProbe,
+}
+
+// InstallProbe install a probe implementation
+func InstallProbe(name string, p Probe) {
+	probes[name] = p
+}
+
+// GetProbe returns probe by name
+func GetProbe(name string) (Probe, error) {
+	p, ok := probes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown health check probe [%s]", name)
+	}
+	return p, nil
+}
+
+func tcpProbe(ctx context.Context, t *Target, c config.HealthCheckConfig) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.Address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		// probe only checks liveness, certificate is verified by real calls
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	},
+}
+
+func httpProbe(ctx context.Context, t *Target, c config.HealthCheckConfig) error {
+	scheme := "http"
+	if t.SSLEnabled {
+		scheme = "https"
+	}
+	path := c.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	req, err := http.NewRequest(http.MethodGet, scheme+"://"+t.Address+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != c.ExpectedStatus {
+		return fmt.Errorf("expected status %d, got %d", c.ExpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+func highwayProbe(ctx context.Context, t *Target, c config.HealthCheckConfig) error {
+	reply := client.Reply{ServiceName: t.ServiceName}
+	if t.Instance.Metadata != nil {
+		reply.AppId = t.Instance.Metadata[common.BuildinTagApp]
+		reply.Version = t.Instance.Metadata[common.BuildinTagVersion]
+	}
+	return client.Test(ctx, common.ProtocolHighway, t.Address, reply)
+}
+
+// newTarget picks the endpoint of instance to probe
+func newTarget(service string, ins *registry.MicroServiceInstance, protocol string) (*Target, bool) {
+	for p, ep := range ins.EndpointsMap {
+		if protocol != "" && p != protocol {
+			continue
+		}
+		t := &Target{ServiceName: service, Protocol: p, Instance: ins}
+		s := strings.SplitN(ep, "?", 2)
+		t.Address = s[0]
+		if len(s) == 2 && s[1] == "sslEnabled=true" {
+			t.SSLEnabled = true
+		}
+		return t, true
+	}
+	return nil, false
+}
@@ -0,0 +1,213 @@
+//Package collector collects go runtime, process and chassis metrics,
+//metrics are published to both go-metrics system registry and system prometheus registry
+package collector
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/client/highway"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/handler"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/metrics"
+	"github.com/go-chassis/go-chassis/metrics/prom"
+	"github.com/go-chassis/go-chassis/session"
+	"github.com/go-chassis/go-chassis/third_party/forked/afex/hystrix-go/hystrix"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// constants for name prefixes of metrics in go-metrics registry
+const (
+	RuntimePrefix = "runtime."
+	ProcessPrefix = "process."
+	ChassisPrefix = "chassis."
+)
+
+// Gauge is a chassis gauge, Label is empty if gauge has only one value
+type Gauge struct {
+	// Name is dotted go-metrics name, prometheus name replaces dots with underscores
+	Name  string
+	Help  string
+	Label string
+	// Read returns value of each label value, key is empty if gauge has no label
+	Read func() map[string]float64
+}
+
+var chassisGauges = []Gauge{
+	{
+		Name: "chassis.handler.chains",
+		Help: "number of handler chains",
+		Read: func() map[string]float64 {
+			return map[string]float64{"": float64(len(handler.ChainMap))}
+		},
+	},
+	{
+		Name:  "chassis.highway.connections",
+		Help:  "open highway connections to each peer",
+		Label: "peer",
+		Read: func() map[string]float64 {
+			return toFloat(highway.CachedClients.ConnectionCounts())
+		},
+	},
+	{
+		Name:  "chassis.registry.instances",
+		Help:  "cached instances of each service in discovery cache",
+		Label: "service",
+		Read: func() map[string]float64 {
+			return toFloat(registry.CachedInstanceCounts())
+		},
+	},
+	{
+		Name: "chassis.session.active",
+		Help: "number of sticky sessions",
+		Read: func() map[string]float64 {
+			return map[string]float64{"": float64(session.Count())}
+		},
+	},
+	{
+		Name:  "chassis.circuit.pool.utilization",
+		Help:  "active requests divided by max concurrent requests of circuit",
+		Label: "circuit",
+		Read:  hystrix.PoolUtilization,
+	},
+}
+
+func toFloat(m map[string]int) map[string]float64 {
+	f := make(map[string]float64, len(m))
+	for k, v := range m {
+		f[k] = float64(v)
+	}
+	return f
+}
+
+var once sync.Once
+
+// Init starts collecting metrics unless cse.metrics.enableGoRuntimeMetrics is false
+func Init() error {
+	if !config.GoRuntimeMetricsEnabled() {
+		return nil
+	}
+	once.Do(func() {
+		// prometheus gets these metrics from native collectors, do not mirror them again
+		metrics.ExcludeFromPrometheus(RuntimePrefix, ProcessPrefix, ChassisPrefix)
+		prom.EnableRunTimeMetrics()
+		metrics.GetSystemPrometheusRegistry().MustRegister(newChassisCollector(chassisGauges))
+
+		r := metrics.GetSystemRegistry()
+		gometrics.RegisterRuntimeMemStats(r)
+		u := newUpdater(r, chassisGauges)
+		go func() {
+			for range time.Tick(config.GetMetricsFlushInterval()) {
+				gometrics.CaptureRuntimeMemStatsOnce(r)
+				u.update()
+			}
+		}()
+		lager.Logger.Info("runtime, process and chassis metrics are enabled")
+	})
+	return nil
+}
+
+// updater writes runtime, process and chassis gauges into go-metrics registry
+type updater struct {
+	r      gometrics.Registry
+	gauges []Gauge
+	// names is the go-metrics names of each chassis gauge written last time
+	names []map[string]struct{}
+}
+
+func newUpdater(r gometrics.Registry, gauges []Gauge) *updater {
+	u := &updater{r: r, gauges: gauges, names: make([]map[string]struct{}, len(gauges))}
+	for i := range u.names {
+		u.names[i] = make(map[string]struct{})
+	}
+	return u
+}
+
+// update writes gauges which gometrics.RegisterRuntimeMemStats does not have,
+// runtime.NumThread is registered and captured by go-metrics, so it must not be set again
+func (u *updater) update() {
+	u.set(RuntimePrefix+"GOMAXPROCS", float64(runtime.GOMAXPROCS(0)))
+	u.set(RuntimePrefix+"NumCPU", float64(runtime.NumCPU()))
+	u.updateProcess()
+	for i, g := range u.gauges {
+		names := make(map[string]struct{})
+		for k, v := range g.Read() {
+			name := g.Name
+			if k != "" {
+				name += "." + metrics.SanitizeName(k)
+			}
+			names[name] = struct{}{}
+			u.set(name, v)
+		}
+		// values of peers or services which are gone
+		for name := range u.names[i] {
+			if _, ok := names[name]; !ok {
+				u.r.Unregister(name)
+			}
+		}
+		u.names[i] = names
+	}
+}
+
+// updateProcess reads cpu, rss and file descriptors from procfs, it does nothing if procfs is not available
+func (u *updater) updateProcess() {
+	p, err := procfs.Self()
+	if err != nil {
+		return
+	}
+	if stat, err := p.Stat(); err == nil {
+		u.set(ProcessPrefix+"cpu_seconds", stat.CPUTime())
+		u.set(ProcessPrefix+"resident_memory_bytes", float64(stat.ResidentMemory()))
+	}
+	if n, err := p.FileDescriptorsLen(); err == nil {
+		u.set(ProcessPrefix+"open_fds", float64(n))
+	}
+}
+
+func (u *updater) set(name string, v float64) {
+	gometrics.GetOrRegisterGaugeFloat64(name, u.r).Update(v)
+}
+
+// chassisCollector exports chassis gauges to prometheus with labels
+type chassisCollector struct {
+	gauges []Gauge
+	descs  []*prometheus.Desc
+}
+
+func newChassisCollector(gauges []Gauge) *chassisCollector {
+	c := &chassisCollector{gauges: gauges}
+	for _, g := range gauges {
+		var labels []string
+		if g.Label != "" {
+			labels = []string{g.Label}
+		}
+		c.descs = append(c.descs, prometheus.NewDesc(strings.Replace(g.Name, ".", "_", -1), g.Help, labels, nil))
+	}
+	return c
+}
+
+// Describe implements prometheus.Collector
+func (c *chassisCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range c.descs {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *chassisCollector) Collect(ch chan<- prometheus.Metric) {
+	for i, g := range c.gauges {
+		for k, v := range g.Read() {
+			if g.Label == "" {
+				ch <- prometheus.MustNewConstMetric(c.descs[i], prometheus.GaugeValue, v)
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(c.descs[i], prometheus.GaugeValue, v, k)
+		}
+	}
+}
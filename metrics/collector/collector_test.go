@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdater(t *testing.T) {
+	values := map[string]float64{"127.0.0.1:8080": 4, "127.0.0.2:8080": 2}
+	gauges := []Gauge{{
+		Name:  "chassis.test.connections",
+		Label: "peer",
+		Read: func() map[string]float64 {
+			return values
+		},
+	}}
+	r := gometrics.NewRegistry()
+	u := newUpdater(r, gauges)
+	u.update()
+	g, ok := r.Get("chassis.test.connections.127.0.0.1_8080").(gometrics.GaugeFloat64)
+	assert.True(t, ok)
+	assert.Equal(t, float64(4), g.Value())
+	assert.NotNil(t, r.Get(RuntimePrefix+"GOMAXPROCS"))
+
+	// peer is gone
+	delete(values, "127.0.0.2:8080")
+	u.update()
+	assert.Nil(t, r.Get("chassis.test.connections.127.0.0.2_8080"))
+	assert.NotNil(t, r.Get("chassis.test.connections.127.0.0.1_8080"))
+}
+
+func TestUpdaterWithRuntimeMemStats(t *testing.T) {
+	r := gometrics.NewRegistry()
+	gometrics.RegisterRuntimeMemStats(r)
+	u := newUpdater(r, nil)
+	gometrics.CaptureRuntimeMemStatsOnce(r)
+	assert.NotPanics(t, u.update)
+	_, ok := r.Get(RuntimePrefix + "NumThread").(gometrics.Gauge)
+	assert.True(t, ok)
+	_, ok = r.Get(RuntimePrefix + "GOMAXPROCS").(gometrics.GaugeFloat64)
+	assert.True(t, ok)
+}
+
+func TestChassisCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(newChassisCollector(chassisGauges))
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+	found := map[string]bool{}
+	for _, f := range families {
+		found[f.GetName()] = true
+	}
+	assert.True(t, found["chassis_handler_chains"])
+	assert.True(t, found["chassis_session_active"])
+}
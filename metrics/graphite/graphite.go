@@ -0,0 +1,93 @@
+//Package graphite pushes chassis system registry to graphite in plaintext protocol
+package graphite
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// Name is reporter name, config is under cse.metrics.reporters.graphite
+const Name = "graphite"
+
+// constants for default values
+const (
+	DefaultAddress = "127.0.0.1:2003"
+	dialTimeout    = 5 * time.Second
+)
+
+// Reporter writes metrics in graphite plaintext protocol with graphite 1.1 tags:
+//
+//	prefix.name;tag1=value1;tag2=value2 value timestamp
+type Reporter struct {
+	protocol string
+	address  string
+	prefix   string
+	tags     string
+}
+
+// NewReporter creates graphite reporter
+func NewReporter(c config.ReporterConfig) *Reporter {
+	r := &Reporter{
+		protocol: c.Protocol,
+		address:  c.Address,
+		prefix:   c.Prefix,
+	}
+	if r.protocol == "" {
+		r.protocol = "tcp"
+	}
+	if r.address == "" {
+		r.address = DefaultAddress
+	}
+	tags := metrics.Tags(c)
+	for _, k := range metrics.SortedKeys(tags) {
+		r.tags += ";" + metrics.SanitizeName(k) + "=" + metrics.SanitizeName(tags[k])
+	}
+	return r
+}
+
+// Report sends all metrics in registry, counters are sent as totals
+func (r *Reporter) Report(registry gometrics.Registry) error {
+	conn, err := net.DialTimeout(r.protocol, r.address, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	w := bufio.NewWriter(conn)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, p := range metrics.Snapshot(registry) {
+		if r.prefix != "" {
+			w.WriteString(r.prefix)
+			w.WriteByte('.')
+		}
+		w.WriteString(p.Name)
+		w.WriteString(r.tags)
+		w.WriteByte(' ')
+		w.WriteString(strconv.FormatFloat(p.Value, 'f', -1, 64))
+		w.WriteByte(' ')
+		w.WriteString(now)
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
+func report(registry gometrics.Registry) error {
+	c := config.GetReporterConfig(Name)
+	if !c.Enable {
+		return nil
+	}
+	r := NewReporter(c)
+	metrics.StartPush(Name, c.FlushInterval, func() error {
+		return r.Report(registry)
+	})
+	return nil
+}
+
+func init() {
+	metrics.InstallReporter(Name, report)
+}
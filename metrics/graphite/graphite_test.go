@@ -0,0 +1,39 @@
+package graphite_test
+
+import (
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics/graphite"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter_Report(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		b, _ := ioutil.ReadAll(conn)
+		conn.Close()
+		received <- string(b)
+	}()
+
+	r := gometrics.NewRegistry()
+	gometrics.GetOrRegisterCounter("requests", r).Inc(3)
+	reporter := graphite.NewReporter(config.ReporterConfig{Address: l.Addr().String(), Prefix: "chassis",
+		Tags: map[string]string{"host": "node1", "service": "Server", "version": "0.1"}})
+	assert.NoError(t, reporter.Report(r))
+	fields := strings.Fields(<-received)
+	assert.Len(t, fields, 3)
+	assert.Equal(t, "chassis.requests;host=node1;service=Server;version=0.1", fields[0])
+	assert.Equal(t, "3", fields[1])
+}
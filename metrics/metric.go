@@ -7,6 +7,7 @@ package metrics
 import (
 	"sync"
 
+	"github.com/go-chassis/go-chassis/core/config"
 	"github.com/go-chassis/go-chassis/core/lager"
 
 	"github.com/emicklei/go-restful"
@@ -48,14 +49,19 @@ func GetOrCreateRegistry(name string) metrics.Registry {
 	return r
 }
 
+// promHandler responds OpenMetrics format if scraper accepts it, exemplars are only exposed in OpenMetrics
+var promHandler = promhttp.HandlerFor(GetSystemPrometheusRegistry(), promhttp.HandlerOpts{EnableOpenMetrics: true})
+
 // HTTPHandleFunc is a go-restful handler which can expose metrics in http server
 func HTTPHandleFunc(req *restful.Request, rep *restful.Response) {
-	promhttp.HandlerFor(GetSystemPrometheusRegistry(), promhttp.HandlerOpts{}).ServeHTTP(rep.ResponseWriter, req.Request)
+	promHandler.ServeHTTP(rep.ResponseWriter, req.Request)
 }
 
 //Init prepare the metrics registry and report metrics to other systems
 func Init() error {
 	metricRegistries[defaultName] = metrics.DefaultRegistry
+	SetMaxLabelValues(config.GetMetricsMaxLabelValues())
+	SetLatencyConfig(config.GetLatencyMetricsConfig())
 	for k, report := range reporterPlugins {
 		lager.Logger.Info("report metrics to " + k)
 		if err := report(GetSystemRegistry()); err != nil {
@@ -0,0 +1,92 @@
+//Package opentsdb pushes chassis system registry to OpenTSDB with telnet put command
+package opentsdb
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// Name is reporter name, config is under cse.metrics.reporters.opentsdb
+const Name = "opentsdb"
+
+// constants for default values
+const (
+	DefaultAddress = "127.0.0.1:4242"
+	dialTimeout    = 5 * time.Second
+)
+
+// Reporter writes metrics with OpenTSDB put command:
+//
+//	put prefix.name timestamp value tag1=value1 tag2=value2
+type Reporter struct {
+	address string
+	prefix  string
+	tags    string
+}
+
+// NewReporter creates OpenTSDB reporter, OpenTSDB requires at least one tag, host tag is always sent
+func NewReporter(c config.ReporterConfig) *Reporter {
+	r := &Reporter{
+		address: c.Address,
+		prefix:  c.Prefix,
+	}
+	if r.address == "" {
+		r.address = DefaultAddress
+	}
+	tags := metrics.Tags(c)
+	if len(tags) == 0 {
+		tags["host"] = "unknown"
+	}
+	for _, k := range metrics.SortedKeys(tags) {
+		r.tags += " " + metrics.SanitizeName(k) + "=" + metrics.SanitizeName(tags[k])
+	}
+	return r
+}
+
+// Report sends all metrics in registry, counters are sent as totals
+func (r *Reporter) Report(registry gometrics.Registry) error {
+	conn, err := net.DialTimeout("tcp", r.address, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	w := bufio.NewWriter(conn)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, p := range metrics.Snapshot(registry) {
+		w.WriteString("put ")
+		if r.prefix != "" {
+			w.WriteString(r.prefix)
+			w.WriteByte('.')
+		}
+		w.WriteString(p.Name)
+		w.WriteByte(' ')
+		w.WriteString(now)
+		w.WriteByte(' ')
+		w.WriteString(strconv.FormatFloat(p.Value, 'f', -1, 64))
+		w.WriteString(r.tags)
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
+func report(registry gometrics.Registry) error {
+	c := config.GetReporterConfig(Name)
+	if !c.Enable {
+		return nil
+	}
+	r := NewReporter(c)
+	metrics.StartPush(Name, c.FlushInterval, func() error {
+		return r.Report(registry)
+	})
+	return nil
+}
+
+func init() {
+	metrics.InstallReporter(Name, report)
+}
@@ -0,0 +1,38 @@
+package opentsdb_test
+
+import (
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics/opentsdb"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter_Report(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		b, _ := ioutil.ReadAll(conn)
+		conn.Close()
+		received <- string(b)
+	}()
+
+	r := gometrics.NewRegistry()
+	gometrics.GetOrRegisterGauge("goroutines", r).Update(10)
+	reporter := opentsdb.NewReporter(config.ReporterConfig{Address: l.Addr().String(),
+		Tags: map[string]string{"host": "node1", "service": "Server", "version": "0.1"}})
+	assert.NoError(t, reporter.Report(r))
+	fields := strings.Fields(<-received)
+	assert.Equal(t, []string{"put", "goroutines"}, fields[:2])
+	assert.Equal(t, []string{"10", "host=node1", "service=Server", "version=0.1"}, fields[3:])
+}
@@ -0,0 +1,175 @@
+//Package otlp pushes chassis system registry to OpenTelemetry collector with OTLP
+package otlp
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics"
+	"github.com/go-chassis/go-chassis/pkg/shutdown"
+	gometrics "github.com/rcrowley/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Name is reporter name, config is under cse.metrics.reporters.otlp
+const Name = "otlp"
+
+// constants for protocols
+const (
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+)
+
+// DefaultAddress is default OTLP http endpoint
+const DefaultAddress = "http://127.0.0.1:4318/v1/metrics"
+
+// Reporter exports registry periodically with OpenTelemetry metric SDK,
+// tags become resource attributes
+type Reporter struct {
+	provider *sdkmetric.MeterProvider
+}
+
+// NewReporter creates OTLP reporter of registry, it starts exporting in background
+func NewReporter(registry gometrics.Registry, c config.ReporterConfig) (*Reporter, error) {
+	exporter, err := newExporter(c)
+	if err != nil {
+		return nil, err
+	}
+	tags := metrics.Tags(c)
+	attrs := make([]attribute.KeyValue, 0, len(tags))
+	for _, k := range metrics.SortedKeys(tags) {
+		key := k
+		// use OpenTelemetry semantic conventions for well known tags
+		switch k {
+		case "service":
+			key = "service.name"
+		case "version":
+			key = "service.version"
+		case "host":
+			key = "host.name"
+		}
+		attrs = append(attrs, attribute.String(key, tags[k]))
+	}
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithInterval(c.FlushInterval),
+		sdkmetric.WithProducer(&producer{registry: registry, prefix: c.Prefix, start: time.Now()}))
+	return &Reporter{
+		provider: sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(reader),
+			sdkmetric.WithResource(resource.NewSchemaless(attrs...))),
+	}, nil
+}
+
+// Flush exports registry immediately
+func (r *Reporter) Flush(ctx context.Context) error {
+	return r.provider.ForceFlush(ctx)
+}
+
+// Close exports registry and stops exporting
+func (r *Reporter) Close(ctx context.Context) error {
+	return r.provider.Shutdown(ctx)
+}
+
+func newExporter(c config.ReporterConfig) (sdkmetric.Exporter, error) {
+	address := c.Address
+	if address == "" {
+		address = DefaultAddress
+	}
+	endpoint, insecure, path, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Protocol {
+	case ProtocolGRPC:
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(endpoint)}
+		if insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(context.Background(), opts...)
+	case ProtocolHTTP, "":
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(endpoint)}
+		if insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if path != "" && path != "/" {
+			opts = append(opts, otlpmetrichttp.WithURLPath(path))
+		}
+		return otlpmetrichttp.New(context.Background(), opts...)
+	}
+	return nil, errors.New("Not support otlp protocol: " + c.Protocol)
+}
+
+// parseAddress returns host:port and path of address, address without https scheme is treated as insecure
+func parseAddress(address string) (endpoint string, insecure bool, path string, err error) {
+	if !strings.Contains(address, "://") {
+		return address, true, "", nil
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", false, "", err
+	}
+	return u.Host, u.Scheme != "https", u.Path, nil
+}
+
+// producer converts registry to OpenTelemetry metric data on each export
+type producer struct {
+	registry gometrics.Registry
+	prefix   string
+	start    time.Time
+}
+
+// Produce implements sdkmetric.Producer, counters are cumulative monotonic sums, others are gauges
+func (p *producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	now := time.Now()
+	points := metrics.Snapshot(p.registry)
+	ms := make([]metricdata.Metrics, 0, len(points))
+	for _, point := range points {
+		name := point.Name
+		if p.prefix != "" {
+			name = p.prefix + "." + name
+		}
+		dp := []metricdata.DataPoint[float64]{{StartTime: p.start, Time: now, Value: point.Value}}
+		m := metricdata.Metrics{Name: name}
+		if point.Counter {
+			m.Data = metricdata.Sum[float64]{
+				DataPoints:  dp,
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+			}
+		} else {
+			m.Data = metricdata.Gauge[float64]{DataPoints: dp}
+		}
+		ms = append(ms, m)
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: "github.com/go-chassis/go-chassis"},
+		Metrics: ms,
+	}}, nil
+}
+
+func report(registry gometrics.Registry) error {
+	c := config.GetReporterConfig(Name)
+	if !c.Enable {
+		return nil
+	}
+	r, err := NewReporter(registry, c)
+	if err != nil {
+		return err
+	}
+	shutdown.RegisterFlusher("metrics-"+Name, r.Close)
+	return nil
+}
+
+func init() {
+	metrics.InstallReporter(Name, report)
+}
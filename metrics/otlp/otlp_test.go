@@ -0,0 +1,38 @@
+package otlp_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics/otlp"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter_Flush(t *testing.T) {
+	received := make(chan int, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		b, _ := ioutil.ReadAll(req.Body)
+		received <- len(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	r := gometrics.NewRegistry()
+	gometrics.GetOrRegisterCounter("requests", r).Inc(3)
+	reporter, err := otlp.NewReporter(r, config.ReporterConfig{Address: s.URL + "/v1/metrics",
+		FlushInterval: time.Hour})
+	assert.NoError(t, err)
+	assert.NoError(t, reporter.Flush(context.Background()))
+	assert.True(t, <-received > 0)
+	assert.NoError(t, reporter.Close(context.Background()))
+
+	_, err = otlp.NewReporter(r, config.ReporterConfig{Protocol: "kafka"})
+	assert.Error(t, err)
+}
@@ -15,18 +15,17 @@ package prom
 // Some parts of this file have been modified to make it functional in this package
 import (
 	"fmt"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-chassis/go-chassis/core/config"
 
-	"github.com/go-chassis/go-chassis/core/archaius"
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/metrics"
 	"github.com/go-chassis/go-chassis/pkg/runtime"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	gometrics "github.com/rcrowley/go-metrics"
 )
 
@@ -112,6 +111,9 @@ func (c *PrometheusSinker) UpdatePrometheusMetrics() {
 // UpdatePrometheusMetricsOnce update prometheus metrics once
 func (c *PrometheusSinker) UpdatePrometheusMetricsOnce() error {
 	c.Registry.Each(func(name string, i interface{}) {
+		if metrics.ExcludedFromPrometheus(name) {
+			return
+		}
 		metricName := extractMetricKey(name)
 		operationID := extractOperationID(name)
 		schemaID := extractSchemaID(name)
@@ -159,10 +161,15 @@ func (c *PrometheusSinker) UpdatePrometheusMetricsOnce() error {
 	return nil
 }
 
-// EnableRunTimeMetrics enable runtime metrics
+// EnableRunTimeMetrics registers go runtime collector and process collector to system prometheus registry,
+// go runtime collector covers memory, gc and scheduler, process collector covers cpu, rss and file descriptors
 func EnableRunTimeMetrics() {
-	metrics.GetSystemPrometheusRegistry().MustRegister(prometheus.NewProcessCollector(os.Getpid(), ""))
-	metrics.GetSystemPrometheusRegistry().MustRegister(prometheus.NewGoCollector())
+	onceEnable.Do(func() {
+		metrics.GetSystemPrometheusRegistry().MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		metrics.GetSystemPrometheusRegistry().MustRegister(collectors.NewGoCollector(
+			collectors.WithGoCollectorRuntimeMetrics(collectors.MetricsGC, collectors.MetricsMemory, collectors.MetricsScheduler)))
+		lager.Logger.Info("Go Runtime Metrics is enabled")
+	})
 }
 
 func getEventType(metricName string) string {
@@ -247,13 +254,6 @@ var onceEnable sync.Once
 //ReportMetricsToPrometheus report metrics to prometheus registry, you can use GetSystemPrometheusRegistry to get prometheus registry. by default chassis will report system metrics to prometheus
 func ReportMetricsToPrometheus(r gometrics.Registry) error {
 	promConfig := GetPrometheusSinker(r)
-	if archaius.GetBool("cse.metrics.enableGoRuntimeMetrics", true) {
-		onceEnable.Do(func() {
-			EnableRunTimeMetrics()
-			lager.Logger.Info("Go Runtime Metrics is enabled")
-		})
-
-	}
 	go promConfig.UpdatePrometheusMetrics()
 	return nil
 }
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
+	"github.com/go-chassis/go-chassis/pkg/shutdown"
+	"github.com/rcrowley/go-metrics"
+)
+
+var percentiles = []float64{0.5, 0.75, 0.95, 0.99}
+var percentileNames = []string{"p50", "p75", "p95", "p99"}
+
+// Point is a flattened value of a go-metrics metric at report time
+type Point struct {
+	Name  string
+	Value float64
+	// Counter means Value is a monotonic total, reporter may send the delta of it
+	Counter bool
+}
+
+// Snapshot flattens every metric in registry to points sorted by name,
+// histograms and timers become count, min, max, mean and percentiles, durations are in milliseconds
+func Snapshot(r metrics.Registry) []Point {
+	points := make([]Point, 0)
+	r.Each(func(name string, i interface{}) {
+		name = SanitizeName(name)
+		switch m := i.(type) {
+		case metrics.Counter:
+			points = append(points, Point{Name: name, Value: float64(m.Count()), Counter: true})
+		case metrics.Gauge:
+			points = append(points, Point{Name: name, Value: float64(m.Value())})
+		case metrics.GaugeFloat64:
+			points = append(points, Point{Name: name, Value: m.Value()})
+		case metrics.Meter:
+			s := m.Snapshot()
+			points = append(points,
+				Point{Name: name + ".count", Value: float64(s.Count()), Counter: true},
+				Point{Name: name + ".rate1", Value: s.Rate1()},
+				Point{Name: name + ".rate_mean", Value: s.RateMean()})
+		case metrics.Histogram:
+			s := m.Snapshot()
+			points = append(points, Point{Name: name + ".count", Value: float64(s.Count()), Counter: true})
+			points = appendDistribution(points, name, float64(s.Min()), float64(s.Max()), s.Mean(), s.Percentiles(percentiles), 1)
+		case metrics.Timer:
+			s := m.Snapshot()
+			points = append(points,
+				Point{Name: name + ".count", Value: float64(s.Count()), Counter: true},
+				Point{Name: name + ".rate1", Value: s.Rate1()})
+			points = appendDistribution(points, name, float64(s.Min()), float64(s.Max()), s.Mean(), s.Percentiles(percentiles), float64(time.Millisecond))
+		}
+	})
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Name < points[j].Name
+	})
+	return points
+}
+
+func appendDistribution(points []Point, name string, min, max, mean float64, ps []float64, unit float64) []Point {
+	points = append(points,
+		Point{Name: name + ".min", Value: min / unit},
+		Point{Name: name + ".max", Value: max / unit},
+		Point{Name: name + ".mean", Value: mean / unit})
+	for i, p := range ps {
+		points = append(points, Point{Name: name + "." + percentileNames[i], Value: p / unit})
+	}
+	return points
+}
+
+// SanitizeName replaces characters which are not allowed by statsd, graphite or opentsdb with underscore
+func SanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Tags returns tags describing this instance merged with tags in reporter config
+func Tags(c config.ReporterConfig) map[string]string {
+	tags := map[string]string{
+		"service": config.SelfServiceName,
+		"version": config.SelfVersion,
+		"host":    runtime.HostName,
+	}
+	if config.GlobalDefinition != nil && config.GlobalDefinition.AppID != "" {
+		tags["app"] = config.GlobalDefinition.AppID
+	}
+	for k, v := range tags {
+		if v == "" {
+			delete(tags, k)
+		}
+	}
+	for k, v := range c.Tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// SortedKeys returns keys of tags in order, so that reporters write tags in a stable order
+func SortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeltaTracker turns counter totals into deltas between two reports
+type DeltaTracker struct {
+	mu   sync.Mutex
+	last map[string]float64
+}
+
+// NewDeltaTracker creates a delta tracker
+func NewDeltaTracker() *DeltaTracker {
+	return &DeltaTracker{last: make(map[string]float64)}
+}
+
+// Delta returns increase of counter since last call, counter reset is treated as a new counter
+func (d *DeltaTracker) Delta(name string, total float64) float64 {
+	delta := d.Peek(name, total)
+	d.Commit(map[string]float64{name: total})
+	return delta
+}
+
+// Peek returns increase of counter since last committed total, it does not change the baseline,
+// so that a report which fails to be sent is included in the next delta
+func (d *DeltaTracker) Peek(name string, total float64) float64 {
+	d.mu.Lock()
+	last := d.last[name]
+	d.mu.Unlock()
+	if total < last {
+		return total
+	}
+	return total - last
+}
+
+// Commit saves totals as baselines of following deltas, it is called after totals are sent
+func (d *DeltaTracker) Commit(totals map[string]float64) {
+	d.mu.Lock()
+	for name, total := range totals {
+		d.last[name] = total
+	}
+	d.mu.Unlock()
+}
+
+// StartPush calls push every interval in background,
+// push is called once more when chassis shuts down, so that last values are not lost
+func StartPush(name string, interval time.Duration, push func() error) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := push(); err != nil {
+					lager.Logger.Warnf("push metrics to %s failed: %s", name, err)
+				}
+			}
+		}
+	}()
+	shutdown.RegisterFlusher("metrics-"+name, func(ctx context.Context) error {
+		close(stop)
+		<-done
+		return push()
+	})
+}
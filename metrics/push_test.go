@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	r := gometrics.NewRegistry()
+	gometrics.GetOrRegisterCounter("requests", r).Inc(3)
+	gometrics.GetOrRegisterGauge("queue size", r).Update(7)
+	gometrics.GetOrRegisterTimer("latency", r).Update(20 * time.Millisecond)
+
+	points := Snapshot(r)
+	m := make(map[string]Point, len(points))
+	for _, p := range points {
+		m[p.Name] = p
+	}
+	assert.Equal(t, Point{Name: "requests", Value: 3, Counter: true}, m["requests"])
+	assert.Equal(t, float64(7), m["queue_size"].Value)
+	assert.Equal(t, Point{Name: "latency.count", Value: 1, Counter: true}, m["latency.count"])
+	assert.Equal(t, float64(20), m["latency.max"].Value)
+	assert.Equal(t, float64(20), m["latency.p99"].Value)
+}
+
+func TestDeltaTracker(t *testing.T) {
+	d := NewDeltaTracker()
+	assert.Equal(t, float64(3), d.Delta("c", 3))
+	assert.Equal(t, float64(2), d.Delta("c", 5))
+	// counter reset
+	assert.Equal(t, float64(1), d.Delta("c", 1))
+}
+
+func TestDeltaTracker_Commit(t *testing.T) {
+	d := NewDeltaTracker()
+	assert.Equal(t, float64(3), d.Peek("c", 3))
+	// baseline is not changed until totals are committed
+	assert.Equal(t, float64(5), d.Peek("c", 5))
+	d.Commit(map[string]float64{"c": 5})
+	assert.Equal(t, float64(2), d.Peek("c", 7))
+	assert.Equal(t, float64(1), d.Peek("c", 1))
+}
+
+func TestTags(t *testing.T) {
+	config.SelfServiceName = "Server"
+	tags := Tags(config.ReporterConfig{Tags: map[string]string{"zone": "az1", "service": "Renamed"}})
+	assert.Equal(t, "az1", tags["zone"])
+	assert.Equal(t, "Renamed", tags["service"])
+	assert.Equal(t, []string{"a", "b"}, SortedKeys(map[string]string{"b": "", "a": ""}))
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/rcrowley/go-metrics"
 )
@@ -13,6 +14,28 @@ type Reporter func(metrics.Registry) error
 var ErrDuplicated = errors.New("duplicated reporter")
 var reporterPlugins = make(map[string]Reporter)
 
+var promExcluded []string
+
+//ExcludeFromPrometheus tells prometheus reporter not to mirror go-metrics with name prefix,
+//it is used by metrics which are already exported to prometheus by native collectors
+func ExcludeFromPrometheus(prefixes ...string) {
+	l.Lock()
+	promExcluded = append(promExcluded, prefixes...)
+	l.Unlock()
+}
+
+//ExcludedFromPrometheus returns true if metric should not be mirrored to prometheus
+func ExcludedFromPrometheus(name string) bool {
+	l.RLock()
+	defer l.RUnlock()
+	for _, p := range promExcluded {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
 //InstallReporter install reporter implementation
 func InstallReporter(name string, reporter Reporter) error {
 	_, ok := reporterPlugins[name]
@@ -22,5 +45,3 @@ func InstallReporter(name string, reporter Reporter) error {
 	reporterPlugins[name] = reporter
 	return nil
 }
-
-//TODO ReportMetricsToOpenTSDB use go-metrics reporter to send metrics to opentsdb
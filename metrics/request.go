@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// constants for request metrics names
+const (
+	RequestTotal      = "request_total"
+	RequestErrorTotal = "request_error_total"
+	RequestDuration   = "request_duration_seconds"
+	// RequestDurationSummary is exported if latency summary is enabled
+	RequestDurationSummary = "request_duration_summary_seconds"
+)
+
+// ExemplarTraceID is exemplar label name of trace id
+const ExemplarTraceID = "trace_id"
+
+// constants for request metrics label names
+const (
+	LabelRole      = "role"
+	LabelSource    = "source"
+	LabelTarget    = "target"
+	LabelSchema    = "schema"
+	LabelOperation = "operation"
+	LabelProtocol  = "protocol"
+	LabelStatus    = "status"
+)
+
+// constants for role label values
+const (
+	RoleConsumer = "consumer"
+	RoleProvider = "provider"
+)
+
+// OverflowLabelValue replaces label values beyond cardinality limit
+const OverflowLabelValue = "other"
+
+var requestLabelNames = []string{LabelRole, LabelSource, LabelTarget, LabelSchema,
+	LabelOperation, LabelProtocol, LabelStatus}
+
+// RequestLabels describes one invocation
+type RequestLabels struct {
+	Role      string
+	Source    string
+	Target    string
+	Schema    string
+	Operation string
+	Protocol  string
+	Status    string
+}
+
+func (l RequestLabels) values() []string {
+	return []string{l.Role, l.Source, l.Target, l.Schema, l.Operation, l.Protocol, l.Status}
+}
+
+// requestMetrics records rate, errors and duration of invocations
+type requestMetrics struct {
+	total    *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	summary  *prometheus.SummaryVec
+}
+
+var (
+	reqMetrics     *requestMetrics
+	reqMetricsOnce sync.Once
+	guard          = newLabelGuard(config.DefaultMetricsMaxLabelValues)
+	latencyConfig  = config.LatencyMetricsConfig{Exemplar: true}
+)
+
+// SetLatencyConfig decides how request duration is exported,
+// it must be called before the first request is recorded
+func SetLatencyConfig(c config.LatencyMetricsConfig) {
+	latencyConfig = c
+}
+
+// ExemplarEnabled returns true if request duration carries trace id,
+// caller can skip looking up trace id if it returns false
+func ExemplarEnabled() bool {
+	return latencyConfig.Exemplar
+}
+
+func getRequestMetrics() *requestMetrics {
+	reqMetricsOnce.Do(func() {
+		// classic buckets are kept with native histogram, so that text format scrapers still get distribution
+		buckets := latencyConfig.Buckets
+		if len(buckets) == 0 {
+			buckets = prometheus.DefBuckets
+		}
+		reqMetrics = &requestMetrics{
+			total: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: RequestTotal,
+				Help: "number of invocations",
+			}, requestLabelNames),
+			errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: RequestErrorTotal,
+				Help: "number of failed invocations",
+			}, requestLabelNames),
+			duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:                        RequestDuration,
+				Help:                        "duration of invocations in seconds",
+				Buckets:                     buckets,
+				NativeHistogramBucketFactor: latencyConfig.NativeHistogramBucketFactor,
+			}, requestLabelNames),
+		}
+		GetSystemPrometheusRegistry().MustRegister(reqMetrics.total, reqMetrics.errors, reqMetrics.duration)
+		if latencyConfig.Summary {
+			reqMetrics.summary = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+				Name:       RequestDurationSummary,
+				Help:       "quantiles of invocation duration in seconds",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			}, requestLabelNames)
+			GetSystemPrometheusRegistry().MustRegister(reqMetrics.summary)
+		}
+	})
+	return reqMetrics
+}
+
+// RecordRequest records one invocation into system prometheus registry,
+// traceID is attached to duration as exemplar if it is not empty,
+// so that a slow bucket links to the trace of the invocation
+func RecordRequest(l RequestLabels, d time.Duration, failed bool, traceID string) {
+	m := getRequestMetrics()
+	values := guard.apply(l.values())
+	m.total.WithLabelValues(values...).Inc()
+	if failed {
+		m.errors.WithLabelValues(values...).Inc()
+	}
+	o := m.duration.WithLabelValues(values...)
+	if e, ok := o.(prometheus.ExemplarObserver); ok && traceID != "" && latencyConfig.Exemplar {
+		e.ObserveWithExemplar(d.Seconds(), prometheus.Labels{ExemplarTraceID: traceID})
+	} else {
+		o.Observe(d.Seconds())
+	}
+	if m.summary != nil {
+		m.summary.WithLabelValues(values...).Observe(d.Seconds())
+	}
+}
+
+// SetMaxLabelValues sets how many distinct values each label can have,
+// values seen after limit is reached are recorded as OverflowLabelValue
+func SetMaxLabelValues(n int) {
+	guard.setMax(n)
+}
+
+// labelGuard limits distinct values of each label
+type labelGuard struct {
+	mu   sync.RWMutex
+	max  int
+	seen []map[string]struct{}
+}
+
+func newLabelGuard(max int) *labelGuard {
+	g := &labelGuard{max: max, seen: make([]map[string]struct{}, len(requestLabelNames))}
+	for i := range g.seen {
+		g.seen[i] = make(map[string]struct{})
+	}
+	return g
+}
+
+func (g *labelGuard) setMax(n int) {
+	g.mu.Lock()
+	g.max = n
+	g.mu.Unlock()
+}
+
+// apply replaces values beyond limit in place and returns them
+func (g *labelGuard) apply(values []string) []string {
+	g.mu.RLock()
+	known := true
+	for i, v := range values {
+		if _, ok := g.seen[i][v]; !ok {
+			known = false
+			break
+		}
+	}
+	g.mu.RUnlock()
+	if known {
+		return values
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, v := range values {
+		if _, ok := g.seen[i][v]; ok {
+			continue
+		}
+		if len(g.seen[i]) >= g.max {
+			values[i] = OverflowLabelValue
+			continue
+		}
+		g.seen[i][v] = struct{}{}
+	}
+	return values
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelGuard(t *testing.T) {
+	g := newLabelGuard(2)
+	assert.Equal(t, []string{"consumer", "a", "b", "s", "/v1/1", "rest", "200"},
+		g.apply([]string{"consumer", "a", "b", "s", "/v1/1", "rest", "200"}))
+	assert.Equal(t, []string{"consumer", "a", "b", "s", "/v1/2", "rest", "200"},
+		g.apply([]string{"consumer", "a", "b", "s", "/v1/2", "rest", "200"}))
+	assert.Equal(t, []string{"consumer", "a", "b", "s", OverflowLabelValue, "rest", "200"},
+		g.apply([]string{"consumer", "a", "b", "s", "/v1/3", "rest", "200"}))
+	// known values are kept after limit is reached
+	assert.Equal(t, []string{"consumer", "a", "b", "s", "/v1/1", "rest", "200"},
+		g.apply([]string{"consumer", "a", "b", "s", "/v1/1", "rest", "200"}))
+
+	g.setMax(3)
+	assert.Equal(t, "/v1/3", g.apply([]string{"consumer", "a", "b", "s", "/v1/3", "rest", "200"})[4])
+}
+
+func TestRecordRequest(t *testing.T) {
+	SetLatencyConfig(config.LatencyMetricsConfig{Exemplar: true, Summary: true})
+	l := RequestLabels{Role: RoleConsumer, Target: "Server", Operation: "SayHello", Protocol: "highway", Status: "ok"}
+	RecordRequest(l, 20*time.Millisecond, false, "4bf92f3577b34da6a3ce929d0e0e4736")
+	RecordRequest(l, 30*time.Millisecond, true, "")
+
+	families, err := GetSystemPrometheusRegistry().Gather()
+	assert.NoError(t, err)
+	found := map[string]bool{}
+	for _, f := range families {
+		found[f.GetName()] = true
+		switch f.GetName() {
+		case RequestErrorTotal:
+			assert.Equal(t, float64(1), f.GetMetric()[0].GetCounter().GetValue())
+		case RequestDuration:
+			h := f.GetMetric()[0].GetHistogram()
+			assert.Equal(t, uint64(2), h.GetSampleCount())
+			var traceIDs []string
+			for _, b := range h.GetBucket() {
+				for _, lp := range b.GetExemplar().GetLabel() {
+					if lp.GetName() == ExemplarTraceID {
+						traceIDs = append(traceIDs, lp.GetValue())
+					}
+				}
+			}
+			assert.Equal(t, []string{"4bf92f3577b34da6a3ce929d0e0e4736"}, traceIDs)
+		}
+	}
+	assert.True(t, found[RequestTotal])
+	assert.True(t, found[RequestDurationSummary])
+}
@@ -0,0 +1,44 @@
+package statsd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// failWriter fails until it is fixed
+type failWriter struct {
+	bytes.Buffer
+	fail bool
+}
+
+func (w *failWriter) Write(b []byte) (int, error) {
+	if w.fail {
+		return 0, errors.New("connection refused")
+	}
+	return w.Buffer.Write(b)
+}
+
+func TestReporter_SendFailure(t *testing.T) {
+	r := gometrics.NewRegistry()
+	c := gometrics.GetOrRegisterCounter("requests", r)
+	c.Inc(3)
+	reporter := NewReporter(config.ReporterConfig{}, false)
+
+	w := &failWriter{fail: true}
+	assert.Error(t, reporter.send(w, r))
+	// counts of failed report are sent next time
+	c.Inc(2)
+	w.fail = false
+	assert.NoError(t, reporter.send(w, r))
+	assert.Equal(t, "requests:5|c\n", w.String())
+
+	w.Reset()
+	c.Inc(1)
+	assert.NoError(t, reporter.send(w, r))
+	assert.Equal(t, "requests:1|c\n", w.String())
+}
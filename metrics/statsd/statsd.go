@@ -0,0 +1,157 @@
+//Package statsd pushes chassis system registry to StatsD or DogStatsD
+package statsd
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+// constants for reporter names, config is under cse.metrics.reporters.<name>
+const (
+	Name          = "statsd"
+	DogStatsDName = "dogstatsd"
+)
+
+// constants for default values
+const (
+	DefaultAddress = "127.0.0.1:8125"
+	// maxPacketSize keeps udp packets under ethernet MTU
+	maxPacketSize = 1432
+	dialTimeout   = 5 * time.Second
+)
+
+// Reporter writes metrics in StatsD line protocol,
+// counters are sent as delta since last report, other metrics are sent as gauges.
+// DogStatsD reporter appends tags to every line
+type Reporter struct {
+	protocol string
+	address  string
+	prefix   string
+	tags     []byte
+	delta    *metrics.DeltaTracker
+}
+
+// NewReporter creates StatsD reporter, tags are only sent if dogStatsD is true
+func NewReporter(c config.ReporterConfig, dogStatsD bool) *Reporter {
+	r := &Reporter{
+		protocol: c.Protocol,
+		address:  c.Address,
+		prefix:   c.Prefix,
+		delta:    metrics.NewDeltaTracker(),
+	}
+	if r.protocol == "" {
+		r.protocol = "udp"
+	}
+	if r.address == "" {
+		r.address = DefaultAddress
+	}
+	if dogStatsD {
+		r.tags = formatTags(metrics.Tags(c))
+	}
+	return r
+}
+
+func formatTags(tags map[string]string) []byte {
+	if len(tags) == 0 {
+		return nil
+	}
+	b := bytes.NewBufferString("|#")
+	for i, k := range metrics.SortedKeys(tags) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(metrics.SanitizeName(k))
+		b.WriteByte(':')
+		b.WriteString(metrics.SanitizeName(tags[k]))
+	}
+	return b.Bytes()
+}
+
+// Report sends all metrics in registry
+func (r *Reporter) Report(registry gometrics.Registry) error {
+	conn, err := net.DialTimeout(r.protocol, r.address, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return r.send(conn, registry)
+}
+
+// send writes metrics to w, baselines of counters are saved only after the packet holding them is written,
+// so that counts of a failed write are sent in the next report
+func (r *Reporter) send(w io.Writer, registry gometrics.Registry) error {
+	var packet bytes.Buffer
+	totals := make(map[string]float64)
+	flush := func() error {
+		if _, err := w.Write(packet.Bytes()); err != nil {
+			return err
+		}
+		r.delta.Commit(totals)
+		packet.Reset()
+		totals = make(map[string]float64)
+		return nil
+	}
+	for _, p := range metrics.Snapshot(registry) {
+		line := r.line(p)
+		// datagram must not be split, tcp stream is written at once
+		if r.protocol != "tcp" && packet.Len() > 0 && packet.Len()+len(line) > maxPacketSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		packet.Write(line)
+		if p.Counter {
+			totals[p.Name] = p.Value
+		}
+	}
+	if packet.Len() == 0 {
+		return nil
+	}
+	return flush()
+}
+
+func (r *Reporter) line(p metrics.Point) []byte {
+	var b bytes.Buffer
+	if r.prefix != "" {
+		b.WriteString(r.prefix)
+		b.WriteByte('.')
+	}
+	b.WriteString(p.Name)
+	b.WriteByte(':')
+	if p.Counter {
+		b.WriteString(strconv.FormatFloat(r.delta.Peek(p.Name, p.Value), 'f', -1, 64))
+		b.WriteString("|c")
+	} else {
+		b.WriteString(strconv.FormatFloat(p.Value, 'f', -1, 64))
+		b.WriteString("|g")
+	}
+	b.Write(r.tags)
+	b.WriteByte('\n')
+	return b.Bytes()
+}
+
+func report(name string, dogStatsD bool) metrics.Reporter {
+	return func(registry gometrics.Registry) error {
+		c := config.GetReporterConfig(name)
+		if !c.Enable {
+			return nil
+		}
+		r := NewReporter(c, dogStatsD)
+		metrics.StartPush(name, c.FlushInterval, func() error {
+			return r.Report(registry)
+		})
+		return nil
+	}
+}
+
+func init() {
+	metrics.InstallReporter(Name, report(Name, false))
+	metrics.InstallReporter(DogStatsDName, report(DogStatsDName, true))
+}
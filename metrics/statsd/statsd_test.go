@@ -0,0 +1,57 @@
+package statsd_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/metrics/statsd"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func read(t *testing.T, conn net.PacketConn) string {
+	buf := make([]byte, 65536)
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestReporter_Report(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	r := gometrics.NewRegistry()
+	c := gometrics.GetOrRegisterCounter("requests", r)
+	c.Inc(3)
+	gometrics.GetOrRegisterGauge("goroutines", r).Update(10)
+
+	reporter := statsd.NewReporter(config.ReporterConfig{Address: conn.LocalAddr().String(), Prefix: "chassis"}, false)
+	assert.NoError(t, reporter.Report(r))
+	assert.Equal(t, "chassis.goroutines:10|g\nchassis.requests:3|c\n", read(t, conn))
+
+	// counters are sent as delta
+	c.Inc(2)
+	assert.NoError(t, reporter.Report(r))
+	assert.Contains(t, read(t, conn), "chassis.requests:2|c\n")
+}
+
+func TestDogStatsDReporter_Report(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	r := gometrics.NewRegistry()
+	gometrics.GetOrRegisterGauge("goroutines", r).Update(10)
+	reporter := statsd.NewReporter(config.ReporterConfig{Address: conn.LocalAddr().String(),
+		Tags: map[string]string{"zone": "az1", "host": "node1"}}, true)
+	assert.NoError(t, reporter.Report(r))
+	line := read(t, conn)
+	assert.True(t, strings.HasPrefix(line, "goroutines:10|g|#"))
+	assert.Contains(t, line, "zone:az1")
+	assert.Contains(t, line, "host:node1")
+}
@@ -10,6 +10,10 @@ import (
 const (
 	StatusRunning = "UP"
 	StatusDown    = "DOWN"
+	// StatusOutOfService means instance is alive but receives no request
+	StatusOutOfService = "OUTOFSERVICE"
+	// StatusTesting means instance only receives requests which ask for testing instances
+	StatusTesting = "TESTING"
 )
 
 //HostName is the host name of service host
@@ -0,0 +1,75 @@
+// Package shutdown keeps hooks which go chassis runs during graceful shutdown,
+// flushers run after servers are drained, then cleanup hooks run
+package shutdown
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+)
+
+// Hook is called during graceful shutdown, it should return before ctx is done
+type Hook func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+var (
+	mu       sync.Mutex
+	flushers []namedHook
+	hooks    []namedHook
+)
+
+// RegisterFlusher registers a hook to flush buffered telemetry data, such as spans and metrics
+func RegisterFlusher(name string, h Hook) {
+	mu.Lock()
+	flushers = append(flushers, namedHook{name: name, hook: h})
+	mu.Unlock()
+}
+
+// RegisterHook registers a user cleanup hook, hooks run in registration order
+func RegisterHook(name string, h Hook) {
+	mu.Lock()
+	hooks = append(hooks, namedHook{name: name, hook: h})
+	mu.Unlock()
+}
+
+// Flush runs all flushers
+func Flush(ctx context.Context) {
+	mu.Lock()
+	fs := append([]namedHook(nil), flushers...)
+	mu.Unlock()
+	run(ctx, fs)
+}
+
+// RunHooks runs all cleanup hooks
+func RunHooks(ctx context.Context) {
+	mu.Lock()
+	hs := append([]namedHook(nil), hooks...)
+	mu.Unlock()
+	run(ctx, hs)
+}
+
+func run(ctx context.Context, hs []namedHook) {
+	for _, h := range hs {
+		if ctx.Err() != nil {
+			lager.Logger.Warnf("shutdown deadline exceeded, skip hook [%s]", h.name)
+			continue
+		}
+		if err := call(ctx, h); err != nil {
+			lager.Logger.Errorf(err, "shutdown hook [%s] failed", h.name)
+		}
+	}
+}
+
+func call(ctx context.Context, h namedHook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			lager.Logger.Errorf(nil, "shutdown hook [%s] panics: %v", h.name, r)
+		}
+	}()
+	return h.hook(ctx)
+}
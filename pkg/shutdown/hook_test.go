@@ -0,0 +1,39 @@
+package shutdown_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/pkg/shutdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunHooks(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	var called []string
+	shutdown.RegisterHook("a", func(ctx context.Context) error {
+		called = append(called, "a")
+		return errors.New("failed hook should not stop others")
+	})
+	shutdown.RegisterHook("b", func(ctx context.Context) error {
+		panic("panic hook should not stop others")
+	})
+	shutdown.RegisterHook("c", func(ctx context.Context) error {
+		called = append(called, "c")
+		return nil
+	})
+	shutdown.RegisterFlusher("f", func(ctx context.Context) error {
+		called = append(called, "f")
+		return nil
+	})
+	shutdown.Flush(context.Background())
+	shutdown.RunHooks(context.Background())
+	assert.Equal(t, []string{"f", "a", "c"}, called)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	shutdown.RunHooks(ctx)
+	assert.Equal(t, []string{"f", "a", "c"}, called)
+}
@@ -3,11 +3,13 @@ package common
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
-	"errors"
 	"fmt"
 	"io/ioutil"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/util/string"
@@ -27,12 +29,47 @@ type SSLConfig struct {
 	CertFile     string   `yaml:"cert_file" json:"certFile"`
 	KeyFile      string   `yaml:"key_file" json:"keyFile"`
 	CertPWDFile  string   `yaml:"cert_pwd_file" json:"certPwdFile"`
+
+	CurvePreferences []tls.CurveID `yaml:"curve_preferences" json:"curvePreferences"`
+	ALPNProtocols    []string      `yaml:"alpn_protocols" json:"alpnProtocols"`
+	// SessionTicketKeyFile has base64 encoded 32 bytes keys line by line, the first one encrypts new tickets,
+	// it is reloaded with certificate, so that keys are rotated by rewriting the file
+	SessionTicketKeyFile string `yaml:"session_ticket_key_file" json:"sessionTicketKeyFile"`
+	// SessionTicketKeyRotation is interval of rotating random session ticket keys of server
+	// if there is no session ticket key file
+	SessionTicketKeyRotation time.Duration `yaml:"session_ticket_key_rotation" json:"sessionTicketKeyRotation"`
+	// OCSPStapleFile is DER encoded OCSP response stapled in handshake, it is reloaded with certificate
+	OCSPStapleFile string `yaml:"ocsp_staple_file" json:"ocspStapleFile"`
 }
 
-//TLSCipherSuiteMap is a map with key of type string and value of type unsigned integer
+//TLSCipherSuiteMap is a map of AEAD cipher suites supported by go,
+//TLS 1.3 suites can not be disabled, they are accepted so that a list can be shared by all versions
 var TLSCipherSuiteMap = map[string]uint16{
-	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_AES_128_GCM_SHA256":                  tls.TLS_AES_128_GCM_SHA256,
+	"TLS_AES_256_GCM_SHA384":                  tls.TLS_AES_256_GCM_SHA384,
+	"TLS_CHACHA20_POLY1305_SHA256":            tls.TLS_CHACHA20_POLY1305_SHA256,
+}
+
+//DefaultCipherSuites is cipher suites in order of preference when cipherSuits is not configured,
+//suites without forward secrecy are not included
+var DefaultCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+	"TLS_AES_128_GCM_SHA256",
+	"TLS_AES_256_GCM_SHA384",
+	"TLS_CHACHA20_POLY1305_SHA256",
 }
 
 //TLSVersionMap is a map with key of type string and value of type unsigned integer
@@ -40,8 +77,27 @@ var TLSVersionMap = map[string]uint16{
 	"TLSv1.0": tls.VersionTLS10,
 	"TLSv1.1": tls.VersionTLS11,
 	"TLSv1.2": tls.VersionTLS12,
+	"TLSv1.3": tls.VersionTLS13,
+}
+
+//TLSCurveMap is a map of elliptic curves supported by go
+var TLSCurveMap = map[string]tls.CurveID{
+	"X25519": tls.X25519,
+	"P256":   tls.CurveP256,
+	"P384":   tls.CurveP384,
+	"P521":   tls.CurveP521,
 }
 
+// tls13CipherSuites are cipher suites of TLS 1.3, they are not used by lower versions
+var tls13CipherSuites = map[uint16]bool{
+	tls.TLS_AES_128_GCM_SHA256:       true,
+	tls.TLS_AES_256_GCM_SHA384:       true,
+	tls.TLS_CHACHA20_POLY1305_SHA256: true,
+}
+
+// sessionTicketKeySize is size of a session ticket key of crypto/tls
+const sessionTicketKeySize = 32
+
 //GetX509CACertPool read a certificate file and gets the certificate configuration
 func GetX509CACertPool(caCertFile string) (*x509.CertPool, error) {
 	pool := x509.NewCertPool()
@@ -106,61 +162,46 @@ func LoadTLSCertificate(certFile, keyFile, passphase string, cipher security.Cip
 }
 
 func getTLSConfig(sslConfig *SSLConfig, role string) (tlsConfig *tls.Config, err error) {
-	clientAuthMode := tls.NoClientCert
-	var pool *x509.CertPool
+	m, err := (&CertReloader{sslConfig: *sslConfig, role: role}).load()
+	if err != nil {
+		return nil, err
+	}
 	// ca file is needed when veryPeer is true
+	clientAuthMode := tls.NoClientCert
 	if sslConfig.VerifyPeer {
-		pool, err = GetX509CACertPool(sslConfig.CAFile)
-		if err != nil {
-			return nil, err
-		}
-
 		clientAuthMode = tls.RequireAndVerifyClientCert
 	}
-
-	// if cert pwd file is set, get the pwd
-	var keyPassphase []byte
-	if sslConfig.CertPWDFile != "" {
-		keyPassphase, err = ioutil.ReadFile(sslConfig.CertPWDFile)
-		if err != nil {
-			return nil, fmt.Errorf("read cert pwd %s failed", sslConfig.CertPWDFile)
-		}
-	}
-
-	// certificate is necessary for server, optional for client
 	var certs []tls.Certificate
-	if !(role == common.Client && sslConfig.KeyFile == "" && sslConfig.CertFile == "") {
-		var cipherPlugin security.Cipher
-		if f, err := security.GetCipherNewFunc(sslConfig.CipherPlugin); err != nil {
-			return nil, fmt.Errorf("Get cipher plugin [%s] failed, %v", sslConfig.CipherPlugin, err)
-		} else if cipherPlugin = f(); cipherPlugin == nil {
-			return nil, errors.New("Invalid cipher plugin")
-		}
-		certs, err = LoadTLSCertificate(sslConfig.CertFile, sslConfig.KeyFile, strings.TrimSpace(string(keyPassphase)), cipherPlugin)
-		if err != nil {
-			return nil, err
-		}
+	if m.cert != nil {
+		certs = append(certs, *m.cert)
 	}
 
 	switch role {
 	case "server":
 		tlsConfig = &tls.Config{
-			ClientCAs:                pool,
+			ClientCAs:                m.pool,
 			Certificates:             certs,
 			CipherSuites:             sslConfig.CipherSuites,
 			PreferServerCipherSuites: true,
 			ClientAuth:               clientAuthMode,
 			MinVersion:               sslConfig.MinVersion,
 			MaxVersion:               sslConfig.MaxVersion,
+			CurvePreferences:         sslConfig.CurvePreferences,
+			NextProtos:               sslConfig.ALPNProtocols,
+		}
+		if len(m.ticketKeys) != 0 {
+			tlsConfig.SetSessionTicketKeys(m.ticketKeys)
 		}
 	case common.Client:
 		tlsConfig = &tls.Config{
-			RootCAs:            pool,
+			RootCAs:            m.pool,
 			Certificates:       certs,
 			CipherSuites:       sslConfig.CipherSuites,
 			InsecureSkipVerify: !sslConfig.VerifyPeer,
 			MinVersion:         sslConfig.MinVersion,
 			MaxVersion:         sslConfig.MaxVersion,
+			CurvePreferences:   sslConfig.CurvePreferences,
+			NextProtos:         sslConfig.ALPNProtocols,
 		}
 	}
 
@@ -191,7 +232,7 @@ func ParseSSLCipherSuites(ciphers string) ([]uint16, error) {
 			cipherSuiteList = append(cipherSuiteList, cipherSuite)
 		} else {
 			// 配置算法不存在
-			return nil, fmt.Errorf("cipher %s not exist", cipherSuiteName)
+			return nil, fmt.Errorf("cipher %s not exist, allowed values: %s", cipherSuiteName, cipherSuiteNames())
 		}
 	}
 
@@ -204,8 +245,117 @@ func ParseSSLProtocol(sprotocol string) (uint16, error) {
 	if protocol, ok := TLSVersionMap[sprotocol]; ok {
 		result = protocol
 	} else {
-		return result, fmt.Errorf("invalid ssl minimal version invalid(%s)", sprotocol)
+		return result, fmt.Errorf("invalid ssl protocol %s, allowed values: %s", sprotocol, versionNames())
 	}
 
 	return result, nil
 }
+
+//ParseSSLCurvePreferences parses curve names separated by comma, empty list means default curves of go
+func ParseSSLCurvePreferences(curves string) ([]tls.CurveID, error) {
+	var result []tls.CurveID
+	for _, name := range strings.Split(curves, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		curve, ok := TLSCurveMap[name]
+		if !ok {
+			return nil, fmt.Errorf("curve %s not exist, allowed values: %s", name, curveNames())
+		}
+		result = append(result, curve)
+	}
+	return result, nil
+}
+
+//ParseSSLALPNProtocols parses application protocols separated by comma, such as h2,http/1.1
+func ParseSSLALPNProtocols(protocols string) []string {
+	var result []string
+	for _, p := range strings.Split(protocols, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+//CheckSSLCipherSuites returns error if no cipher suite is usable by versions from minVersion to maxVersion
+func CheckSSLCipherSuites(suites []uint16, minVersion, maxVersion uint16) error {
+	if minVersion > maxVersion {
+		return fmt.Errorf("minimal version %s is higher than maximal version %s",
+			versionName(minVersion), versionName(maxVersion))
+	}
+	// TLS 1.3 suites are always enabled
+	if maxVersion >= tls.VersionTLS13 {
+		return nil
+	}
+	for _, s := range suites {
+		if !tls13CipherSuites[s] {
+			return nil
+		}
+	}
+	return fmt.Errorf("no cipher suite for versions lower than TLSv1.3")
+}
+
+//LoadSessionTicketKeys reads base64 encoded session ticket keys of file line by line
+func LoadSessionTicketKeys(file string) ([][32]byte, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read session ticket key file %s failed", file)
+	}
+	var keys [][32]byte
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(line)
+		if err != nil || len(b) != sessionTicketKeySize {
+			return nil, fmt.Errorf("session ticket key of file %s must be base64 encoded %d bytes",
+				file, sessionTicketKeySize)
+		}
+		var key [32]byte
+		copy(key[:], b)
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no session ticket key in file %s", file)
+	}
+	return keys, nil
+}
+
+func cipherSuiteNames() string {
+	names := make([]string, 0, len(TLSCipherSuiteMap))
+	for k := range TLSCipherSuiteMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func versionNames() string {
+	names := make([]string, 0, len(TLSVersionMap))
+	for k := range TLSVersionMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func versionName(v uint16) string {
+	for k, version := range TLSVersionMap {
+		if version == v {
+			return k
+		}
+	}
+	return fmt.Sprintf("0x%04x", v)
+}
+
+func curveNames() string {
+	names := make([]string, 0, len(TLSCurveMap))
+	for k := range TLSCurveMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,75 @@
+package common_test
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	secCommon "github.com/go-chassis/go-chassis/security/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSSLOptions(t *testing.T) {
+	suites, err := secCommon.ParseSSLCipherSuites("TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305, TLS_AES_128_GCM_SHA256")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305, tls.TLS_AES_128_GCM_SHA256}, suites)
+	_, err = secCommon.ParseSSLCipherSuites("TLS_RSA_WITH_RC4_128_SHA")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	for _, name := range secCommon.DefaultCipherSuites {
+		_, ok := secCommon.TLSCipherSuiteMap[name]
+		assert.True(t, ok, name)
+	}
+
+	v, err := secCommon.ParseSSLProtocol("TLSv1.3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), v)
+	_, err = secCommon.ParseSSLProtocol("SSLv3")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TLSv1.0, TLSv1.1, TLSv1.2, TLSv1.3")
+
+	curves, err := secCommon.ParseSSLCurvePreferences("X25519,P256")
+	assert.NoError(t, err)
+	assert.Equal(t, []tls.CurveID{tls.X25519, tls.CurveP256}, curves)
+	curves, err = secCommon.ParseSSLCurvePreferences("")
+	assert.NoError(t, err)
+	assert.Empty(t, curves)
+	_, err = secCommon.ParseSSLCurvePreferences("P224")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "P256, P384, P521, X25519")
+
+	assert.Equal(t, []string{"h2", "http/1.1"}, secCommon.ParseSSLALPNProtocols("h2, http/1.1,"))
+	assert.Empty(t, secCommon.ParseSSLALPNProtocols(""))
+
+	only13 := []uint16{tls.TLS_AES_128_GCM_SHA256}
+	assert.Error(t, secCommon.CheckSSLCipherSuites(only13, tls.VersionTLS12, tls.VersionTLS12))
+	assert.NoError(t, secCommon.CheckSSLCipherSuites(only13, tls.VersionTLS12, tls.VersionTLS13))
+	assert.NoError(t, secCommon.CheckSSLCipherSuites(suites, tls.VersionTLS12, tls.VersionTLS12))
+	assert.Error(t, secCommon.CheckSSLCipherSuites(suites, tls.VersionTLS13, tls.VersionTLS12))
+}
+
+func TestLoadSessionTicketKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ticket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "ticket.keys")
+
+	k1 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	k2 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32)))
+	assert.NoError(t, ioutil.WriteFile(file, []byte(k1+"\n\n"+k2+"\n"), 0600))
+	keys, err := secCommon.LoadSessionTicketKeys(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(keys))
+	assert.Equal(t, byte('a'), keys[0][0])
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	_, err = secCommon.LoadSessionTicketKeys(file)
+	assert.Error(t, err)
+	assert.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	_, err = secCommon.LoadSessionTicketKeys(file)
+	assert.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+)
+
+// PeerIdentity returns identity in certificate, it is the first URI SAN, such as spiffe://cluster.local/ns/default/sa/Server,
+// or the first DNS SAN if there is no URI SAN, it returns empty if there is neither
+func PeerIdentity(cert *x509.Certificate) string {
+	if cert == nil {
+		return ""
+	}
+	for _, u := range cert.URIs {
+		if u != nil {
+			return u.String()
+		}
+	}
+	if len(cert.DNSNames) != 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+// ConnectionIdentity returns identity of peer certificate in cs.
+// peer certificate is sent only if ssl verifyPeer is true, and server rejects the handshake if it is not verified,
+// so the identity is trustworthy
+func ConnectionIdentity(cs *tls.ConnectionState) string {
+	if cs == nil || len(cs.PeerCertificates) == 0 {
+		return ""
+	}
+	return PeerIdentity(cs.PeerCertificates[0])
+}
+
+// IdentityServiceName returns micro service name of identity,
+// it is the last path segment of URI identity, for example Server of spiffe://cluster.local/ns/default/sa/Server,
+// or the first label of DNS identity, for example Server of Server.default.svc
+func IdentityServiceName(identity string) string {
+	if i := strings.Index(identity, "://"); i != -1 {
+		path := identity[i+3:]
+		if j := strings.IndexAny(path, "?#"); j != -1 {
+			path = path[:j]
+		}
+		path = strings.TrimRight(path, "/")
+		if j := strings.Index(path, "/"); j == -1 {
+			// trust domain only
+			return ""
+		}
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	if i := strings.Index(identity, "."); i != -1 {
+		return identity[:i]
+	}
+	return identity
+}
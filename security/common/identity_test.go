@@ -0,0 +1,37 @@
+package common_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	secCommon "github.com/go-chassis/go-chassis/security/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerIdentity(t *testing.T) {
+	u, err := url.Parse("spiffe://cluster.local/ns/default/sa/Server")
+	assert.NoError(t, err)
+	assert.Equal(t, "spiffe://cluster.local/ns/default/sa/Server",
+		secCommon.PeerIdentity(&x509.Certificate{URIs: []*url.URL{u}, DNSNames: []string{"Server.default.svc"}}))
+	assert.Equal(t, "Server.default.svc", secCommon.PeerIdentity(&x509.Certificate{DNSNames: []string{"Server.default.svc"}}))
+	assert.Empty(t, secCommon.PeerIdentity(&x509.Certificate{}))
+	assert.Empty(t, secCommon.PeerIdentity(nil))
+
+	assert.Empty(t, secCommon.ConnectionIdentity(nil))
+	assert.Empty(t, secCommon.ConnectionIdentity(&tls.ConnectionState{}))
+	assert.Equal(t, "Server.default.svc", secCommon.ConnectionIdentity(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{DNSNames: []string{"Server.default.svc"}}},
+	}))
+}
+
+func TestIdentityServiceName(t *testing.T) {
+	assert.Equal(t, "Server", secCommon.IdentityServiceName("spiffe://cluster.local/ns/default/sa/Server"))
+	assert.Equal(t, "Server", secCommon.IdentityServiceName("spiffe://cluster.local/Server/"))
+	assert.Equal(t, "Server", secCommon.IdentityServiceName("spiffe://cluster.local/Server?x=y"))
+	assert.Equal(t, "", secCommon.IdentityServiceName("spiffe://cluster.local"))
+	assert.Equal(t, "Server", secCommon.IdentityServiceName("Server.default.svc"))
+	assert.Equal(t, "Server", secCommon.IdentityServiceName("Server"))
+	assert.Equal(t, "", secCommon.IdentityServiceName(""))
+}
@@ -0,0 +1,379 @@
+package common
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/security"
+	"github.com/go-chassis/go-chassis/security/plugins/envelope"
+)
+
+// maxRotatedTicketKeys is number of random session ticket keys kept by rotation,
+// tickets encrypted by former keys can still be resumed until their keys are dropped
+const maxRotatedTicketKeys = 3
+
+// certMaterial is certificate, ca pool and session ticket keys loaded at the same time
+type certMaterial struct {
+	cert       *tls.Certificate
+	pool       *x509.CertPool
+	notAfter   time.Time
+	ticketKeys [][32]byte
+}
+
+// CertReloader loads certificate, key and ca files of ssl config, and reloads them when files change.
+// tls config of GetReloadableServerTLSConfig and GetReloadableClientTLSConfig reads certificate and ca pool
+// from reloader in every handshake, so a reload applies to all listeners and clients sharing the reloader at once
+type CertReloader struct {
+	sslConfig SSLConfig
+	role      string
+	material  atomic.Value
+	// mu serializes reloads, stats is size and modification time of files loaded last time
+	mu    sync.Mutex
+	stats map[string]fileStat
+	// servers are tls configs of GetReloadableServerTLSConfig, session ticket keys are set to them
+	servers    []*tls.Config
+	ticketKeys [][32]byte
+	rotatedAt  time.Time
+}
+
+type fileStat struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewCertReloader loads files of sslConfig, role is client or server
+func NewCertReloader(sslConfig *SSLConfig, role string) (*CertReloader, error) {
+	r := &CertReloader{sslConfig: *sslConfig, role: role}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads files again if any of them changed, it returns true if certificate or ca pool is replaced.
+// if loading fails, the certificate and ca pool loaded last time are kept.
+// random session ticket keys are rotated by Reload if the rotation interval passes
+func (r *CertReloader) Reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.rotateTicketKeys(time.Now()); err != nil {
+		return false, err
+	}
+	stats, err := r.statFiles()
+	if err != nil {
+		return false, err
+	}
+	if r.stats != nil && sameStats(r.stats, stats) {
+		return false, nil
+	}
+	m, err := r.load()
+	if err != nil {
+		return false, err
+	}
+	r.material.Store(m)
+	r.stats = stats
+	if len(m.ticketKeys) != 0 {
+		r.setTicketKeys(m.ticketKeys)
+	}
+	return true, nil
+}
+
+// rotateTicketKeys adds a random session ticket key in front of keys when rotation interval passes,
+// it does nothing if keys are read from file
+func (r *CertReloader) rotateTicketKeys(now time.Time) error {
+	rotation := r.sslConfig.SessionTicketKeyRotation
+	if r.role == common.Client || r.sslConfig.SessionTicketKeyFile != "" || rotation <= 0 {
+		return nil
+	}
+	if !r.rotatedAt.IsZero() && now.Sub(r.rotatedAt) < rotation {
+		return nil
+	}
+	var key [32]byte
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return err
+	}
+	keys := append([][32]byte{key}, r.ticketKeys...)
+	if len(keys) > maxRotatedTicketKeys {
+		keys = keys[:maxRotatedTicketKeys]
+	}
+	r.setTicketKeys(keys)
+	r.rotatedAt = now
+	return nil
+}
+
+func (r *CertReloader) setTicketKeys(keys [][32]byte) {
+	r.ticketKeys = keys
+	for _, c := range r.servers {
+		c.SetSessionTicketKeys(keys)
+	}
+}
+
+// addServer registers server tls config, so that it gets session ticket keys of reloader
+func (r *CertReloader) addServer(c *tls.Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.servers = append(r.servers, c)
+	if len(r.ticketKeys) != 0 {
+		c.SetSessionTicketKeys(r.ticketKeys)
+	}
+}
+
+// Files returns files loaded by reloader
+func (r *CertReloader) Files() []string {
+	var files []string
+	for _, f := range []string{r.sslConfig.CAFile, r.sslConfig.CertFile, r.sslConfig.KeyFile, r.sslConfig.CertPWDFile,
+		r.sslConfig.SessionTicketKeyFile, r.sslConfig.OCSPStapleFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// Name returns cert file, or ca file if there is no cert file
+func (r *CertReloader) Name() string {
+	if r.sslConfig.CertFile != "" {
+		return r.sslConfig.CertFile
+	}
+	return r.sslConfig.CAFile
+}
+
+// NotAfter returns expiry time of certificate, it is zero if there is no certificate
+func (r *CertReloader) NotAfter() time.Time {
+	return r.current().notAfter
+}
+
+func (r *CertReloader) current() *certMaterial {
+	return r.material.Load().(*certMaterial)
+}
+
+func (r *CertReloader) statFiles() (map[string]fileStat, error) {
+	stats := make(map[string]fileStat)
+	for _, f := range r.Files() {
+		// os.Stat follows symbolic links, so that files of kubernetes secret volume are watched
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		stats[f] = fileStat{size: info.Size(), modTime: info.ModTime()}
+	}
+	return stats, nil
+}
+
+func sameStats(a, b map[string]fileStat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *CertReloader) load() (*certMaterial, error) {
+	m := &certMaterial{}
+	var err error
+	if r.sslConfig.VerifyPeer {
+		if m.pool, err = GetX509CACertPool(r.sslConfig.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	if r.role != common.Client && r.sslConfig.SessionTicketKeyFile != "" {
+		if m.ticketKeys, err = LoadSessionTicketKeys(r.sslConfig.SessionTicketKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	// certificate is necessary for server, optional for client
+	if r.role == common.Client && r.sslConfig.KeyFile == "" && r.sslConfig.CertFile == "" {
+		return m, nil
+	}
+	var keyPassphase []byte
+	if r.sslConfig.CertPWDFile != "" {
+		keyPassphase, err = ioutil.ReadFile(r.sslConfig.CertPWDFile)
+		if err != nil {
+			return nil, fmt.Errorf("read cert pwd %s failed", r.sslConfig.CertPWDFile)
+		}
+	}
+	var cipherPlugin security.Cipher
+	// passphase marked as encrypted is decrypted by envelope cipher whatever cipher plugin is
+	if envelope.IsEncrypted(strings.TrimSpace(string(keyPassphase))) {
+		cipherPlugin = &envelope.Cipher{}
+	} else if f, err := security.GetCipherNewFunc(r.sslConfig.CipherPlugin); err != nil {
+		return nil, fmt.Errorf("Get cipher plugin [%s] failed, %v", r.sslConfig.CipherPlugin, err)
+	} else if cipherPlugin = f(); cipherPlugin == nil {
+		return nil, errors.New("Invalid cipher plugin")
+	}
+	certs, err := LoadTLSCertificate(r.sslConfig.CertFile, r.sslConfig.KeyFile, strings.TrimSpace(string(keyPassphase)), cipherPlugin)
+	if err != nil {
+		return nil, err
+	}
+	m.cert = &certs[0]
+	leaf, err := x509.ParseCertificate(m.cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("parse cert file %s failed", r.sslConfig.CertFile)
+	}
+	m.cert.Leaf = leaf
+	m.notAfter = leaf.NotAfter
+	if r.sslConfig.OCSPStapleFile != "" {
+		if m.cert.OCSPStaple, err = ioutil.ReadFile(r.sslConfig.OCSPStapleFile); err != nil {
+			return nil, fmt.Errorf("read ocsp staple file %s failed", r.sslConfig.OCSPStapleFile)
+		}
+	}
+	return m, nil
+}
+
+// GetCertificate is used as tls.Config.GetCertificate of server
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if c := r.current().cert; c != nil {
+		return c, nil
+	}
+	return nil, errors.New("no certificate")
+}
+
+// GetClientCertificate is used as tls.Config.GetClientCertificate of client,
+// client sends no certificate if it is not configured
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if c := r.current().cert; c != nil {
+		return c, nil
+	}
+	return &tls.Certificate{}, nil
+}
+
+// VerifyPeerCertificate is used as tls.Config.VerifyPeerCertificate of server,
+// it verifies client certificate chain against the latest ca pool
+func (r *CertReloader) VerifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	certs, err := parseCertificates(rawCerts)
+	if err != nil {
+		return err
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         r.current().pool,
+		Intermediates: intermediates(certs),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
+
+// VerifyConnection is used as tls.Config.VerifyConnection of client,
+// it verifies server certificate chain against the latest ca pool and checks server name.
+// ip hosts are not sent as server name indication, so connection without server name is rejected,
+// use WithServerName to verify them
+func (r *CertReloader) VerifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no server certificate")
+	}
+	if cs.ServerName == "" {
+		return errors.New("unknown server name to verify server certificate")
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         r.current().pool,
+		DNSName:       cs.ServerName,
+		Intermediates: intermediates(cs.PeerCertificates),
+	})
+	return err
+}
+
+func parseCertificates(rawCerts [][]byte) ([]*x509.Certificate, error) {
+	if len(rawCerts) == 0 {
+		return nil, errors.New("no peer certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		c, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, nil
+}
+
+func intermediates(certs []*x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		pool.AddCert(c)
+	}
+	return pool
+}
+
+// GetReloadableServerTLSConfig returns server tls config reading certificate and ca pool from r
+func GetReloadableServerTLSConfig(r *CertReloader) *tls.Config {
+	c := &tls.Config{
+		GetCertificate:           r.GetCertificate,
+		CipherSuites:             r.sslConfig.CipherSuites,
+		PreferServerCipherSuites: true,
+		ClientAuth:               tls.NoClientCert,
+		MinVersion:               r.sslConfig.MinVersion,
+		MaxVersion:               r.sslConfig.MaxVersion,
+		CurvePreferences:         r.sslConfig.CurvePreferences,
+		NextProtos:               r.sslConfig.ALPNProtocols,
+	}
+	if r.sslConfig.VerifyPeer {
+		// chain is verified by VerifyPeerCertificate with the latest ca pool
+		c.ClientAuth = tls.RequireAnyClientCert
+		c.VerifyPeerCertificate = r.VerifyPeerCertificate
+	}
+	r.addServer(c)
+	return c
+}
+
+// GetReloadableClientTLSConfig returns client tls config reading certificate and ca pool from r
+func GetReloadableClientTLSConfig(r *CertReloader) *tls.Config {
+	c := &tls.Config{
+		GetClientCertificate: r.GetClientCertificate,
+		CipherSuites:         r.sslConfig.CipherSuites,
+		// chain is verified by VerifyConnection with the latest ca pool
+		InsecureSkipVerify: true,
+		MinVersion:         r.sslConfig.MinVersion,
+		MaxVersion:         r.sslConfig.MaxVersion,
+		CurvePreferences:   r.sslConfig.CurvePreferences,
+		NextProtos:         r.sslConfig.ALPNProtocols,
+	}
+	if r.sslConfig.VerifyPeer {
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			// server name configured in c is used if it is not sent in handshake
+			if cs.ServerName == "" {
+				cs.ServerName = c.ServerName
+			}
+			return r.VerifyConnection(cs)
+		}
+	}
+	return c
+}
+
+// WithServerName returns a copy of client tls config c to dial addr,
+// server certificate is verified against host of addr if c has no server name, including ip host
+func WithServerName(c *tls.Config, addr string) *tls.Config {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	c = c.Clone()
+	if c.ServerName == "" {
+		c.ServerName = host
+	}
+	if verify := c.VerifyConnection; verify != nil {
+		serverName := c.ServerName
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			if cs.ServerName == "" {
+				cs.ServerName = serverName
+			}
+			return verify(cs)
+		}
+	}
+	return c
+}
@@ -0,0 +1,366 @@
+package common_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	secCommon "github.com/go-chassis/go-chassis/security/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns cert and key pem signed by ca for localhost and ips
+func (ca *testCA) issue(t *testing.T, serial int64, notAfter time.Time, ips ...net.IP) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  ips,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func writeFile(t *testing.T, path string, content []byte, modTime time.Time) {
+	assert.NoError(t, ioutil.WriteFile(path, content, 0600))
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// handshake returns serial number of server certificate
+func handshake(server, client *tls.Config) (*big.Int, error) {
+	cs, err := handshakeState(server, client)
+	if err != nil {
+		return nil, err
+	}
+	return cs.PeerCertificates[0].SerialNumber, nil
+}
+
+// handshakeState returns connection state of client,
+// loopback connection is used so that alerts do not block handshake
+func handshakeState(server, client *tls.Config) (*tls.ConnectionState, error) {
+	l, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	errs := make(chan error, 1)
+	go func() {
+		s, err := l.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer s.Close()
+		errs <- s.(*tls.Conn).Handshake()
+	}()
+	conn, err := tls.Dial("tcp", l.Addr().String(), client)
+	if err != nil {
+		<-errs
+		return nil, err
+	}
+	defer conn.Close()
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	cs := conn.ConnectionState()
+	return &cs, nil
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloader")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	clientCertFile := filepath.Join(dir, "client.crt")
+	clientKeyFile := filepath.Join(dir, "client.key")
+	past := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, ca.pem, past)
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	cert, key := ca.issue(t, 2, notAfter)
+	writeFile(t, certFile, cert, past)
+	writeFile(t, keyFile, key, past)
+	cert, key = ca.issue(t, 3, notAfter)
+	writeFile(t, clientCertFile, cert, past)
+	writeFile(t, clientKeyFile, key, past)
+
+	sslConfig := &secCommon.SSLConfig{
+		CipherPlugin: "default",
+		VerifyPeer:   true,
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CAFile:       caFile,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+	}
+	serverReloader, err := secCommon.NewCertReloader(sslConfig, "server")
+	assert.NoError(t, err)
+	assert.Equal(t, notAfter.Unix(), serverReloader.NotAfter().Unix())
+	assert.Equal(t, certFile, serverReloader.Name())
+
+	clientSSLConfig := *sslConfig
+	clientSSLConfig.CertFile = clientCertFile
+	clientSSLConfig.KeyFile = clientKeyFile
+	clientReloader, err := secCommon.NewCertReloader(&clientSSLConfig, common.Client)
+	assert.NoError(t, err)
+
+	server := secCommon.GetReloadableServerTLSConfig(serverReloader)
+	client := secCommon.GetReloadableClientTLSConfig(clientReloader)
+	client.ServerName = "localhost"
+	serial, err := handshake(server, client)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), serial.Int64())
+
+	// files are not changed
+	changed, err := serverReloader.Reload()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// rotate server certificate
+	cert, key = ca.issue(t, 4, notAfter.Add(time.Hour))
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	changed, err = serverReloader.Reload()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	serial, err = handshake(server, client)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), serial.Int64())
+
+	// broken file keeps the former certificate
+	writeFile(t, certFile, []byte("broken"), time.Now().Add(time.Minute))
+	changed, err = serverReloader.Reload()
+	assert.Error(t, err)
+	assert.False(t, changed)
+	serial, err = handshake(server, client)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), serial.Int64())
+
+	// server trusts another ca only
+	writeFile(t, caFile, newTestCA(t).pem, time.Now().Add(2*time.Minute))
+	writeFile(t, certFile, cert, time.Now().Add(2*time.Minute))
+	_, err = serverReloader.Reload()
+	assert.NoError(t, err)
+	_, err = handshake(server, client)
+	assert.Error(t, err)
+
+	// client checks server name
+	client.ServerName = "example.com"
+	_, err = handshake(secCommon.GetReloadableServerTLSConfig(clientReloader), client)
+	assert.Error(t, err)
+}
+
+func TestCertReloaderTLSOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloader")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	ocspFile := filepath.Join(dir, "server.ocsp")
+	past := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, ca.pem, past)
+	cert, key := ca.issue(t, 2, time.Now().Add(time.Hour))
+	writeFile(t, certFile, cert, past)
+	writeFile(t, keyFile, key, past)
+	writeFile(t, ocspFile, []byte("ocsp response"), past)
+
+	sslConfig := &secCommon.SSLConfig{
+		CipherPlugin:     "default",
+		MinVersion:       tls.VersionTLS13,
+		MaxVersion:       tls.VersionTLS13,
+		CAFile:           caFile,
+		CertFile:         certFile,
+		KeyFile:          keyFile,
+		CurvePreferences: []tls.CurveID{tls.X25519},
+		ALPNProtocols:    []string{"h2", "http/1.1"},
+		OCSPStapleFile:   ocspFile,
+	}
+	serverReloader, err := secCommon.NewCertReloader(sslConfig, "server")
+	assert.NoError(t, err)
+	clientSSLConfig := *sslConfig
+	clientSSLConfig.VerifyPeer = true
+	clientSSLConfig.CertFile = ""
+	clientSSLConfig.KeyFile = ""
+	clientReloader, err := secCommon.NewCertReloader(&clientSSLConfig, common.Client)
+	assert.NoError(t, err)
+
+	client := secCommon.GetReloadableClientTLSConfig(clientReloader)
+	client.ServerName = "localhost"
+	cs, err := handshakeState(secCommon.GetReloadableServerTLSConfig(serverReloader), client)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), cs.Version)
+	assert.Equal(t, "h2", cs.NegotiatedProtocol)
+	assert.Equal(t, []byte("ocsp response"), cs.OCSPResponse)
+
+	// staple is reloaded with certificate
+	writeFile(t, ocspFile, []byte("new ocsp response"), time.Now())
+	changed, err := serverReloader.Reload()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	cs, err = handshakeState(secCommon.GetReloadableServerTLSConfig(serverReloader), client)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new ocsp response"), cs.OCSPResponse)
+}
+
+func TestCertReloaderVerifyIPHost(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloader")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	past := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, ca.pem, past)
+	// certificate has no ip san of 127.0.0.1
+	cert, key := ca.issue(t, 2, time.Now().Add(time.Hour))
+	writeFile(t, certFile, cert, past)
+	writeFile(t, keyFile, key, past)
+
+	sslConfig := &secCommon.SSLConfig{
+		CipherPlugin: "default",
+		CAFile:       caFile,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+	}
+	serverReloader, err := secCommon.NewCertReloader(sslConfig, "server")
+	assert.NoError(t, err)
+	clientSSLConfig := *sslConfig
+	clientSSLConfig.VerifyPeer = true
+	clientSSLConfig.CertFile = ""
+	clientSSLConfig.KeyFile = ""
+	clientReloader, err := secCommon.NewCertReloader(&clientSSLConfig, common.Client)
+	assert.NoError(t, err)
+	server := secCommon.GetReloadableServerTLSConfig(serverReloader)
+	client := secCommon.GetReloadableClientTLSConfig(clientReloader)
+
+	// 127.0.0.1 is not sent as server name indication
+	_, err = handshake(server, client)
+	assert.Error(t, err)
+	_, err = handshake(server, secCommon.WithServerName(client, "127.0.0.1:30100"))
+	assert.Error(t, err)
+
+	cert, key = ca.issue(t, 3, time.Now().Add(time.Hour), net.ParseIP("127.0.0.1"))
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	changed, err := serverReloader.Reload()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	serial, err := handshake(server, secCommon.WithServerName(client, "127.0.0.1:30100"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), serial.Int64())
+	_, err = handshake(server, secCommon.WithServerName(client, "127.0.0.2:30100"))
+	assert.Error(t, err)
+}
+
+func TestCertReloaderSessionTicketRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloader")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	past := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, ca.pem, past)
+	cert, key := ca.issue(t, 2, time.Now().Add(time.Hour))
+	writeFile(t, certFile, cert, past)
+	writeFile(t, keyFile, key, past)
+
+	// tickets of TLS 1.2 are sent in handshake
+	sslConfig := &secCommon.SSLConfig{
+		CipherPlugin:             "default",
+		MinVersion:               tls.VersionTLS12,
+		MaxVersion:               tls.VersionTLS12,
+		CertFile:                 certFile,
+		KeyFile:                  keyFile,
+		SessionTicketKeyRotation: time.Nanosecond,
+	}
+	serverReloader, err := secCommon.NewCertReloader(sslConfig, "server")
+	assert.NoError(t, err)
+	server := secCommon.GetReloadableServerTLSConfig(serverReloader)
+	client := &tls.Config{
+		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
+		ClientSessionCache: tls.NewLRUClientSessionCache(1),
+	}
+	cs, err := handshakeState(server, client)
+	assert.NoError(t, err)
+	assert.False(t, cs.DidResume)
+	cs, err = handshakeState(server, client)
+	assert.NoError(t, err)
+	assert.True(t, cs.DidResume)
+
+	// ticket of former key is accepted until the key is dropped
+	_, err = serverReloader.Reload()
+	assert.NoError(t, err)
+	cs, err = handshakeState(server, &tls.Config{
+		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
+		ClientSessionCache: client.ClientSessionCache,
+	})
+	assert.NoError(t, err)
+	assert.True(t, cs.DidResume)
+	for i := 0; i < 3; i++ {
+		_, err = serverReloader.Reload()
+		assert.NoError(t, err)
+	}
+	cs, err = handshakeState(server, client)
+	assert.NoError(t, err)
+	assert.False(t, cs.DidResume)
+}
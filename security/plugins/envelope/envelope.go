@@ -0,0 +1,214 @@
+// Package envelope is a cipher plugin using envelope encryption,
+// every value is encrypted by a random data key, and the data key is wrapped by a key of KeyManager.
+// ciphertext is in format of
+//
+//	enc:v1:{key id}:{base64 wrapped data key}:{base64 nonce and sealed value}
+//
+// key id of wrapping key is embedded, so that values encrypted before key rotation can still be decrypted
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/go-chassis/go-chassis/security"
+)
+
+// constants of envelope cipher
+const (
+	// Name is name of cipher plugin
+	Name = "envelope"
+	// Version is version of ciphertext format
+	Version = "v1"
+	// Prefix marks a value encrypted by envelope cipher
+	Prefix = "enc:" + Version + ":"
+
+	dataKeySize = 32
+)
+
+// errors of envelope cipher
+var (
+	ErrNotEncrypted     = errors.New("value is not encrypted by envelope cipher")
+	ErrMalformedValue   = errors.New("malformed envelope ciphertext")
+	ErrKeyNotFound      = errors.New("key of key id not found")
+	ErrNoKeyManager     = errors.New("no key manager")
+	ErrInvalidKeyID     = errors.New("key id must not be empty or contain ':'")
+	ErrInvalidKeyLength = errors.New("key length must be 16, 24 or 32 bytes")
+)
+
+// IsEncrypted returns true if s is marked as envelope ciphertext
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// KeyID returns id of key which wraps data key of ciphertext s
+func KeyID(s string) (string, error) {
+	p, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	return p.keyID, nil
+}
+
+type envelope struct {
+	keyID   string
+	wrapped []byte
+	sealed  []byte
+}
+
+func parse(s string) (*envelope, error) {
+	if !IsEncrypted(s) {
+		return nil, ErrNotEncrypted
+	}
+	parts := strings.Split(strings.TrimPrefix(s, Prefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, ErrMalformedValue
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedValue
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedValue
+	}
+	return &envelope{keyID: parts[0], wrapped: wrapped, sealed: sealed}, nil
+}
+
+func (e *envelope) String() string {
+	return Prefix + e.keyID + ":" + base64.RawURLEncoding.EncodeToString(e.wrapped) +
+		":" + base64.RawURLEncoding.EncodeToString(e.sealed)
+}
+
+// Encrypt encrypts plaintext with a new data key wrapped by km
+func Encrypt(km KeyManager, plaintext []byte) (string, error) {
+	dek := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", err
+	}
+	keyID, wrapped, err := km.WrapKey(dek)
+	if err != nil {
+		return "", err
+	}
+	if !validKeyID(keyID) {
+		return "", ErrInvalidKeyID
+	}
+	// key id is authenticated, so that it can not be replaced
+	sealed, err := seal(dek, plaintext, []byte(Prefix+keyID))
+	if err != nil {
+		return "", err
+	}
+	return (&envelope{keyID: keyID, wrapped: wrapped, sealed: sealed}).String(), nil
+}
+
+// Decrypt decrypts ciphertext s with data key unwrapped by km
+func Decrypt(km KeyManager, s string) ([]byte, error) {
+	e, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	dek, err := km.UnwrapKey(e.keyID, e.wrapped)
+	if err != nil {
+		return nil, err
+	}
+	return open(dek, e.sealed, []byte(Prefix+e.keyID))
+}
+
+// EncryptValue encrypts a config value with default key manager, the result can be written in config files
+func EncryptValue(plaintext string) (string, error) {
+	km, err := DefaultKeyManager()
+	if err != nil {
+		return "", err
+	}
+	return Encrypt(km, []byte(plaintext))
+}
+
+// DecryptValue decrypts a config value with default key manager,
+// value not marked as envelope ciphertext is returned as it is
+func DecryptValue(s string) (string, error) {
+	if !IsEncrypted(s) {
+		return s, nil
+	}
+	km, err := DefaultKeyManager()
+	if err != nil {
+		return "", err
+	}
+	b, err := Decrypt(km, s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// RotateValue decrypts s and encrypts it again with the current primary key,
+// it is used to re-encrypt config values after key rotation
+func RotateValue(s string) (string, error) {
+	plaintext, err := DecryptValue(s)
+	if err != nil {
+		return "", err
+	}
+	return EncryptValue(plaintext)
+}
+
+func validKeyID(id string) bool {
+	return id != "" && !strings.Contains(id, ":")
+}
+
+// seal encrypts plaintext with aes gcm, nonce is put in front of result
+func seal(key, plaintext, additional []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+func open(key, sealed, additional []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedValue
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additional)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidKeyLength
+	}
+	return cipher.NewGCM(block)
+}
+
+// Cipher is cipher plugin of envelope encryption, it uses default key manager
+type Cipher struct{}
+
+func init() {
+	security.InstallCipherPlugin(Name, newCipher)
+}
+
+func newCipher() security.Cipher {
+	return &Cipher{}
+}
+
+// Encrypt encrypts src with default key manager
+func (c *Cipher) Encrypt(src string) (string, error) {
+	return EncryptValue(src)
+}
+
+// Decrypt decrypts src with default key manager, src not marked as envelope ciphertext is returned as it is,
+// because config values are decrypted when they are read from archaius
+func (c *Cipher) Decrypt(src string) (string, error) {
+	return DecryptValue(src)
+}
@@ -0,0 +1,181 @@
+package envelope_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chassis/go-chassis/security"
+	"github.com/go-chassis/go-chassis/security/plugins/envelope"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeyring(t *testing.T, primary string, ids ...string) *envelope.Keyring {
+	keys := make(map[string][]byte)
+	for i, id := range ids {
+		key := make([]byte, 32)
+		key[0] = byte(i + 1)
+		keys[id] = key
+	}
+	k, err := envelope.NewKeyring(primary, keys)
+	assert.NoError(t, err)
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	k := newKeyring(t, "k1", "k1")
+	s, err := envelope.Encrypt(k, []byte("secret"))
+	assert.NoError(t, err)
+	assert.True(t, envelope.IsEncrypted(s))
+	assert.True(t, strings.HasPrefix(s, "enc:v1:k1:"))
+	id, err := envelope.KeyID(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", id)
+
+	b, err := envelope.Decrypt(k, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(b))
+
+	// every value has its own data key
+	s2, err := envelope.Encrypt(k, []byte("secret"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, s, s2)
+
+	// tampered value
+	parts := strings.Split(s, ":")
+	sealed, _ := base64.RawURLEncoding.DecodeString(parts[4])
+	sealed[len(sealed)-1] ^= 1
+	parts[4] = base64.RawURLEncoding.EncodeToString(sealed)
+	_, err = envelope.Decrypt(k, strings.Join(parts, ":"))
+	assert.Error(t, err)
+
+	_, err = envelope.Decrypt(k, "secret")
+	assert.Equal(t, envelope.ErrNotEncrypted, err)
+	_, err = envelope.Decrypt(k, "enc:v1:k1:abc")
+	assert.Equal(t, envelope.ErrMalformedValue, err)
+}
+
+func TestKeyRotation(t *testing.T) {
+	old := newKeyring(t, "k1", "k1")
+	s, err := envelope.Encrypt(old, []byte("secret"))
+	assert.NoError(t, err)
+
+	rotated := newKeyring(t, "k2", "k1", "k2")
+	b, err := envelope.Decrypt(rotated, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(b))
+
+	envelope.SetKeyManager(rotated)
+	defer envelope.SetKeyManager(nil)
+	s2, err := envelope.RotateValue(s)
+	assert.NoError(t, err)
+	id, _ := envelope.KeyID(s2)
+	assert.Equal(t, "k2", id)
+	plaintext, err := envelope.DecryptValue(s2)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	// key id is authenticated
+	_, err = envelope.Decrypt(rotated, strings.Replace(s, ":k1:", ":k2:", 1))
+	assert.Error(t, err)
+	// removed key
+	_, err = envelope.Decrypt(newKeyring(t, "k2", "k2"), s)
+	assert.Error(t, err)
+}
+
+type stubKMS struct {
+	keys map[string][]byte
+}
+
+func (s *stubKMS) WrapKey(dek []byte) (string, []byte, error) {
+	id := "kms-" + string(rune('a'+len(s.keys)))
+	s.keys[id] = dek
+	return id, []byte(id), nil
+}
+
+func (s *stubKMS) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	if dek, ok := s.keys[keyID]; ok && string(wrapped) == keyID {
+		return dek, nil
+	}
+	return nil, errors.New("unknown key")
+}
+
+func TestKeyManagerPlugin(t *testing.T) {
+	kms := &stubKMS{keys: make(map[string][]byte)}
+	envelope.InstallKeyManagerPlugin("stub", func() (envelope.KeyManager, error) {
+		return kms, nil
+	})
+	os.Setenv(envelope.EnvKeyManager, "stub")
+	defer os.Unsetenv(envelope.EnvKeyManager)
+	envelope.SetKeyManager(nil)
+	defer envelope.SetKeyManager(nil)
+
+	f, err := security.GetCipherNewFunc(envelope.Name)
+	assert.NoError(t, err)
+	c := f()
+	s, err := c.Encrypt("secret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(s, "enc:v1:kms-a:"))
+	plaintext, err := c.Decrypt(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+	// value not marked is plain text
+	plaintext, err = c.Decrypt("plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", plaintext)
+
+	os.Setenv(envelope.EnvKeyManager, "unknown")
+	envelope.SetKeyManager(nil)
+	_, err = envelope.EncryptValue("secret")
+	assert.Error(t, err)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	k1, err := envelope.GenerateKey()
+	assert.NoError(t, err)
+	k2, err := envelope.GenerateKey()
+	assert.NoError(t, err)
+
+	k, err := envelope.ParseKeyring("k1="+k1+", k2="+k2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", k.PrimaryKeyID())
+	k, err = envelope.ParseKeyring("k1="+k1+",k2="+k2, "k1")
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", k.PrimaryKeyID())
+	_, err = envelope.ParseKeyring("k1="+k1, "k3")
+	assert.Error(t, err)
+	_, err = envelope.ParseKeyring("k1=abc", "")
+	assert.Error(t, err)
+	_, err = envelope.ParseKeyring("k:1="+k1, "")
+	assert.Equal(t, envelope.ErrInvalidKeyID, err)
+
+	dir, err := ioutil.TempDir("", "keyring")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, envelope.KeyringFile)
+	content := "primary: k1\nkeys:\n  k1: " + k1 + "\n  k2: " + k2 + "\n"
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	k, err = envelope.LoadKeyringFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", k.PrimaryKeyID())
+
+	os.Setenv(envelope.EnvKeyringFile, path)
+	defer os.Unsetenv(envelope.EnvKeyringFile)
+	k, err = envelope.LoadKeyring()
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", k.PrimaryKeyID())
+
+	// environment keyring goes first
+	os.Setenv(envelope.EnvKeyring, "k2="+k2)
+	defer os.Unsetenv(envelope.EnvKeyring)
+	envelope.SetKeyManager(nil)
+	defer envelope.SetKeyManager(nil)
+	s, err := envelope.EncryptValue("secret")
+	assert.NoError(t, err)
+	id, _ := envelope.KeyID(s)
+	assert.Equal(t, "k2", id)
+}
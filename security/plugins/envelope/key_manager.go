@@ -0,0 +1,81 @@
+package envelope
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// environment variables choosing key manager
+const (
+	// EnvKeyManager is name of key manager plugin, default is keyring
+	EnvKeyManager = "CIPHER_KEY_MANAGER"
+	// KeyringKeyManager is name of key manager using local keyring
+	KeyringKeyManager = "keyring"
+)
+
+// KeyManager wraps and unwraps data keys, it can be a local keyring or a client of KMS.
+// key id returned by WrapKey is embedded in ciphertext and used by UnwrapKey,
+// so a key manager must keep former keys after rotation to decrypt former values
+type KeyManager interface {
+	// WrapKey encrypts data key with the primary key, and returns id of the primary key
+	WrapKey(dek []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey decrypts data key wrapped by key of keyID
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+var keyManagerPlugins = make(map[string]func() (KeyManager, error))
+
+//InstallKeyManagerPlugin install key manager plugin
+func InstallKeyManagerPlugin(name string, f func() (KeyManager, error)) {
+	keyManagerPlugins[name] = f
+}
+
+//GetKeyManagerPlugin return key manager plugin
+func GetKeyManagerPlugin(name string) func() (KeyManager, error) {
+	return keyManagerPlugins[name]
+}
+
+var (
+	defaultKM   KeyManager
+	defaultKMMu sync.Mutex
+)
+
+// DefaultKeyManager returns key manager set by SetKeyManager,
+// or creates key manager plugin of CIPHER_KEY_MANAGER at first use.
+// creating is tried again next time if it fails, so that a keyring file can be provided later
+func DefaultKeyManager() (KeyManager, error) {
+	defaultKMMu.Lock()
+	defer defaultKMMu.Unlock()
+	if defaultKM != nil {
+		return defaultKM, nil
+	}
+	name := os.Getenv(EnvKeyManager)
+	if name == "" {
+		name = KeyringKeyManager
+	}
+	f := GetKeyManagerPlugin(name)
+	if f == nil {
+		return nil, fmt.Errorf("unknown key manager plugin [%s]", name)
+	}
+	km, err := f()
+	if err != nil {
+		return nil, fmt.Errorf("create key manager [%s] failed, %v", name, err)
+	}
+	if km == nil {
+		return nil, ErrNoKeyManager
+	}
+	defaultKM = km
+	return km, nil
+}
+
+// SetKeyManager replaces default key manager, nil means creating it from environment again
+func SetKeyManager(km KeyManager) {
+	defaultKMMu.Lock()
+	defaultKM = km
+	defaultKMMu.Unlock()
+}
+
+func init() {
+	InstallKeyManagerPlugin(KeyringKeyManager, newKeyringKeyManager)
+}
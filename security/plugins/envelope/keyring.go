@@ -0,0 +1,166 @@
+package envelope
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chassis/go-chassis/pkg/util/fileutil"
+	"gopkg.in/yaml.v2"
+)
+
+// environment variables and file of local keyring
+const (
+	// EnvKeyring is keyring in format of {id}={base64 key},{id}={base64 key}
+	EnvKeyring = "CIPHER_KEYRING"
+	// EnvKeyringPrimary is id of primary key of CIPHER_KEYRING, default is the last one
+	EnvKeyringPrimary = "CIPHER_KEYRING_PRIMARY"
+	// EnvKeyringFile is path of keyring file, default is keyring.yaml in conf dir
+	EnvKeyringFile = "CIPHER_KEYRING_FILE"
+	// KeyringFile is name of default keyring file
+	KeyringFile = "keyring.yaml"
+)
+
+// Keyring is a local key manager holding key encryption keys,
+// the primary key wraps new data keys, others are kept to unwrap data keys of former values
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// KeyringFileContent is content of keyring file, keys are base64 encoded, for example:
+//
+//	primary: k2
+//	keys:
+//	  k1: {base64 key generated by GenerateKey}
+//	  k2: {base64 key generated by GenerateKey}
+type KeyringFileContent struct {
+	Primary string            `yaml:"primary"`
+	Keys    map[string]string `yaml:"keys"`
+}
+
+// NewKeyring returns keyring of keys, primary must be one of keys
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keyring has no key")
+	}
+	k := &Keyring{primary: primary, keys: make(map[string][]byte, len(keys))}
+	for id, key := range keys {
+		if !validKeyID(id) {
+			return nil, ErrInvalidKeyID
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("key [%s]: %v", id, ErrInvalidKeyLength)
+		}
+		k.keys[id] = key
+	}
+	if _, ok := k.keys[primary]; !ok {
+		return nil, fmt.Errorf("primary key [%s] not found", primary)
+	}
+	return k, nil
+}
+
+// ParseKeyring parses keyring in format of CIPHER_KEYRING, empty primary means the last key
+func ParseKeyring(s, primary string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	last := ""
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid keyring item, it should be {id}={base64 key}")
+		}
+		id := strings.TrimSpace(kv[0])
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("decode key [%s] failed, %v", id, err)
+		}
+		keys[id] = key
+		last = id
+	}
+	if primary == "" {
+		primary = last
+	}
+	return NewKeyring(primary, keys)
+}
+
+// LoadKeyringFile loads keyring of yaml file
+func LoadKeyringFile(path string) (*Keyring, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &KeyringFileContent{}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("unmarshal keyring file %s failed, %v", path, err)
+	}
+	keys := make(map[string][]byte, len(c.Keys))
+	for id, v := range c.Keys {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("decode key [%s] failed, %v", id, err)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(c.Primary, keys)
+}
+
+// LoadKeyring loads keyring of CIPHER_KEYRING, or keyring file of CIPHER_KEYRING_FILE,
+// or keyring.yaml in conf dir
+func LoadKeyring() (*Keyring, error) {
+	if s := os.Getenv(EnvKeyring); s != "" {
+		return ParseKeyring(s, os.Getenv(EnvKeyringPrimary))
+	}
+	path := os.Getenv(EnvKeyringFile)
+	if path == "" {
+		path = filepath.Join(fileutil.GetConfDir(), KeyringFile)
+	}
+	return LoadKeyringFile(path)
+}
+
+func newKeyringKeyManager() (KeyManager, error) {
+	return LoadKeyring()
+}
+
+// GenerateKey returns a random base64 encoded 256 bits key, which can be added to keyring
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// PrimaryKeyID returns id of key wrapping new data keys
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// WrapKey encrypts data key with primary key
+func (k *Keyring) WrapKey(dek []byte) (string, []byte, error) {
+	wrapped, err := seal(k.keys[k.primary], dek, []byte(k.primary))
+	if err != nil {
+		return "", nil, err
+	}
+	return k.primary, wrapped, nil
+}
+
+// UnwrapKey decrypts data key with key of keyID
+func (k *Keyring) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%v: %s", ErrKeyNotFound, keyID)
+	}
+	return open(key, wrapped, []byte(keyID))
+}
@@ -2,16 +2,20 @@ package highway
 
 import (
 	"bufio"
+	"crypto/tls"
 	"net"
 	"sync"
+	"sync/atomic"
 
 	highwayclient "github.com/go-chassis/go-chassis/client/highway"
 	"github.com/go-chassis/go-chassis/client/highway/pb"
 	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
 	"github.com/go-chassis/go-chassis/core/handler"
 	"github.com/go-chassis/go-chassis/core/invocation"
 	"github.com/go-chassis/go-chassis/core/lager"
 	"github.com/go-chassis/go-chassis/core/provider"
+	"github.com/go-chassis/go-chassis/core/server"
 	"io"
 )
 
@@ -19,6 +23,8 @@ import (
 type ConnectionMgr struct {
 	conns map[string]*HighwayConnection
 	count int
+	// inflight is the number of requests being handled
+	inflight int64
 	sync.RWMutex
 }
 
@@ -47,11 +53,21 @@ func (connMgr *ConnectionMgr) deleteConn(addr string) {
 
 //DeactiveAllConn close all conn
 func (connMgr *ConnectionMgr) DeactiveAllConn() {
+	connMgr.RLock()
+	conns := make([]*HighwayConnection, 0, len(connMgr.conns))
 	for _, conn := range connMgr.conns {
+		conns = append(conns, conn)
+	}
+	connMgr.RUnlock()
+	for _, conn := range conns {
 		conn.Close()
 	}
 }
 
+func (connMgr *ConnectionMgr) inflightCount() int64 {
+	return atomic.LoadInt64(&connMgr.inflight)
+}
+
 //HighwayConnection Highway connection
 type HighwayConnection struct {
 	remoteAddr   string
@@ -60,11 +76,19 @@ type HighwayConnection struct {
 	mtx          *sync.Mutex
 	closed       bool
 	connMgr      *ConnectionMgr
+	// tlsState is state of tls connection after handshake, it is nil if tls is not used
+	tlsState *tls.ConnectionState
 }
 
 //newHighwayConnection Create service connection
 func newHighwayConnection(conn net.Conn, handlerChain string, connMgr *ConnectionMgr) *HighwayConnection {
-	return &HighwayConnection{(conn.(*net.TCPConn)).RemoteAddr().String(), handlerChain, conn, &sync.Mutex{}, false, connMgr}
+	return &HighwayConnection{
+		remoteAddr:   conn.RemoteAddr().String(),
+		handlerChain: handlerChain,
+		baseConn:     conn,
+		mtx:          &sync.Mutex{},
+		connMgr:      connMgr,
+	}
 }
 
 //Open open service connection
@@ -125,18 +149,27 @@ func (svrConn *HighwayConnection) msgRecvLoop() {
 		svrConn.Close()
 		return
 	}
+	if tlsConn, ok := svrConn.baseConn.(*tls.Conn); ok {
+		// tls handshake is done when login request is read
+		cs := tlsConn.ConnectionState()
+		svrConn.tlsState = &cs
+	}
 	rdBuf := bufio.NewReaderSize(svrConn.baseConn, highwayclient.DefaultReadBufferSize)
 	for {
 		protoObj := &highwayclient.ProtocolObject{}
 		err := protoObj.DeSerializeFrame(rdBuf)
 		if err != nil {
 			if err != io.EOF {
-				lager.Logger.Errorf(err, "DeSerializeFrame failed.")
+				lager.Component(lager.ComponentHighway).Errorf(err, "DeSerializeFrame failed.")
 			}
 
 			break
 		}
-		go svrConn.handleFrame(protoObj)
+		atomic.AddInt64(&svrConn.connMgr.inflight, 1)
+		go func(protoObj *highwayclient.ProtocolObject) {
+			defer atomic.AddInt64(&svrConn.connMgr.inflight, -1)
+			svrConn.handleFrame(protoObj)
+		}(protoObj)
 	}
 	svrConn.Close()
 }
@@ -155,7 +188,7 @@ func (svrConn *HighwayConnection) writeError(req *highwayclient.Request, err err
 		errSnd := wBuf.Flush()
 		if errSnd != nil {
 			svrConn.Close()
-			lager.Logger.Errorf(errSnd, "writeError failed.")
+			lager.Component(lager.ComponentHighway).Errorf(errSnd, "writeError failed.")
 		}
 	}
 }
@@ -165,7 +198,7 @@ func (svrConn *HighwayConnection) handleFrame(protoObj *highwayclient.ProtocolOb
 	req := &highwayclient.Request{}
 	err = protoObj.DeSerializeReq(req)
 	if err != nil {
-		lager.Logger.Errorf(err, "DeSerializeReq failed")
+		lager.Component(lager.ComponentHighway).Errorf(err, "DeSerializeReq failed")
 		svrConn.writeError(req, err)
 		return err
 	}
@@ -178,9 +211,15 @@ func (svrConn *HighwayConnection) handleFrame(protoObj *highwayclient.ProtocolOb
 	i.Ctx = common.NewContext(req.Attachments)
 	i.SourceMicroService = common.FromContext(i.Ctx)[common.HeaderSourceName]
 	i.Protocol = common.ProtocolHighway
+	if svrConn.tlsState != nil {
+		if err := server.SetSourceIdentity(i, svrConn.tlsState, config.GetSSLVerifySourceService()); err != nil {
+			svrConn.writeError(req, err)
+			return err
+		}
+	}
 	c, err := handler.GetChain(common.Provider, svrConn.handlerChain)
 	if err != nil {
-		lager.Logger.Errorf(err, "Handler chain init err")
+		lager.Component(lager.ComponentHighway).Errorf(err, "Handler chain init err")
 		svrConn.writeError(req, err)
 	}
 
@@ -208,7 +247,7 @@ func (svrConn *HighwayConnection) handleFrame(protoObj *highwayclient.ProtocolOb
 			protoObj.SerializeRsp(rsp, wBuf)
 			err = wBuf.Flush()
 			if err != nil {
-				lager.Logger.Errorf(err, "Send Respond failed.")
+				lager.Component(lager.ComponentHighway).Errorf(err, "Send Respond failed.")
 				svrConn.Close()
 				return err
 			}
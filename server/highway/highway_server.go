@@ -1,6 +1,7 @@
 package highway
 
 import (
+	"context"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/config"
 	"sync"
@@ -26,9 +27,14 @@ const (
 
 var remoteLogin = true
 
+// drainCheckInterval is the interval of checking in-flight requests during shutdown
+const drainCheckInterval = 10 * time.Millisecond
+
 type highwayServer struct {
-	connMgr *ConnectionMgr
-	opts    server.Options
+	connMgr  *ConnectionMgr
+	opts     server.Options
+	listener net.Listener
+	closed   bool
 	sync.RWMutex
 }
 
@@ -73,22 +79,35 @@ func (s *highwayServer) Start() error {
 	}
 
 	if lisErr != nil {
-		lager.Logger.Error("listening failed, reason:", lisErr)
+		lager.Component(lager.ComponentHighway).Error("listening failed, reason:", lisErr)
 		return lisErr
 	}
+	s.Lock()
+	s.listener = listener
+	s.Unlock()
 	go s.acceptLoop(listener)
 	return nil
 }
 
+func (s *highwayServer) isClosed() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.closed
+}
+
 func (s *highwayServer) acceptLoop(l net.Listener) {
 	for {
 		conn, err := l.Accept()
 		if err != nil {
-			lager.Logger.Errorf(err, "Error accepting")
+			if s.isClosed() {
+				return
+			}
+			lager.Component(lager.ComponentHighway).Errorf(err, "Error accepting")
 			select {
 			case <-time.After(time.Second * 3):
-				lager.Logger.Info("Sleep three second")
+				lager.Component(lager.ComponentHighway).Info("Sleep three second")
 			}
+			continue
 		}
 		highwayConn := s.connMgr.createConn(conn, s.opts.ChainName)
 		highwayConn.Open()
@@ -96,10 +115,47 @@ func (s *highwayServer) acceptLoop(l net.Listener) {
 }
 
 func (s *highwayServer) Stop() error {
+	s.closeListener()
 	s.connMgr.DeactiveAllConn()
 	return nil
 }
 
+// Shutdown stops accepting connections, waits for in-flight requests until ctx is done, then closes all connections
+func (s *highwayServer) Shutdown(ctx context.Context) error {
+	s.closeListener()
+	err := s.waitInflight(ctx)
+	if err != nil {
+		lager.Component(lager.ComponentHighway).Warnf("highway server shutdown with %d in-flight requests: %s", s.connMgr.inflightCount(), err)
+	}
+	s.connMgr.DeactiveAllConn()
+	return err
+}
+
+func (s *highwayServer) waitInflight(ctx context.Context) error {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for s.connMgr.inflightCount() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (s *highwayServer) closeListener() {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+}
+
 func newHighwayServer(opts server.Options) server.ProtocolServer {
 	return &highwayServer{
 		connMgr: newConnectMgr(),
@@ -5,9 +5,11 @@ package highway_test
 import (
 	"context"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-chassis/go-chassis"
 	"github.com/go-chassis/go-chassis/client/highway"
@@ -111,3 +113,24 @@ func TestStart(t *testing.T) {
 	err = s.Stop()
 	assert.NoError(t, err)
 }
+
+func TestShutdown(t *testing.T) {
+	initEnv()
+	addr := "127.0.0.1:2400"
+	f, err := server.GetServerFunc("highway")
+	assert.NoError(t, err)
+	s := f(server.Options{
+		Address:   addr,
+		ChainName: "default",
+	})
+	assert.NoError(t, s.Start())
+
+	gs, ok := s.(server.GracefulServer)
+	assert.True(t, ok)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, gs.Shutdown(ctx))
+
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	assert.Error(t, err)
+}
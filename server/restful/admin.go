@@ -0,0 +1,141 @@
+package restful
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+)
+
+// DefaultAdminPath is the default path prefix of management API
+const DefaultAdminPath = "admin"
+
+// InstanceStatus is the body of instance status management API
+type InstanceStatus struct {
+	Status string `json:"status"`
+}
+
+// LogLevel is the body of log level management API,
+// empty component means global level, RevertAfter is a duration like 10m
+type LogLevel struct {
+	Component   string `json:"component,omitempty"`
+	Level       string `json:"level"`
+	RevertAfter string `json:"revertAfter,omitempty"`
+}
+
+// LogLevels is the response of log level management API
+type LogLevels struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// registerAdminRoutes adds management API to web service
+func registerAdminRoutes(ws *restful.WebService) {
+	if !archaius.GetBool("cse.admin.enable", false) {
+		return
+	}
+	adminPath := archaius.GetString("cse.admin.apiPath", DefaultAdminPath)
+	if !strings.HasPrefix(adminPath, "/") {
+		adminPath = "/" + adminPath
+	}
+	adminPath = strings.TrimSuffix(adminPath, "/")
+	lager.Logger.Info("Enabled admin API on " + adminPath)
+	ws.Route(ws.GET(adminPath + "/instance/status").Filter(authenticate(false)).To(getInstanceStatus))
+	ws.Route(ws.PUT(adminPath + "/instance/status").Filter(authenticate(true)).To(putInstanceStatus))
+	ws.Route(ws.GET(adminPath + "/logger/level").Filter(authenticate(true)).To(getLogLevel))
+	ws.Route(ws.PUT(adminPath + "/logger/level").Filter(authenticate(true)).To(putLogLevel))
+	ws.Route(ws.DELETE(adminPath + "/logger/level").Filter(authenticate(true)).To(deleteLogLevel))
+}
+
+// authenticate checks bearer token of request against cse.admin.token,
+// if required is true, API is forbidden unless token is configured
+func authenticate(required bool) restful.FilterFunction {
+	return func(req *restful.Request, rep *restful.Response, chain *restful.FilterChain) {
+		token := archaius.GetString("cse.admin.token", "")
+		if token == "" {
+			if required {
+				rep.WriteErrorString(http.StatusForbidden, "cse.admin.token is not configured")
+				return
+			}
+			chain.ProcessFilter(req, rep)
+			return
+		}
+		auth := req.HeaderParameter("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+			rep.AddHeader("WWW-Authenticate", "Bearer")
+			rep.WriteErrorString(http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		chain.ProcessFilter(req, rep)
+	}
+}
+
+func getInstanceStatus(req *restful.Request, rep *restful.Response) {
+	rep.WriteHeaderAndJson(http.StatusOK, InstanceStatus{Status: registry.InstanceStatus()}, restful.MIME_JSON)
+}
+
+// putInstanceStatus switches local instance to UP, OUTOFSERVICE or TESTING
+func putInstanceStatus(req *restful.Request, rep *restful.Response) {
+	s := InstanceStatus{}
+	if err := req.ReadEntity(&s); err != nil {
+		rep.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	s.Status = strings.ToUpper(s.Status)
+	if !registry.IsValidInstanceStatus(s.Status) {
+		rep.WriteErrorString(http.StatusBadRequest, "invalid instance status: "+s.Status)
+		return
+	}
+	if err := registry.UpdateInstanceStatus(s.Status); err != nil {
+		rep.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+	rep.WriteHeaderAndJson(http.StatusOK, s, restful.MIME_JSON)
+}
+
+func getLogLevel(req *restful.Request, rep *restful.Response) {
+	level, components := lager.Levels()
+	rep.WriteHeaderAndJson(http.StatusOK, LogLevels{Level: level, Components: components}, restful.MIME_JSON)
+}
+
+// putLogLevel changes global or component log level, level reverts after RevertAfter if it is set
+func putLogLevel(req *restful.Request, rep *restful.Response) {
+	l := LogLevel{}
+	if err := req.ReadEntity(&l); err != nil {
+		rep.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	var revertAfter time.Duration
+	if l.RevertAfter != "" {
+		d, err := time.ParseDuration(l.RevertAfter)
+		if err != nil || d < 0 {
+			rep.WriteErrorString(http.StatusBadRequest, "invalid revertAfter: "+l.RevertAfter)
+			return
+		}
+		revertAfter = d
+	}
+	if err := lager.SetLevel(l.Component, l.Level, revertAfter); err != nil {
+		rep.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	lager.Logger.Infof("log level of [%s] is changed to [%s] by admin API, revert after [%s]",
+		l.Component, lager.Level(l.Component), revertAfter)
+	l.Level = lager.Level(l.Component)
+	rep.WriteHeaderAndJson(http.StatusOK, l, restful.MIME_JSON)
+}
+
+// deleteLogLevel resets level of component given by query parameter, or global level if component is empty
+func deleteLogLevel(req *restful.Request, rep *restful.Response) {
+	component := req.QueryParameter("component")
+	if err := lager.ResetLevel(component); err != nil {
+		rep.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	rep.WriteHeaderAndJson(http.StatusOK, LogLevel{Component: component, Level: lager.Level(component)}, restful.MIME_JSON)
+}
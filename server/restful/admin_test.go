@@ -0,0 +1,81 @@
+package restful
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-chassis/go-chassis/core/archaius"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/core/registry/mock"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAdminContainer() *restful.Container {
+	p := os.Getenv("GOPATH")
+	os.Setenv("CHASSIS_HOME", filepath.Join(p, "src", "github.com", "go-chassis", "go-chassis", "examples", "discovery", "server"))
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	config.Init()
+	archaius.AddKeyValue("cse.admin.enable", true)
+	ws := new(restful.WebService)
+	registerAdminRoutes(ws)
+	c := restful.NewContainer()
+	c.Add(ws)
+	return c
+}
+
+func putStatus(c *restful.Container, status, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/admin/instance/status", bytes.NewBufferString(`{"status":"`+status+`"}`))
+	req.Header.Set("Content-Type", restful.MIME_JSON)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, req)
+	return w
+}
+
+func TestPutInstanceStatus(t *testing.T) {
+	c := newAdminContainer()
+	registry.DefaultRegistrator = new(mock.RegistratorMock)
+	runtime.InstanceID = "instanceID"
+	defer func() {
+		registry.DefaultRegistrator = nil
+		runtime.InstanceID = ""
+		runtime.InstanceStatus = ""
+	}()
+
+	t.Run("token is not configured", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, putStatus(c, "TESTING", "").Code)
+		assert.Equal(t, runtime.StatusRunning, registry.InstanceStatus())
+	})
+
+	archaius.AddKeyValue("cse.admin.token", "secret")
+	defer archaius.DeleteKeyValue("cse.admin.token", "secret")
+	t.Run("unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, putStatus(c, "TESTING", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, putStatus(c, "TESTING", "wrong").Code)
+		assert.Equal(t, runtime.StatusRunning, registry.InstanceStatus())
+	})
+	t.Run("authorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, putStatus(c, "DOWN", "secret").Code)
+		assert.Equal(t, http.StatusOK, putStatus(c, "testing", "secret").Code)
+		assert.Equal(t, runtime.StatusTesting, registry.InstanceStatus())
+	})
+}
+
+func TestGetInstanceStatus(t *testing.T) {
+	c := newAdminContainer()
+	// reading status does not require token
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/instance/status", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"UP"}`, w.Body.String())
+}
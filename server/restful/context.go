@@ -100,3 +100,9 @@ func (bs *Context) ReadResponseWriter() http.ResponseWriter {
 func (bs *Context) ReadRestfulResponse() *restful.Response {
 	return bs.resp
 }
+
+//ReadContext returns context of invocation after provider handler chain,
+//pass it to invoker so that handlers of consumer can read values saved by handlers of provider, such as caller token
+func (bs *Context) ReadContext() context.Context {
+	return bs.ctx
+}
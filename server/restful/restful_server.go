@@ -2,14 +2,18 @@ package restful
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"reflect"
 	"strings"
 	"sync"
 
+	"github.com/go-chassis/go-chassis/auth/jwt"
 	"github.com/go-chassis/go-chassis/core/archaius"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/config"
@@ -32,6 +36,8 @@ const (
 	MimeFile          = "application/octet-stream"
 	MimeMult          = "multipart/form-data"
 	SessionID         = ""
+	// HTTP2Protocol is alpn protocol of http2 over tls
+	HTTP2Protocol = "h2"
 )
 
 func init() {
@@ -63,6 +69,7 @@ func newRestfulServer(opts server.Options) server.ProtocolServer {
 		lager.Logger.Info("Enbaled metrics API on " + metricPath)
 		ws.Route(ws.GET(metricPath).To(metrics.HTTPHandleFunc))
 	}
+	registerAdminRoutes(ws)
 	return &restfulServer{
 		opts:      opts,
 		container: restful.NewContainer(),
@@ -119,6 +126,11 @@ func (r *restfulServer) Register(schema interface{}, options ...server.RegisterO
 		schemaName = tokens[len(tokens)-1]
 	}
 	lager.Logger.Infof("schema registered is [%s]", schemaName)
+	if scoped, ok := schema.(ScopedSchema); ok {
+		for funcName, scopes := range scoped.RouteScopes() {
+			jwt.RequireScopes(schemaName, funcName, scopes...)
+		}
+	}
 	for _, route := range routes {
 		lager.Logger.Infof("Add route path: [%s] Method: [%s] Func: [%s]. ", route.Path, route.Method, route.ResourceFuncName)
 		method, exist := schemaType.MethodByName(route.ResourceFuncName)
@@ -140,16 +152,29 @@ func (r *restfulServer) Register(schema interface{}, options ...server.RegisterO
 				lager.Logger.Errorf(err, "transfer http request to invocation failed")
 				return
 			}
+			if req.Request.TLS != nil {
+				if err := server.SetSourceIdentity(inv, req.Request.TLS, config.GetSSLVerifySourceService()); err != nil {
+					rep.AddHeader("Content-Type", "text/plain")
+					rep.WriteErrorString(http.StatusForbidden, err.Error())
+					return
+				}
+			}
 			//give inv.ctx to user handlers, user may inject headers in handler chain
 			bs := NewBaseServer(inv.Ctx)
 			bs.req = req
 			bs.resp = rep
 			c.Next(inv, func(ir *invocation.Response) error {
 				if ir.Err != nil {
+					// handler rejects the request, for example with 401 or 403
+					if ir.Status >= http.StatusBadRequest {
+						writeHandlerErr(rep, ir.Status, ir.Err)
+					}
 					return ir.Err
 				}
 				transfer(inv, req)
+				bs.ctx = inv.Ctx
 				method.Func.Call([]reflect.Value{schemaValue, reflect.ValueOf(bs)})
+				ir.Status = bs.resp.StatusCode()
 				if bs.resp.StatusCode() >= http.StatusBadRequest {
 					return fmt.Errorf("get err from http handle, get status: %d", bs.resp.StatusCode())
 				}
@@ -164,6 +189,21 @@ func (r *restfulServer) Register(schema interface{}, options ...server.RegisterO
 	}
 	return reflect.TypeOf(schema).String(), nil
 }
+
+// writeHandlerErr writes error of handler, error which can be marshaled, like contract violation, is written as json
+func writeHandlerErr(rep *restful.Response, status int, err error) {
+	if m, ok := err.(json.Marshaler); ok {
+		if b, e := m.MarshalJSON(); e == nil {
+			rep.AddHeader("Content-Type", common.JSON)
+			rep.WriteHeader(status)
+			rep.Write(b)
+			return
+		}
+	}
+	rep.AddHeader("Content-Type", "text/plain")
+	rep.WriteErrorString(status, err.Error())
+}
+
 func transfer(inv *invocation.Invocation, req *restful.Request) {
 	for k, v := range inv.Metadata {
 		req.SetAttribute(k, v.(string))
@@ -198,6 +238,12 @@ func (r *restfulServer) Start() error {
 	r.container.Add(r.ws)
 	if r.opts.TLSConfig != nil {
 		r.server = &http.Server{Addr: config.Address, Handler: r.container, TLSConfig: r.opts.TLSConfig}
+		// h2 is negotiated by default like ListenAndServeTLS, it is disabled if alpn protocols are configured without h2
+		if len(r.opts.TLSConfig.NextProtos) == 0 {
+			r.opts.TLSConfig.NextProtos = []string{HTTP2Protocol, "http/1.1"}
+		} else if !hasString(r.opts.TLSConfig.NextProtos, HTTP2Protocol) {
+			r.server.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+		}
 	} else {
 		r.server = &http.Server{Addr: config.Address, Handler: r.container}
 	}
@@ -221,7 +267,7 @@ func (r *restfulServer) Start() error {
 	swagger.RegisterSwaggerService(swaggerConfig, r.container)
 	go func() {
 		if r.server.TLSConfig != nil {
-			err = r.server.ListenAndServeTLS("", "")
+			err = r.serveTLS()
 		} else {
 			err = r.server.ListenAndServe()
 		}
@@ -236,9 +282,35 @@ func (r *restfulServer) Start() error {
 	return nil
 }
 
+// serveTLS serves with the tls config of options instead of the copy made by ListenAndServeTLS,
+// so that session ticket keys rotated by the cert reloader take effect
+func (r *restfulServer) serveTLS() error {
+	l, err := net.Listen("tcp", r.server.Addr)
+	if err != nil {
+		return err
+	}
+	return r.server.Serve(tls.NewListener(l, r.opts.TLSConfig))
+}
+
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *restfulServer) Stop() error {
-	//only golang 1.8 is support graceful shutdown.
-	if err := r.server.Shutdown(nil); err != nil {
+	return r.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests until ctx is done
+func (r *restfulServer) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Shutdown(ctx); err != nil {
 		return err // failure/timeout shutting down the server gracefully
 	}
 	return nil
@@ -12,6 +12,12 @@ type Route struct {
 	ResourceFuncName string //Resource function name
 }
 
+//ScopedSchema is implemented by schema which declares scopes of routes alongside URLPatterns,
+//key is ResourceFuncName of Route, jwt-provider handler rejects token without all of the scopes
+type ScopedSchema interface {
+	RouteScopes() map[string][]string
+}
+
 //GetRouteSpecs is to return a rest API specification of a go struct
 func GetRouteSpecs(schema interface{}) ([]Route, error) {
 	rfValue := reflect.ValueOf(schema)
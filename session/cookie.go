@@ -0,0 +1,205 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/security"
+)
+
+// versions of session cookie, cookie value is {version}.{base64 payload}.{base64 signature},
+// payload is {session id}|{endpoint}|{unix time of signing}, it is encrypted by cipher plugin in version e1
+const (
+	cookieVersion          = "v1"
+	encryptedCookieVersion = "e1"
+)
+
+// errors of session cookie
+var (
+	ErrInvalidCookie = errors.New("invalid session cookie")
+	ErrExpiredCookie = errors.New("session cookie expired")
+)
+
+// CookieCodec signs and verifies sticky session cookies, so that clients can not pin themselves
+// to an arbitrary endpoint or probe session store with forged cookies.
+// endpoint is embedded in cookie, stickiness survives when session store loses the session
+type CookieCodec struct {
+	secret []byte
+	cipher security.Cipher
+	maxAge time.Duration
+}
+
+// NewCookieCodec returns codec signing cookies with secret, cipher is optional,
+// cookies signed more than maxAge ago are rejected if maxAge is positive
+func NewCookieCodec(secret []byte, cipher security.Cipher, maxAge time.Duration) *CookieCodec {
+	return &CookieCodec{secret: secret, cipher: cipher, maxAge: maxAge}
+}
+
+// Encode returns signed cookie value of session bound to endpoint
+func (c *CookieCodec) Encode(sid, ep string) (string, error) {
+	payload := strings.Join([]string{sid, ep, strconv.FormatInt(time.Now().Unix(), 10)}, "|")
+	version := cookieVersion
+	if c.cipher != nil {
+		encrypted, err := c.cipher.Encrypt(payload)
+		if err != nil {
+			return "", err
+		}
+		payload = encrypted
+		version = encryptedCookieVersion
+	}
+	signed := version + "." + base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(c.mac(signed)), nil
+}
+
+// Decode verifies cookie value and returns session id and endpoint of it
+func (c *CookieCodec) Decode(value string) (sid, ep string, err error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return "", "", ErrInvalidCookie
+	}
+	signed := parts[0] + "." + parts[1]
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, c.mac(signed)) {
+		return "", "", ErrInvalidCookie
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", "", ErrInvalidCookie
+	}
+	payload := string(b)
+	switch parts[0] {
+	case cookieVersion:
+	case encryptedCookieVersion:
+		if c.cipher == nil {
+			return "", "", ErrInvalidCookie
+		}
+		if payload, err = c.cipher.Decrypt(payload); err != nil {
+			return "", "", ErrInvalidCookie
+		}
+	default:
+		return "", "", ErrInvalidCookie
+	}
+	fields := strings.Split(payload, "|")
+	if len(fields) != 3 || fields[0] == "" {
+		return "", "", ErrInvalidCookie
+	}
+	signedAt, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return "", "", ErrInvalidCookie
+	}
+	if c.maxAge > 0 && time.Since(time.Unix(signedAt, 0)) > c.maxAge {
+		return "", "", ErrExpiredCookie
+	}
+	return fields[0], fields[1], nil
+}
+
+func (c *CookieCodec) mac(s string) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+// cookieState is codec and attributes of session cookie in use
+type cookieState struct {
+	codec  *CookieCodec
+	config config.SessionCookie
+}
+
+var (
+	cookies atomic.Value
+	// randomSecret signs cookies if secret is not configured, cookies are invalid after process restarts
+	randomSecret []byte
+)
+
+func init() {
+	randomSecret = make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, randomSecret); err != nil {
+		panic(err)
+	}
+	cookies.Store(&cookieState{
+		codec: NewCookieCodec(randomSecret, nil, 0),
+		config: config.SessionCookie{
+			HTTPOnly: true,
+			SameSite: config.DefaultSessionCookieSameSite,
+			Path:     "/",
+		},
+	})
+}
+
+// RefreshCookieConfig reads config of session cookie under cse.loadbalance.SessionStickinessRule.cookie,
+// former config is kept if cipher plugin can not be created
+func RefreshCookieConfig() error {
+	c := config.GetSessionCookie()
+	secret := randomSecret
+	if c.Secret != "" {
+		secret = []byte(c.Secret)
+	}
+	var cipher security.Cipher
+	if c.Encrypt {
+		f, err := security.GetCipherNewFunc(c.CipherPlugin)
+		if err != nil {
+			return err
+		}
+		if cipher = f(); cipher == nil {
+			return fmt.Errorf("invalid cipher plugin [%s]", c.CipherPlugin)
+		}
+	}
+	cookies.Store(&cookieState{
+		codec:  NewCookieCodec(secret, cipher, time.Duration(c.MaxAge)*time.Second),
+		config: c,
+	})
+	return nil
+}
+
+func currentCookie() *cookieState {
+	return cookies.Load().(*cookieState)
+}
+
+// EncodeCookie returns signed cookie value of session bound to endpoint
+func EncodeCookie(sid, ep string) (string, error) {
+	return currentCookie().codec.Encode(sid, ep)
+}
+
+// DecodeCookie verifies cookie value, and returns session id and endpoint hint of it
+func DecodeCookie(value string) (sid, ep string, err error) {
+	return currentCookie().codec.Decode(value)
+}
+
+// NewCookie returns session cookie of value with attributes of config
+func NewCookie(value string) *http.Cookie {
+	c := currentCookie().config
+	return &http.Cookie{
+		Name:     common.LBSessionID,
+		Value:    value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		MaxAge:   c.MaxAge,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+		SameSite: sameSite(c.SameSite),
+	}
+}
+
+func sameSite(s string) http.SameSite {
+	switch strings.ToLower(s) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	}
+	return http.SameSiteDefaultMode
+}
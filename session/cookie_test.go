@@ -0,0 +1,162 @@
+package session_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/common"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/session"
+	"github.com/stretchr/testify/assert"
+)
+
+// reverseCipher is a cipher which is easy to check
+type reverseCipher struct{}
+
+func (reverseCipher) Encrypt(src string) (string, error) {
+	b := []byte(src)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b), nil
+}
+
+func (c reverseCipher) Decrypt(src string) (string, error) {
+	return c.Encrypt(src)
+}
+
+func TestCookieCodec(t *testing.T) {
+	c := session.NewCookieCodec([]byte("secret"), nil, 0)
+	v, err := c.Encode("abc", "127.0.0.1:8080")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(v, "v1."))
+	sid, ep, err := c.Decode(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", sid)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+
+	// tampered or forged cookies are rejected
+	forged, _ := session.NewCookieCodec([]byte("other"), nil, 0).Encode("abc", "10.0.0.1:8080")
+	for _, bad := range []string{"", "abc", v + "x", "v2" + v[2:], forged} {
+		_, _, err = c.Decode(bad)
+		assert.Equal(t, session.ErrInvalidCookie, err, bad)
+	}
+
+	// payload is not readable when cookie is encrypted
+	e := session.NewCookieCodec([]byte("secret"), reverseCipher{}, 0)
+	v, err = e.Encode("abc", "127.0.0.1:8080")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(v, "e1."))
+	sid, ep, err = e.Decode(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", sid)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+	_, _, err = c.Decode(v)
+	assert.Equal(t, session.ErrInvalidCookie, err)
+
+	expiring := session.NewCookieCodec([]byte("secret"), nil, time.Second)
+	v, _ = expiring.Encode("abc", "127.0.0.1:8080")
+	_, _, err = expiring.Decode(v)
+	assert.NoError(t, err)
+	time.Sleep(2100 * time.Millisecond)
+	_, _, err = expiring.Decode(v)
+	assert.Equal(t, session.ErrExpiredCookie, err)
+}
+
+func TestSaveSessionIDWithSignedCookie(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Add("Set-Cookie", common.LBSessionID+"=forged")
+	resp.Header.Add("Set-Cookie", "user=tom")
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1/", nil)
+	req.AddCookie(&http.Cookie{Name: common.LBSessionID, Value: "forged"})
+	session.SaveSessionIDFromHTTP("127.0.0.1:8080", 10, resp, req)
+
+	var c *http.Cookie
+	for _, rc := range resp.Cookies() {
+		if rc.Name == common.LBSessionID {
+			assert.Nil(t, c)
+			c = rc
+		}
+	}
+	assert.NotNil(t, c)
+	assert.True(t, c.HttpOnly)
+	assert.Equal(t, "/", c.Path)
+	assert.Equal(t, 2, len(resp.Header["Set-Cookie"]))
+	assert.Contains(t, resp.Header["Set-Cookie"][1], "SameSite=Lax")
+	sid, ep, err := session.DecodeCookie(c.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+	stored, ok := session.Get(sid)
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1:8080", stored)
+
+	// valid cookie keeps its session, and it is signed again so that it does not expire while in use
+	time.Sleep(1100 * time.Millisecond)
+	resp = &http.Response{Header: http.Header{}}
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1/", nil)
+	req.AddCookie(c)
+	session.SaveSessionIDFromHTTP("127.0.0.1:8080", 10, resp, req)
+	v := session.GetSessionFromResp(common.LBSessionID, resp)
+	assert.NotEqual(t, c.Value, v)
+	kept, ep, err := session.DecodeCookie(v)
+	assert.NoError(t, err)
+	assert.Equal(t, sid, kept)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+
+	// session is moved when endpoint changes
+	resp = &http.Response{Header: http.Header{}}
+	session.SaveSessionIDFromHTTP("127.0.0.1:8081", 10, resp, req)
+	moved, ep, err := session.DecodeCookie(session.GetSessionFromResp(common.LBSessionID, resp))
+	assert.NoError(t, err)
+	assert.Equal(t, sid, moved)
+	assert.Equal(t, "127.0.0.1:8081", ep)
+	stored, _ = session.Get(sid)
+	assert.Equal(t, "127.0.0.1:8081", stored)
+	session.Delete(sid)
+
+	ctx := session.SaveSessionIDFromContext(context.Background(), "127.0.0.1:9090", 10)
+	v = session.GetContextMetadata(ctx, common.LBSessionID)
+	sid, ep, err = session.DecodeCookie(strings.TrimPrefix(v, common.LBSessionID+"="))
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9090", ep)
+	assert.Equal(t, sid, session.SessionIDOfCookie(v))
+	session.DeleteSession(v)
+	_, ok = session.Get(sid)
+	assert.False(t, ok)
+}
+
+func TestEvictSession(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	// evicted cookie keeps session id without endpoint hint
+	ctx := session.SaveSessionIDFromContext(context.Background(), "127.0.0.1:9090", 10)
+	sid := session.SessionIDOfCookie(session.GetContextMetadata(ctx, common.LBSessionID))
+	ctx = session.DeleteSessionFromContext(ctx)
+	evicted, ep, err := session.DecodeCookie(strings.TrimPrefix(session.GetContextMetadata(ctx, common.LBSessionID), common.LBSessionID+"="))
+	assert.NoError(t, err)
+	assert.Equal(t, sid, evicted)
+	assert.Equal(t, "", ep)
+	_, ok := session.Get(sid)
+	assert.False(t, ok)
+
+	resp := &http.Response{Header: http.Header{}}
+	session.SaveSessionIDFromHTTP("127.0.0.1:8080", 10, resp, new(http.Request))
+	sid = session.SessionIDOfCookie(session.GetSessionFromResp(common.LBSessionID, resp))
+	session.DeletingKeySuccessiveFailure(resp)
+	assert.Equal(t, 1, len(resp.Header["Set-Cookie"]))
+	evicted, ep, err = session.DecodeCookie(session.GetSessionFromResp(common.LBSessionID, resp))
+	assert.NoError(t, err)
+	assert.Equal(t, sid, evicted)
+	assert.Equal(t, "", ep)
+	_, ok = session.Get(sid)
+	assert.False(t, ok)
+
+	// unsigned cookie is kept
+	ctx = session.SetContextMetadata(context.Background(), common.LBSessionID, common.LBSessionID+"=forged")
+	ctx = session.DeleteSessionFromContext(ctx)
+	assert.Equal(t, common.LBSessionID+"=forged", session.GetContextMetadata(ctx, common.LBSessionID))
+	assert.Equal(t, "", session.SessionIDOfCookie("forged"))
+}
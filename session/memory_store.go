@@ -0,0 +1,174 @@
+package session
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// defaults of memory store
+const (
+	DefaultShards          = 32
+	DefaultCleanupInterval = 30 * time.Second
+)
+
+// MemoryStore keeps sessions in sharded maps, so that sessions of different shards do not contend for a lock
+type MemoryStore struct {
+	shards []*shard
+	stop   chan struct{}
+	once   sync.Once
+}
+
+type shard struct {
+	mu    sync.RWMutex
+	items map[string]item
+}
+
+type item struct {
+	ep string
+	// expireAt is zero if item never expires
+	expireAt time.Time
+}
+
+func (i item) expired(now time.Time) bool {
+	return !i.expireAt.IsZero() && now.After(i.expireAt)
+}
+
+func expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// NewMemoryStore returns memory store with shards,
+// expired sessions are deleted every cleanupInterval if it is positive
+func NewMemoryStore(shards int, cleanupInterval time.Duration) *MemoryStore {
+	if shards <= 0 {
+		shards = DefaultShards
+	}
+	s := &MemoryStore{shards: make([]*shard, shards), stop: make(chan struct{})}
+	for i := range s.shards {
+		s.shards[i] = &shard{items: make(map[string]item)}
+	}
+	if cleanupInterval > 0 {
+		go s.cleanup(cleanupInterval)
+	}
+	return s
+}
+
+func newMemoryStore() (SessionStore, error) {
+	return NewMemoryStore(DefaultShards, DefaultCleanupInterval), nil
+}
+
+func (s *MemoryStore) cleanup(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.DeleteExpired()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Close stops deleting expired sessions in background
+func (s *MemoryStore) Close() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+func (s *MemoryStore) shard(sid string) *shard {
+	h := fnv.New32a()
+	h.Write([]byte(sid))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Save binds session to endpoint
+func (s *MemoryStore) Save(sid, ep string, ttl time.Duration) error {
+	sh := s.shard(sid)
+	sh.mu.Lock()
+	sh.items[sid] = item{ep: ep, expireAt: expireAt(ttl)}
+	sh.mu.Unlock()
+	return nil
+}
+
+// Get returns endpoint of session
+func (s *MemoryStore) Get(sid string) (string, bool, error) {
+	sh := s.shard(sid)
+	sh.mu.RLock()
+	i, ok := sh.items[sid]
+	sh.mu.RUnlock()
+	if !ok || i.expired(time.Now()) {
+		return "", false, nil
+	}
+	return i.ep, true, nil
+}
+
+// Delete deletes session
+func (s *MemoryStore) Delete(sid string) error {
+	sh := s.shard(sid)
+	sh.mu.Lock()
+	delete(sh.items, sid)
+	sh.mu.Unlock()
+	return nil
+}
+
+// Touch resets ttl of session
+func (s *MemoryStore) Touch(sid string, ttl time.Duration) (bool, error) {
+	sh := s.shard(sid)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	i, ok := sh.items[sid]
+	if !ok || i.expired(time.Now()) {
+		return false, nil
+	}
+	i.expireAt = expireAt(ttl)
+	sh.items[sid] = i
+	return true, nil
+}
+
+// Count returns number of sessions not expired
+func (s *MemoryStore) Count() int {
+	now := time.Now()
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for _, i := range sh.items {
+			if !i.expired(now) {
+				n++
+			}
+		}
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// DeleteByEndpoint deletes all sessions bound to endpoint
+func (s *MemoryStore) DeleteByEndpoint(ep string) error {
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+		for sid, i := range sh.items {
+			if i.ep == ep {
+				delete(sh.items, sid)
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return nil
+}
+
+// DeleteExpired deletes expired sessions
+func (s *MemoryStore) DeleteExpired() {
+	now := time.Now()
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+		for sid, i := range sh.items {
+			if i.expired(now) {
+				delete(sh.items, sid)
+			}
+		}
+		sh.mu.Unlock()
+	}
+}
@@ -1,50 +1,23 @@
 package session
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/rand"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/go-chassis/go-chassis/client/rest"
 	"github.com/go-chassis/go-chassis/core/common"
 	"github.com/go-chassis/go-chassis/core/lager"
 
 	"context"
-	cache "github.com/patrickmn/go-cache"
 )
 
 // ErrResponseNil used for to represent the error response, when it is nil
 var ErrResponseNil = errors.New("can not set session, resp is nil")
 
-// Cache session cache variable
-var Cache *cache.Cache
-
-func init() {
-	Cache = initCache()
-	cookieMap = make(map[string]string)
-}
-func initCache() *cache.Cache {
-	var value *cache.Cache
-
-	value = cache.New(3e+10, time.Second*30)
-	return value
-}
-
-var cookieMap map[string]string
-
-// getLBCookie gets cookie from local map
-func getLBCookie(key string) string {
-	return cookieMap[key]
-}
-
-// setLBCookie sets cookie to local map
-func setLBCookie(key, value string) {
-	cookieMap[key] = value
-}
-
 // GetContextMetadata gets data from context
 func GetContextMetadata(ctx context.Context, key string) string {
 	md, ok := ctx.Value(common.ContextHeaderKey{}).(map[string]string)
@@ -79,37 +52,51 @@ func GetSessionFromResp(cookieKey string, resp *http.Response) string {
 	return ""
 }
 
-// SaveSessionIDFromContext check session id in response ctx and save it to session storage
+// SaveSessionIDFromContext check session id in response ctx and save it to session storage,
+// signed cookie bound to ep is set to ctx
 func SaveSessionIDFromContext(ctx context.Context, ep string, autoTimeout int) context.Context {
-
 	timeValue := time.Duration(autoTimeout) * time.Second
+	cookie := strings.TrimPrefix(GetContextMetadata(ctx, common.LBSessionID), common.LBSessionID+"=")
+	value, err := bind(cookie, ep, timeValue)
+	if err != nil {
+		lager.Logger.Errorf(err, "encode session cookie failed")
+		return ctx
+	}
+	return SetContextMetadata(ctx, common.LBSessionID, common.LBSessionID+"="+value)
+}
 
-	sessionIDStr := GetContextMetadata(ctx, common.LBSessionID)
-	if sessionIDStr != "" {
-		cookieKey := strings.Split(string(sessionIDStr), "=")
-		if len(cookieKey) > 1 {
-			sessionIDStr = cookieKey[1]
+// bind binds session of cookie to ep and returns cookie value of the session.
+// cookie is signed again on every response, so that it does not expire while the session is in use,
+// a new session is created if cookie is absent or not signed by chassis
+func bind(cookie, ep string, timeOut time.Duration) (string, error) {
+	sid, _, err := DecodeCookie(cookie)
+	if err != nil {
+		if cookie != "" {
+			lager.Component(lager.ComponentLoadBalancer).Debugf("ignore session cookie, %v", err)
 		}
+		sid = generateCookieSessionID()
 	}
+	keep(sid, ep, timeOut)
+	return EncodeCookie(sid, ep)
+}
 
-	ClearExpired()
-	var sessBool bool
-	if sessionIDStr != "" {
-		_, sessBool = Cache.Get(sessionIDStr)
+// evict deletes session of cookie and returns cookie value of the session without endpoint hint,
+// so that the evicted endpoint is not picked again by the hint of cookie
+func evict(cookie string) (string, error) {
+	sid, _, err := DecodeCookie(strings.TrimPrefix(cookie, common.LBSessionID+"="))
+	if err != nil {
+		return "", err
 	}
+	Delete(sid)
+	return EncodeCookie(sid, "")
+}
 
-	if sessionIDStr != "" && sessBool {
-		cookie := common.LBSessionID + "=" + sessionIDStr
-		setLBCookie(common.LBSessionID, cookie)
-		Save(sessionIDStr, ep, timeValue)
-		return ctx
+// keep resets timeout of session and binds it to ep, session lost by store is saved again
+func keep(sid, ep string, timeOut time.Duration) {
+	if stored, ok := Get(sid); ok && stored == ep && Touch(sid, timeOut) {
+		return
 	}
-
-	sessionIDValue := generateCookieSessionID()
-	cookie := common.LBSessionID + "=" + sessionIDValue
-	setLBCookie(common.LBSessionID, cookie)
-	Save(sessionIDValue, ep, timeValue)
-	return SetContextMetadata(ctx, common.LBSessionID, cookie)
+	Save(sid, ep, timeOut)
 }
 
 //Temporary responsewriter for SetCookie
@@ -130,25 +117,25 @@ func (c cookieResponseWriter) WriteHeader(int) {
 	panic("ERROR")
 }
 
-//setCookie appends cookie with already present cookie with ';' in between
+//setCookie sets signed session cookie to resp, session cookie set by provider is replaced
 func setCookie(resp *http.Response, value string) {
-	Resp := rest.Response{Resp: resp}
-
-	newCookie := common.LBSessionID + "=" + value
-	oldCookie := string(Resp.GetCookie(common.LBSessionID))
-
-	if oldCookie != "" {
-		//If cookie is already set, append it with ';'
-		newCookie = newCookie + ";" + oldCookie
+	if resp.Header == nil {
+		resp.Header = http.Header{}
 	}
-
-	c1 := http.Cookie{Name: common.LBSessionID, Value: newCookie}
+	var others []string
+	for _, line := range resp.Header["Set-Cookie"] {
+		if !strings.HasPrefix(strings.TrimSpace(line), common.LBSessionID+"=") {
+			others = append(others, line)
+		}
+	}
+	resp.Header["Set-Cookie"] = others
 
 	w := cookieResponseWriter(resp.Header)
-	http.SetCookie(w, &c1)
+	http.SetCookie(w, NewCookie(value))
 }
 
-// SaveSessionIDFromHTTP check session id
+// SaveSessionIDFromHTTP check session id in cookie of req, and sets signed cookie bound to ep to resp.
+// cookie which is not signed by chassis is ignored, and a new session is created
 func SaveSessionIDFromHTTP(ep string, autoTimeout int, resp *http.Response, req *http.Request) {
 	if resp == nil {
 		lager.Logger.Warnf("", ErrResponseNil)
@@ -157,76 +144,68 @@ func SaveSessionIDFromHTTP(ep string, autoTimeout int, resp *http.Response, req
 
 	timeValue := time.Duration(autoTimeout) * time.Second
 
-	var sessionIDStr string
-
-	if c, err := req.Cookie(common.LBSessionID); err == http.ErrNoCookie {
-		sessionIDStr = ""
-	} else {
-		sessionIDStr = c.Value
+	var cookie string
+	if c, err := req.Cookie(common.LBSessionID); err == nil {
+		cookie = c.Value
 	}
-
-	ClearExpired()
-	var sessBool bool
-	if sessionIDStr != "" {
-		_, sessBool = Cache.Get(sessionIDStr)
-	}
-
-	valueChassisLb := GetSessionFromResp(common.LBSessionID, resp)
-	//if session is in resp, then just save it
-	if string(valueChassisLb) != "" {
-		Save(valueChassisLb, ep, timeValue)
-	} else if sessionIDStr != "" && sessBool {
-		setCookie(resp, sessionIDStr)
-		Save(sessionIDStr, ep, timeValue)
-	} else {
-		sessionIDValue := generateCookieSessionID()
-		setCookie(resp, sessionIDValue)
-		Save(sessionIDValue, ep, timeValue)
-
+	value, err := bind(cookie, ep, timeValue)
+	if err != nil {
+		lager.Logger.Errorf(err, "encode session cookie failed")
+		return
 	}
-
+	setCookie(resp, value)
 }
 
-// generateCookieSessionID generate cookies for session id
+// generateCookieSessionID generate random uuid for session id
 func generateCookieSessionID() string {
-
 	result := make([]byte, 16)
-
-	rand.Seed(time.Now().UTC().UnixNano())
-	tmp := rand.Int63()
-	rand.Seed(tmp)
-	for i := 0; i < 16; i++ {
-		result[i] = byte(rand.Intn(16))
+	if _, err := io.ReadFull(rand.Reader, result); err != nil {
+		panic(err)
 	}
 
 	result[6] = (result[6] & 0xF) | (4 << 4)
 	result[8] = (result[8] | 0x40) & 0x7F
 
 	return fmt.Sprintf("%x-%x-%x-%x-%x", result[0:4], result[4:6], result[6:8], result[8:10], result[10:])
-
 }
 
-// DeletingKeySuccessiveFailure deleting key successes and failures
+// DeletingKeySuccessiveFailure deletes session of cookie in resp after successive failures,
+// cookie without endpoint hint is set to resp. session of highway is in context, it is deleted by DeleteSessionFromContext
 func DeletingKeySuccessiveFailure(resp *http.Response) {
-	Cache.DeleteExpired()
 	if resp == nil {
-		valueChassisLb := getLBCookie(common.LBSessionID)
-		if string(valueChassisLb) != "" {
-			cookieKey := strings.Split(string(valueChassisLb), "=")
-			if len(cookieKey) > 1 {
-				Delete(cookieKey[1])
-				setLBCookie(common.LBSessionID, "")
-			}
-		}
 		return
 	}
+	value, err := evict(GetSessionFromResp(common.LBSessionID, resp))
+	if err != nil {
+		return
+	}
+	setCookie(resp, value)
+}
 
-	valueChassisLb := GetSessionFromResp(common.LBSessionID, resp)
-	if string(valueChassisLb) != "" {
-		cookieKey := strings.Split(string(valueChassisLb), "=")
-		if len(cookieKey) > 1 {
-			Delete(cookieKey[1])
-		}
+// DeleteSessionFromContext deletes session of cookie in ctx after successive failures,
+// cookie without endpoint hint is set to the returned ctx
+func DeleteSessionFromContext(ctx context.Context) context.Context {
+	value, err := evict(GetContextMetadata(ctx, common.LBSessionID))
+	if err != nil {
+		return ctx
+	}
+	return SetContextMetadata(ctx, common.LBSessionID, common.LBSessionID+"="+value)
+}
+
+// SessionIDOfCookie returns session id of signed cookie value, with or without go-chassisLB= prefix,
+// it is empty if cookie is not signed by chassis
+func SessionIDOfCookie(cookie string) string {
+	sid, _, err := DecodeCookie(strings.TrimPrefix(cookie, common.LBSessionID+"="))
+	if err != nil {
+		return ""
+	}
+	return sid
+}
+
+// DeleteSession deletes session of signed cookie value, with or without go-chassisLB= prefix
+func DeleteSession(cookie string) {
+	if sid := SessionIDOfCookie(cookie); sid != "" {
+		Delete(sid)
 	}
 }
 
@@ -2,25 +2,68 @@ package session
 
 import (
 	"time"
+
+	"github.com/go-chassis/go-chassis/core/lager"
 )
 
 // Save for setting the session uuid, endpoint, timeout
 func Save(sid string, ep string, timeOut time.Duration) {
-	Cache.Set(sid, ep, timeOut)
+	if err := GetStore().Save(sid, ep, timeOut); err != nil {
+		lager.Logger.Errorf(err, "save session [%s] failed", sid)
+	}
 }
 
 // Get return endpoint based on session uuid
 func Get(sid string) (ep interface{}, ok bool) {
-	ep, ok = Cache.Get(sid)
-	return
+	s, ok, err := GetStore().Get(sid)
+	if err != nil {
+		lager.Logger.Errorf(err, "get session [%s] failed", sid)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	return s, true
+}
+
+// Touch resets timeout of session, it returns false if session does not exist
+func Touch(sid string, timeOut time.Duration) bool {
+	ok, err := GetStore().Touch(sid, timeOut)
+	if err != nil {
+		lager.Logger.Errorf(err, "touch session [%s] failed", sid)
+		return false
+	}
+	return ok
 }
 
-//ClearExpired delete all expired session
+//ClearExpired delete all expired session, it does nothing if store deletes expired sessions by itself
 func ClearExpired() {
-	Cache.DeleteExpired()
+	if c, ok := GetStore().(ExpiredCleaner); ok {
+		c.DeleteExpired()
+	}
+}
+
+// Count returns number of sessions which are not expired, it is 0 if store can not count sessions
+func Count() int {
+	if c, ok := GetStore().(Counter); ok {
+		return c.Count()
+	}
+	return 0
 }
 
 // Delete delete the session uuid
 func Delete(sid string) {
-	Cache.Delete(sid)
+	if err := GetStore().Delete(sid); err != nil {
+		lager.Logger.Errorf(err, "delete session [%s] failed", sid)
+	}
+}
+
+// DeleteByEndpoint delete all sessions bound to the endpoint, sessions of store which can not delete
+// by endpoint are kept, and the strategy picks another instance for them
+func DeleteByEndpoint(ep string) {
+	if d, ok := GetStore().(EndpointDeleter); ok {
+		if err := d.DeleteByEndpoint(ep); err != nil {
+			lager.Logger.Errorf(err, "delete sessions of endpoint [%s] failed", ep)
+		}
+	}
 }
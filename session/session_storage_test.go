@@ -12,6 +12,7 @@ import (
 
 func TestSessionStorage(t *testing.T) {
 	session.Save("abc", "127.0.0.1:8080", time.Second)
+	assert.Equal(t, 1, session.Count())
 	addr, ok := session.Get("abc")
 	assert.Equal(t, true, ok)
 	assert.Equal(t, "127.0.0.1:8080", addr)
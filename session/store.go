@@ -0,0 +1,96 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryStoreName is name of default session store keeping sessions in memory
+const MemoryStoreName = "memory"
+
+// SessionStore keeps endpoints bound to session ids, a session expires after ttl unless it is saved or touched again.
+// it must be safe for concurrent use. an external store, such as redis, keeps stickiness
+// when process restarts or requests land on other replicas
+type SessionStore interface {
+	// Save binds session to endpoint with ttl, zero ttl means the session never expires
+	Save(sid, ep string, ttl time.Duration) error
+	// Get returns endpoint of session, ok is false if session does not exist or expires
+	Get(sid string) (ep string, ok bool, err error)
+	Delete(sid string) error
+	// Touch resets ttl of session, it returns false if session does not exist or expires
+	Touch(sid string, ttl time.Duration) (bool, error)
+}
+
+// Counter is implemented by store which counts sessions not expired
+type Counter interface {
+	Count() int
+}
+
+// EndpointDeleter is implemented by store which deletes all sessions bound to an endpoint,
+// it is used when an instance goes down
+type EndpointDeleter interface {
+	DeleteByEndpoint(ep string) error
+}
+
+// ExpiredCleaner is implemented by store which deletes expired sessions on demand
+type ExpiredCleaner interface {
+	DeleteExpired()
+}
+
+var stores = make(map[string]func() (SessionStore, error))
+
+//InstallPlugin install session store plugin
+func InstallPlugin(name string, f func() (SessionStore, error)) {
+	stores[name] = f
+}
+
+//GetPlugin return session store plugin
+func GetPlugin(name string) func() (SessionStore, error) {
+	return stores[name]
+}
+
+var (
+	store   SessionStore
+	storeMu sync.RWMutex
+)
+
+// Init replaces session store with store plugin of name
+func Init(name string) error {
+	if name == "" {
+		name = MemoryStoreName
+	}
+	f := GetPlugin(name)
+	if f == nil {
+		return fmt.Errorf("unknown session store plugin [%s]", name)
+	}
+	s, err := f()
+	if err != nil {
+		return fmt.Errorf("create session store [%s] failed, %v", name, err)
+	}
+	SetStore(s)
+	return nil
+}
+
+// SetStore replaces session store, sessions of former store are not moved
+func SetStore(s SessionStore) {
+	storeMu.Lock()
+	old := store
+	store = s
+	storeMu.Unlock()
+	if c, ok := old.(*MemoryStore); ok && old != s {
+		c.Close()
+	}
+}
+
+// GetStore returns session store in use
+func GetStore() SessionStore {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+	return store
+}
+
+func init() {
+	InstallPlugin(MemoryStoreName, newMemoryStore)
+	store = NewMemoryStore(DefaultShards, DefaultCleanupInterval)
+}
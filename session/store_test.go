@@ -0,0 +1,138 @@
+package session_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := session.NewMemoryStore(4, 0)
+	defer s.Close()
+
+	assert.NoError(t, s.Save("a", "127.0.0.1:8080", time.Hour))
+	assert.NoError(t, s.Save("b", "127.0.0.1:8081", 0))
+	assert.NoError(t, s.Save("c", "127.0.0.1:8080", time.Millisecond))
+	ep, ok, err := s.Get("a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+
+	time.Sleep(5 * time.Millisecond)
+	_, ok, _ = s.Get("c")
+	assert.False(t, ok)
+	ok, _ = s.Touch("c", time.Hour)
+	assert.False(t, ok)
+	assert.Equal(t, 2, s.Count())
+	s.DeleteExpired()
+	assert.Equal(t, 2, s.Count())
+
+	// touch extends ttl
+	assert.NoError(t, s.Save("d", "127.0.0.1:8082", 20*time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+	ok, _ = s.Touch("d", time.Hour)
+	assert.True(t, ok)
+	time.Sleep(15 * time.Millisecond)
+	_, ok, _ = s.Get("d")
+	assert.True(t, ok)
+
+	assert.NoError(t, s.DeleteByEndpoint("127.0.0.1:8080"))
+	_, ok, _ = s.Get("a")
+	assert.False(t, ok)
+	assert.NoError(t, s.Delete("b"))
+	_, ok, _ = s.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 1, s.Count())
+}
+
+func TestMemoryStoreConcurrency(t *testing.T) {
+	s := session.NewMemoryStore(session.DefaultShards, time.Millisecond)
+	defer s.Close()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				sid := strconv.Itoa(g*1000 + i)
+				s.Save(sid, "ep"+strconv.Itoa(g), time.Minute)
+				s.Get(sid)
+				s.Touch(sid, time.Minute)
+				s.Count()
+			}
+			s.DeleteByEndpoint("ep" + strconv.Itoa(g))
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, s.Count())
+}
+
+// externalStore only implements SessionStore
+type externalStore struct {
+	m map[string]string
+}
+
+func (s *externalStore) Save(sid, ep string, ttl time.Duration) error {
+	s.m[sid] = ep
+	return nil
+}
+
+func (s *externalStore) Get(sid string) (string, bool, error) {
+	if sid == "broken" {
+		return "", false, errors.New("connection refused")
+	}
+	ep, ok := s.m[sid]
+	return ep, ok, nil
+}
+
+func (s *externalStore) Delete(sid string) error {
+	delete(s.m, sid)
+	return nil
+}
+
+func (s *externalStore) Touch(sid string, ttl time.Duration) (bool, error) {
+	_, ok := s.m[sid]
+	return ok, nil
+}
+
+func TestSessionStorePlugin(t *testing.T) {
+	lager.Initialize("", "INFO", "", "size", true, 1, 10, 7)
+	defer session.Init(session.MemoryStoreName)
+	assert.Error(t, session.Init("unknown"))
+
+	external := &externalStore{m: make(map[string]string)}
+	session.InstallPlugin("external", func() (session.SessionStore, error) {
+		return external, nil
+	})
+	assert.NoError(t, session.Init("external"))
+	assert.Equal(t, external, session.GetStore())
+
+	session.Save("abc", "127.0.0.1:8080", time.Second)
+	assert.Equal(t, "127.0.0.1:8080", external.m["abc"])
+	ep, ok := session.Get("abc")
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1:8080", ep)
+	assert.True(t, session.Touch("abc", time.Second))
+	_, ok = session.Get("broken")
+	assert.False(t, ok)
+
+	// optional operations are skipped
+	assert.Equal(t, 0, session.Count())
+	session.DeleteByEndpoint("127.0.0.1:8080")
+	session.ClearExpired()
+	assert.Equal(t, 1, len(external.m))
+
+	// unsigned cookie is ignored
+	session.DeleteSession("go-chassisLB=abc")
+	assert.Equal(t, 1, len(external.m))
+	cookie, err := session.EncodeCookie("abc", "127.0.0.1:8080")
+	assert.NoError(t, err)
+	session.DeleteSession("go-chassisLB=" + cookie)
+	assert.Equal(t, 0, len(external.m))
+}
@@ -0,0 +1,117 @@
+package chassis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-chassis/core/config"
+	"github.com/go-chassis/go-chassis/core/lager"
+	"github.com/go-chassis/go-chassis/core/registry"
+	"github.com/go-chassis/go-chassis/core/server"
+	"github.com/go-chassis/go-chassis/pkg/runtime"
+	"github.com/go-chassis/go-chassis/pkg/shutdown"
+)
+
+var (
+	shutdownOnce sync.Once
+	shutdownErr  error
+	// shutdownDone is closed when graceful shutdown finished
+	shutdownDone = make(chan struct{})
+)
+
+// RunOptions is options of Run
+type RunOptions struct {
+	// SignalHandler makes Run handle os signals, it is true by default
+	SignalHandler bool
+}
+
+// RunOption is used to set RunOptions
+type RunOption func(*RunOptions)
+
+// WithoutSignalHandler let user handle os signals, user must call Shutdown to stop go chassis
+func WithoutSignalHandler() RunOption {
+	return func(o *RunOptions) {
+		o.SignalHandler = false
+	}
+}
+
+// RegisterShutdownHook registers user cleanup function, it runs after servers are drained
+// and before instance is unregistered, the context is done when shutdown timeout exceeded
+func RegisterShutdownHook(name string, f func(ctx context.Context) error) {
+	shutdown.RegisterHook(name, shutdown.Hook(f))
+}
+
+// Shutdown stops go chassis gracefully, it only runs once, later calls wait and return the same result.
+// the sequence is: mark instance DOWN or OUTOFSERVICE in registry, wait for status propagation,
+// stop accepting connections and drain in-flight requests, flush tracing and metrics,
+// run user hooks, then unregister instance
+func Shutdown(ctx context.Context) error {
+	shutdownOnce.Do(func() {
+		shutdownErr = goChassis.shutdown(ctx)
+		close(shutdownDone)
+	})
+	<-shutdownDone
+	return shutdownErr
+}
+
+// gracefulShutdown runs shutdown with configured timeout
+func gracefulShutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.GetShutdownTimeout())
+	defer cancel()
+	return Shutdown(ctx)
+}
+
+func (c *chassis) shutdown(ctx context.Context) error {
+	registered := !config.GetRegistratorDisable() && registry.DefaultRegistrator != nil && runtime.InstanceID != ""
+	if registered {
+		markInstanceDown()
+		waitPropagation(ctx, config.GetShutdownPropagationDelay())
+	}
+
+	err := server.StopServers(ctx)
+	shutdown.Flush(ctx)
+	shutdown.RunHooks(ctx)
+
+	if registered {
+		registry.HBService.Stop()
+		if e := server.UnRegistrySelfInstances(); e != nil {
+			lager.Logger.Errorf(e, "servers failed to unregister")
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		lager.Logger.Warnf("graceful shutdown not completed in time: %s", ctx.Err())
+		if err == nil {
+			err = errors.New("graceful shutdown timeout")
+		}
+	}
+	lager.Logger.Info("go chassis shutdown")
+	return err
+}
+
+// markInstanceDown tells consumers not to send new requests to this instance
+func markInstanceDown() {
+	status := config.GetShutdownStatus()
+	err := registry.DefaultRegistrator.UpdateMicroServiceInstanceStatus(runtime.ServiceID, runtime.InstanceID, status)
+	if err != nil {
+		lager.Logger.Errorf(err, "update instance status to %s failed", status)
+		return
+	}
+	runtime.InstanceStatus = status
+	lager.Logger.Infof("instance status is %s, sid/iid: %s/%s", status, runtime.ServiceID, runtime.InstanceID)
+}
+
+func waitPropagation(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	lager.Logger.Infof("wait %s for instance status propagation", d)
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
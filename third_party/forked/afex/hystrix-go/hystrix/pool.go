@@ -40,3 +40,17 @@ func (p *executorPool) Return(ticket *struct{}) {
 func (p *executorPool) ActiveCount() int {
 	return p.Max - len(p.Tickets)
 }
+
+// PoolUtilization returns active requests divided by max concurrent requests of every circuit
+func PoolUtilization() map[string]float64 {
+	circuitBreakersMutex.RLock()
+	defer circuitBreakersMutex.RUnlock()
+	u := make(map[string]float64, len(circuitBreakers))
+	for name, cb := range circuitBreakers {
+		if cb.executorPool == nil || cb.executorPool.Max == 0 {
+			continue
+		}
+		u[name] = float64(cb.executorPool.ActiveCount()) / float64(cb.executorPool.Max)
+	}
+	return u
+}